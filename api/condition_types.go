@@ -32,17 +32,46 @@ const (
 	SucceedConditionType ConditionType = "Succeed"
 	// BuiltConditionType describes the condition of a resource that needs to be build.
 	BuiltConditionType ConditionType = "Built"
+	// PropertiesValidConditionType describes whether the application properties of the workflow can be parsed
+	PropertiesValidConditionType ConditionType = "PropertiesValid"
+	// RegistryReachableConditionType describes whether the container registry configured in the platform can be reached
+	RegistryReachableConditionType ConditionType = "RegistryReachable"
+	// CacheWarmedConditionType describes whether the builder cache of the platform, like the Kaniko one, is warmed up
+	CacheWarmedConditionType ConditionType = "CacheWarmed"
+	// BuilderConfigValidConditionType describes whether the builder configuration of the platform is valid
+	BuilderConfigValidConditionType ConditionType = "BuilderConfigValid"
 )
 
 const (
-	WaitingForDeploymentReason  = "WaitingForDeployment"
-	DeploymentFailureReason     = "DeploymentFailure"
-	DeploymentUnavailableReason = "DeploymentIsUnavailable"
-	RedeploymentExhaustedReason = "AttemptToRedeployFailed"
-	WaitingForPlatformReason    = "WaitingForPlatform"
-	BuildFailedReason           = "BuildFailedReason"
-	WaitingForBuildReason       = "WaitingForBuild"
-	BuildIsRunningReason        = "BuildIsRunning"
+	WaitingForDeploymentReason    = "WaitingForDeployment"
+	DeploymentFailureReason       = "DeploymentFailure"
+	DeploymentUnavailableReason   = "DeploymentIsUnavailable"
+	RedeploymentExhaustedReason   = "AttemptToRedeployFailed"
+	WaitingForPlatformReason      = "WaitingForPlatform"
+	BuildFailedReason             = "BuildFailedReason"
+	WaitingForBuildReason         = "WaitingForBuild"
+	BuildIsRunningReason          = "BuildIsRunning"
+	DevModeBuildErrorReason       = "DevModeBuildError"
+	PropertiesParseFailedReason   = "PropertiesParseFailed"
+	DuplicatedPlatformReason      = "DuplicatedPlatform"
+	WarmingCacheReason            = "WarmingCache"
+	CacheWarmingFailedReason      = "CacheWarmingFailed"
+	CacheDisabledReason           = "CacheDisabled"
+	RegistryNotVerifiedReason     = "RegistryNotVerified"
+	RegistryConfigInvalidReason   = "RegistryConfigInvalid"
+	RegistryUnreachableReason     = "RegistryUnreachable"
+	RegistryUnauthorizedReason    = "RegistryUnauthorized"
+	RegistryPushDeniedReason      = "RegistryPushDenied"
+	BuilderConfigInvalidReason    = "BuilderConfigInvalid"
+	ClusterPlatformNotFoundReason = "ClusterPlatformNotFound"
+	ImagePromotingReason          = "ImagePromoting"
+	ImagePromotedReason           = "ImagePromoted"
+	ImagePromotionFailedReason    = "ImagePromotionFailed"
+	PrebuiltImageValidatedReason  = "PrebuiltImageValidated"
+	PrebuiltImageInvalidReason    = "PrebuiltImageInvalid"
+	ImageSourceConflictReason     = "ImageSourceConflict"
+	BuildConfigInvalidReason      = "BuildConfigInvalid"
+	NetworkConfigInvalidReason    = "NetworkConfigInvalid"
 )
 
 // Condition describes the common structure for conditions in our types
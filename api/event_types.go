@@ -0,0 +1,30 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// Reasons of the Kubernetes Events emitted for the operator resources.
+// They're part of the API, so clients can rely on them to filter the events.
+const (
+	BuildStartedEventReason     = "BuildStarted"
+	BuildSucceededEventReason   = "BuildSucceeded"
+	BuildFailedEventReason      = "BuildFailed"
+	ImagePromotedEventReason    = "ImagePromoted"
+	ImageRejectedEventReason    = "ImageRejected"
+	DeployedEventReason         = "Deployed"
+	DeploymentFailedEventReason = "DeploymentFailed"
+	RecoveryAttemptEventReason  = "RecoveryAttempt"
+	PlatformReadyEventReason    = "PlatformReady"
+	PlatformErrorEventReason    = "PlatformError"
+)
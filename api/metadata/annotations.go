@@ -27,7 +27,23 @@ const (
 	Label                       = Domain + "/label"
 	Profile                     = Domain + "/profile"
 	SecondaryPlatformAnnotation = Domain + "/secondary.platform"
-	OperatorIDAnnotation        = Domain + "/operator.id"
+	// PlatformAnnotation selects the Platform used to build and deploy a workflow, instead of the active one of its namespace
+	PlatformAnnotation   = Domain + "/platform"
+	OperatorIDAnnotation = Domain + "/operator.id"
+	// ManagedPropertiesAnnotation lists the application properties managed by the operator in the workflow properties ConfigMap,
+	// along with their strategy
+	ManagedPropertiesAnnotation = Domain + "/managed.properties"
+	// UserPropertiesAnnotation is the comma separated list of application properties in the workflow properties ConfigMap
+	// owned by the user, the operator never sets them
+	UserPropertiesAnnotation = Domain + "/user.properties"
+	// PlatformConfigurationChecksumAnnotation is the checksum of the platform configuration applied to the workflow pod template
+	PlatformConfigurationChecksumAnnotation = Domain + "/platform.configuration.checksum"
+	// SecretPropertiesChecksumAnnotation is the checksum of the workflow Secrets applied to the workflow pod template
+	SecretPropertiesChecksumAnnotation = Domain + "/secret.properties.checksum"
+	// PersistenceChecksumAnnotation is the checksum of the workflow persistence applied to the workflow pod template
+	PersistenceChecksumAnnotation = Domain + "/persistence.checksum"
+	// QuarkusExtensionsAnnotation is the comma separated list of Quarkus extensions the workflow build adds to the application
+	QuarkusExtensionsAnnotation = Domain + "/quarkus.extensions"
 	// TODO: is this the right value?
 	ServiceType = Domain + "/name"
 )
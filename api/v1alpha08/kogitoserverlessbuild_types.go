@@ -35,6 +35,8 @@ const (
 	BuildPhasePending BuildPhase = "Pending"
 	// BuildPhaseRunning --
 	BuildPhaseRunning BuildPhase = "Running"
+	// BuildPhaseAttesting the image is pushed, its SBOM and signature are being attached to it
+	BuildPhaseAttesting BuildPhase = "Attesting"
 	// BuildPhaseSucceeded --
 	BuildPhaseSucceeded BuildPhase = "Succeeded"
 	// BuildPhaseFailed --
@@ -45,6 +47,46 @@ const (
 	BuildPhaseError BuildPhase = "Error"
 )
 
+// IsFinished checks if the build reached a final phase
+func (p BuildPhase) IsFinished() bool {
+	return p == BuildPhaseSucceeded || p == BuildPhaseError || p == BuildPhaseFailed
+}
+
+// BuildMode is how the workflow application is compiled
+// +kubebuilder:validation:Enum=jvm;native
+type BuildMode string
+
+const (
+	// BuildModeJVM builds the workflow application running on the JVM
+	BuildModeJVM BuildMode = "jvm"
+	// BuildModeNative compiles the workflow application into a GraalVM native executable, for a faster start-up and a
+	// lower memory footprint. The build takes longer and requires more resources.
+	BuildModeNative BuildMode = "native"
+)
+
+// SBOMFormat the format of the Software Bill of Materials generated for the workflow image
+// +kubebuilder:validation:Enum=spdx;cyclonedx
+type SBOMFormat string
+
+const (
+	// SBOMFormatSPDX the SPDX JSON format
+	SBOMFormatSPDX SBOMFormat = "spdx"
+	// SBOMFormatCycloneDX the CycloneDX JSON format
+	SBOMFormatCycloneDX SBOMFormat = "cyclonedx"
+)
+
+// AttestationSpec how the workflow image is attested once pushed. The SBOM and the signature are attached to the image
+// in the registry, with cosign compatible references.
+type AttestationSpec struct {
+	// SBOM the format of the Software Bill of Materials generated for the image, none is generated when empty
+	// +optional
+	SBOM SBOMFormat `json:"sbom,omitempty"`
+	// SigningKeySecretRef the Secret holding the cosign private key, as `cosign.key`, and its password, as `cosign.password`.
+	// The image and its SBOM are signed with this key, they aren't signed when not set.
+	// +optional
+	SigningKeySecretRef *corev1.LocalObjectReference `json:"signingKeySecretRef,omitempty"`
+}
+
 type BuildTemplate struct {
 	// Timeout defines the Build maximum execution duration.
 	// The Build deadline is set to the Build start time plus the Timeout duration.
@@ -56,6 +98,43 @@ type BuildTemplate struct {
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 	// Arguments lists the command line arguments to send to the builder
 	Arguments []string `json:"arguments,omitempty"`
+	// Dockerfile template used to build the workflows instead of the operator's default one
+	// +optional
+	Dockerfile *DockerfileTemplateSpec `json:"dockerfile,omitempty"`
+	// Extensions Quarkus extensions added to the workflows, as `groupId:artifactId[:version]` coordinates
+	// +optional
+	Extensions []string `json:"extensions,omitempty"`
+	// Addons Kogito add-ons added to the workflows, by name. E.g. `kafka`, `knative-eventing` or `kubernetes`.
+	// The add-on extensions are added to the build and its application properties to the workflow deployment.
+	// +optional
+	Addons []string `json:"addons,omitempty"`
+	// Mode how the workflows are compiled, `jvm` by default.
+	// The `native` mode defaults to larger build resources and timeout when they aren't set.
+	// +optional
+	Mode BuildMode `json:"mode,omitempty"`
+	// Platforms the `os/arch[/variant]` platforms the workflow images are built for, e.g. `linux/amd64` and `linux/arm64`.
+	// With more than one platform, an image is built per platform on a node of that platform, then referenced by an
+	// OCI image index pushed with the workflow image tag. Multiple platforms require the `operator` build strategy.
+	// The per-platform builds run in parallel, so they use the Kaniko cache repository but not the cache volume.
+	// +optional
+	Platforms []string `json:"platforms,omitempty"`
+	// Attestation generates the SBOM of the workflow images and signs them once pushed.
+	// It requires the `operator` build strategy and a single platform, otherwise the workflow isn't built and reports a BuildConfigInvalid condition.
+	// +optional
+	Attestation *AttestationSpec `json:"attestation,omitempty"`
+}
+
+// DockerfileTemplateSpec references a Dockerfile rendered as a Go template before the build.
+// The template can use the `.BaseImage`, `.Mode`, `.QuarkusExtensions` and `.BuildArgs` of the build and the given `.Vars`.
+type DockerfileTemplateSpec struct {
+	// ConfigMapRef the ConfigMap holding the template, in the namespace of the workflow
+	ConfigMapRef corev1.LocalObjectReference `json:"configMapRef"`
+	// Key of the template in the ConfigMap, `Dockerfile` by default
+	// +optional
+	Key string `json:"key,omitempty"`
+	// Vars variables available to the template as `.Vars`
+	// +optional
+	Vars map[string]string `json:"vars,omitempty"`
 }
 
 // KogitoServerlessBuildSpec an abstraction over the actual build process performed by the platform.
@@ -71,11 +150,53 @@ type KogitoServerlessBuildStatus struct {
 	BuildPhase BuildPhase `json:"buildPhase,omitempty"`
 	// Last error found during build
 	Error string `json:"error,omitempty"`
+	// ImageDigest the digest of the built image, the one of the OCI image index for multi-platform builds
+	// +optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// Platforms the images built for each platform of a multi-platform build
+	// +optional
+	Platforms []PlatformImageStatus `json:"platforms,omitempty"`
+	// Attestation references the SBOM and the signature attached to the image, when attested
+	// +optional
+	Attestation *BuildAttestationStatus `json:"attestation,omitempty"`
+	// StartTime when the build was scheduled
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime when the build reached a final phase
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 	// InnerBuild is a reference to an internal build object, which can be anything known only to internal builders.
 	// +kubebuilder:pruning:PreserveUnknownFields
 	InnerBuild runtime.RawExtension `json:"innerBuild,omitempty" patchStrategy:"replace"`
 }
 
+// PlatformImageStatus the image built for a platform of a multi-platform build
+type PlatformImageStatus struct {
+	// Platform the `os/arch[/variant]` platform of the image
+	Platform string `json:"platform"`
+	// ImageTag the tag the image is pushed with
+	ImageTag string `json:"imageTag"`
+	// Digest the digest of the image, once built
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// BuildPhase the phase of the image build
+	// +optional
+	BuildPhase BuildPhase `json:"buildPhase,omitempty"`
+}
+
+// BuildAttestationStatus references the artifacts attached to the workflow image
+type BuildAttestationStatus struct {
+	// SBOMFormat the format of the SBOM
+	// +optional
+	SBOMFormat SBOMFormat `json:"sbomFormat,omitempty"`
+	// SBOM reference of the SBOM attached to the image, as a signed attestation when the image is signed
+	// +optional
+	SBOM string `json:"sbom,omitempty"`
+	// Signature reference of the image signature
+	// +optional
+	Signature string `json:"signature,omitempty"`
+}
+
 // SetInnerBuild use to define a new object pointer to the inner build.
 func (k *KogitoServerlessBuildStatus) SetInnerBuild(innerBuilder interface{}) error {
 	obj, err := json.Marshal(innerBuilder)
@@ -0,0 +1,60 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha08
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// KogitoServerlessClusterPlatformKind is the Kind name of the KogitoServerlessClusterPlatform CR
+	KogitoServerlessClusterPlatformKind string = "KogitoServerlessClusterPlatform"
+)
+
+// ClusterPlatformReference references a KogitoServerlessClusterPlatform
+type ClusterPlatformReference struct {
+	// Name of the KogitoServerlessClusterPlatform
+	Name string `json:"name"`
+}
+
+// KogitoServerlessClusterPlatform holds the organisation-wide defaults of the KogitoServerlessPlatforms referencing it,
+// like the registry, the base images, the build strategy or the configuration.
+// Its spec is the one of a KogitoServerlessPlatform, except the `clusterPlatformRef` that is ignored.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+// +kubebuilder:resource:scope=Cluster,shortName={"kscp", "kclusterplatform", "kclusterplatforms"}
+// +kubebuilder:printcolumn:name="Registry",type=string,JSONPath=`.spec.platform.registry.address`
+// +kubebuilder:printcolumn:name="Strategy",type=string,JSONPath=`.spec.platform.buildStrategy`
+type KogitoServerlessClusterPlatform struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KogitoServerlessPlatformSpec `json:"spec,omitempty"`
+}
+
+// KogitoServerlessClusterPlatformList contains a list of KogitoServerlessClusterPlatform
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+type KogitoServerlessClusterPlatformList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KogitoServerlessClusterPlatform `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KogitoServerlessClusterPlatform{}, &KogitoServerlessClusterPlatformList{})
+}
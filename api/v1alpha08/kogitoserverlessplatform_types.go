@@ -18,18 +18,23 @@ import (
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiegroup/kogito-serverless-operator/api"
 )
 
 // ConfigurationSpecType is used to define the enum values of the supported types for ConfigurationSpec
+// +kubebuilder:validation:Enum=property;configmap;secret
 type ConfigurationSpecType string
 
 const (
-	// PropertyConfigurationSpec ...
+	// PropertyConfigurationSpec is a single application property in the `key=value` format
 	PropertyConfigurationSpec ConfigurationSpecType = "property"
-	// ConfigMapConfigurationSpec ...
+	// ConfigMapConfigurationSpec is a ConfigMap holding application properties.
+	// Either in an `application.properties` key or one property per key.
 	ConfigMapConfigurationSpec ConfigurationSpecType = "configmap"
-	// SecretConfigurationSpec ...
+	// SecretConfigurationSpec is a Secret whose keys are injected as environment variables in the workflow application
 	SecretConfigurationSpec ConfigurationSpecType = "secret"
 )
 
@@ -37,8 +42,13 @@ const (
 type ConfigurationSpec struct {
 	// Type represents the type of configuration, ie: property, configmap, secret, ...
 	Type ConfigurationSpecType `json:"type"`
-	// Value a reference to the object for this configuration (syntax may vary depending on the `Type`)
-	Value corev1.ObjectReference `json:"value"`
+	// Value a reference to the object for this configuration (syntax may vary depending on the `Type`).
+	// For the `configmap` and `secret` types, the object must be in the Platform namespace.
+	// +optional
+	Value corev1.ObjectReference `json:"value,omitempty"`
+	// Property in the `key=value` format for the `property` type
+	// +optional
+	Property string `json:"property,omitempty"`
 }
 
 const (
@@ -100,6 +110,10 @@ type BuildPlatformTemplate struct {
 	BuildStrategyOptions map[string]string `json:"buildStrategyOptions,omitempty"`
 	// Registry the registry where to publish the built image
 	Registry RegistrySpec `json:"registry,omitempty"`
+	// RebuildOnChange restarts the builds of the Workflows using this Platform when the registry, the base image
+	// or the Kaniko cache change, so that their images are published with the new configuration.
+	// +optional
+	RebuildOnChange bool `json:"rebuildOnChange,omitempty"`
 }
 
 // GetTimeout returns the specified duration or a default one
@@ -131,15 +145,79 @@ func (b *BuildPlatformTemplate) IsOptionEmpty(option string) bool {
 
 // KogitoServerlessPlatformSpec defines the desired state of KogitoServerlessPlatform
 type KogitoServerlessPlatformSpec struct {
+	// ClusterPlatformRef references the KogitoServerlessClusterPlatform holding the organisation-wide defaults of this Platform.
+	// The fields set in this Platform override the ones of the cluster platform, its configuration entries are added after the cluster ones.
+	// +optional
+	ClusterPlatformRef *ClusterPlatformReference `json:"clusterPlatformRef,omitempty"`
 	// BuildTemplate specify how to build the Workflow. It's used as a template for the KogitoServerlessBuild
 	BuildTemplate BuildTemplate `json:"build,omitempty"`
 	// BuildPlatform specify how is the platform where we want to build the Workflow
 	BuildPlatform BuildPlatformTemplate `json:"platform,omitempty"`
-	// Configuration list of configuration properties to be attached to all the Workflow built from this Platform
-	Configuration ConfigurationSpec `json:"configuration,omitempty"`
+	// Configuration single configuration entry to be attached to all the Workflow built from this Platform.
+	// Deprecated: use Configurations instead, this entry is applied before them.
+	// +optional
+	Configuration *ConfigurationSpec `json:"configuration,omitempty"`
+	// Configurations list of configuration properties to be attached to all the Workflow built from this Platform.
+	// The precedence order is: operator defaults < platform configuration < workflow properties.
+	// The properties the operator requires to run the workflow, like the HTTP port, can't be overridden.
+	// +optional
+	Configurations []ConfigurationSpec `json:"configurations,omitempty"`
 	// DevBaseImage Base image to run the Workflow in dev mode instead of the operator's default.
 	// Optional, used for the dev profile only
 	DevBaseImage string `json:"devBaseImage,omitempty"`
+	// Network default configuration to expose the Workflows deployed with this Platform outside the cluster.
+	// Workflows can override it in their own spec.
+	// +optional
+	Network *NetworkSpec `json:"network,omitempty"`
+	// Persistence default configuration of the Workflows deployed with this Platform.
+	// Workflows can override it in their own spec.
+	// +optional
+	Persistence *PersistenceSpec `json:"persistence,omitempty"`
+	// Monitoring default configuration of the Workflows deployed with this Platform.
+	// Workflows can override it in their own spec.
+	// +optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// Services supporting the Workflows deployed with this Platform, like the Data Index or the Jobs Service.
+	// The Workflows are configured to use them automatically.
+	// +optional
+	Services *PlatformServicesSpec `json:"services,omitempty"`
+}
+
+// GetConfigurations returns the configuration entries in the order they're applied, the deprecated Configuration entry comes first.
+func (s *KogitoServerlessPlatformSpec) GetConfigurations() []ConfigurationSpec {
+	if s.Configuration == nil || len(s.Configuration.Type) == 0 {
+		return s.Configurations
+	}
+	return append([]ConfigurationSpec{*s.Configuration}, s.Configurations...)
+}
+
+// PlatformServicesSpec describes the supporting services deployed and managed by the Platform
+type PlatformServicesSpec struct {
+	// DataIndex indexes the Workflow instances from their events, exposing them through a GraphQL API.
+	// +optional
+	DataIndex *PlatformServiceSpec `json:"dataIndex,omitempty"`
+	// JobService schedules the timers of the Workflow instances, like the ones used by timeouts.
+	// +optional
+	JobService *PlatformServiceSpec `json:"jobService,omitempty"`
+}
+
+// PlatformServiceSpec describes a supporting service deployed by the Platform
+type PlatformServiceSpec struct {
+	// Enabled deploys the service. Defaults to true once the service is declared.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Image of the service instead of the operator's default, which depends on the persistence.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Replicas of the service. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Resources of the service container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Persistence of the service. Defaults to the Platform persistence, if none the service data is ephemeral.
+	// +optional
+	Persistence *PersistenceSpec `json:"persistence,omitempty"`
 }
 
 // PlatformPhase is the phase of a Platform
@@ -160,43 +238,66 @@ const (
 	PlatformPhaseDuplicate PlatformPhase = "Duplicate"
 )
 
-// PlatformConditionType defines the type of condition
-type PlatformConditionType string
-
-// PlatformCondition describes the state of a resource at a certain point.
-type PlatformCondition struct {
-	// TODO: the Type can't be Kubernetes or OpenShift, but the actual condition like "Ready". See the Conditions implementation in the workflow.
-	// TODO: also, we already have the `Cluster` field for that matter.
-	// TODO: see https://issues.redhat.com/browse/KOGITO-9218
-
-	// Type of platform condition (i.e. Kubernetes, OpenShift).
-	Type PlatformConditionType `json:"type"`
-	// Status of the condition, one of True, False, Unknown.
-	Status corev1.ConditionStatus `json:"status"`
-	// The last time this condition was updated.
-	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
-	// Last time the condition transitioned from one status to another.
-	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
-	// The reason for the condition's last transition.
-	Reason string `json:"reason,omitempty"`
-	// A human-readable message indicating details about the transition.
-	Message string `json:"message,omitempty"`
-}
-
 // KogitoServerlessPlatformStatus defines the observed state of KogitoServerlessPlatform
 type KogitoServerlessPlatformStatus struct {
+	api.Status `json:",inline"`
 	// Cluster what kind of cluster you're running (ie, plain Kubernetes or OpenShift)
 	Cluster PlatformCluster `json:"cluster,omitempty"`
-	// ObservedGeneration is the most recent generation observed for this Platform.
-	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
-	// Phase defines in what phase the Platform is found
+	// Phase defines in what phase the Platform is found, derived from the conditions
 	Phase PlatformPhase `json:"phase,omitempty"`
-	// Conditions which are the conditions met (particularly useful when in ERROR phase)
-	Conditions []PlatformCondition `json:"conditions,omitempty"`
 	// Version the Kogito Serverless operator version controlling this Platform
 	Version string `json:"version,omitempty"`
 	// Info generic information related to the build of Kogito Serverless operator
 	Info map[string]string `json:"info,omitempty"`
+	// Services status of the supporting services deployed by this Platform
+	// +optional
+	Services *PlatformServicesStatus `json:"services,omitempty"`
+	// EffectiveSpec the spec of this Platform merged with the one of the referenced cluster platform, with the defaults applied.
+	// It's the spec used to build and deploy the Workflows, set only when a cluster platform is referenced.
+	// +optional
+	EffectiveSpec *KogitoServerlessPlatformSpec `json:"effectiveSpec,omitempty"`
+	// AppliedBuildPlatform the build platform, with the defaults applied, the Kaniko cache and the registry were last set up with.
+	// The Platform is initialized again when the build strategy, the base image, the registry or the build strategy options change.
+	// +optional
+	AppliedBuildPlatform *BuildPlatformTemplate `json:"appliedBuildPlatform,omitempty"`
+	// KanikoCache status of the Kaniko cache used by the builds of this Platform, set only when the cache is enabled
+	// +optional
+	KanikoCache *KanikoCacheStatus `json:"kanikoCache,omitempty"`
+}
+
+// KanikoCacheStatus describes the Kaniko cache warmed by the Platform
+type KanikoCacheStatus struct {
+	// PersistentVolumeClaim holding the cache
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	// Size of the cache the last time it was warmed
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// LastWarmTime the last time the cache was warmed, either when the Platform was initialized or by the cache refresher
+	// +optional
+	LastWarmTime *metav1.Time `json:"lastWarmTime,omitempty"`
+}
+
+// PlatformServicesStatus describes the observed state of the supporting services deployed by the Platform
+type PlatformServicesStatus struct {
+	// ObservedGeneration is the most recent generation of the Platform applied to the services.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// DataIndex status, if deployed
+	// +optional
+	DataIndex *PlatformServiceStatus `json:"dataIndex,omitempty"`
+	// JobService status, if deployed
+	// +optional
+	JobService *PlatformServiceStatus `json:"jobService,omitempty"`
+}
+
+// PlatformServiceStatus describes the observed state of a supporting service
+type PlatformServiceStatus struct {
+	// URL to reach the service within the cluster
+	URL string `json:"url,omitempty"`
+	// Ready is true when the service is available
+	Ready bool `json:"ready"`
+	// Message explaining why the service isn't ready
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // KogitoServerlessPlatform is the Schema for the kogitoserverlessplatforms API
@@ -207,7 +308,7 @@ type KogitoServerlessPlatformStatus struct {
 // +kubebuilder:resource:shortName={"ksp", "kplatform", "kplatforms"}
 // +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.status.cluster`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
-// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.phase=='Ready'`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=='Succeed')].status`
 type KogitoServerlessPlatform struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
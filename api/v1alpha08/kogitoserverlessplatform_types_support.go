@@ -15,8 +15,9 @@
 package v1alpha08
 
 import (
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiegroup/kogito-serverless-operator/api"
 )
 
 // NewKogitoServerlessPlatformList returns an empty list of Platform objects
@@ -43,122 +44,58 @@ func NewKogitoServerlessPlatform(namespace string, name string) KogitoServerless
 	}
 }
 
-// GetCondition returns the condition with the provided type.
-func (in *KogitoServerlessPlatformStatus) GetCondition(condType PlatformConditionType) *PlatformCondition {
-	for i := range in.Conditions {
-		c := in.Conditions[i]
-		if c.Type == condType {
-			return &c
-		}
-	}
-	return nil
-}
-
-// SetErrorCondition sets the condition error for the given platform
-func (in *KogitoServerlessPlatformStatus) SetErrorCondition(condType PlatformConditionType, reason string, err error) {
-	in.SetConditions(PlatformCondition{
-		Type:               condType,
-		Status:             corev1.ConditionFalse,
-		LastUpdateTime:     metav1.Now(),
-		LastTransitionTime: metav1.Now(),
-		Reason:             reason,
-		Message:            err.Error(),
-	})
-}
-
-// SetConditions updates the resource to include the provided conditions.
-//
-// If a condition that we are about to add already exists and has the same status and
-// reason then we are not going to update.
-func (in *KogitoServerlessPlatformStatus) SetConditions(conditions ...PlatformCondition) {
-	for _, condition := range conditions {
-		if condition.LastUpdateTime.IsZero() {
-			condition.LastUpdateTime = metav1.Now()
-		}
-		if condition.LastTransitionTime.IsZero() {
-			condition.LastTransitionTime = metav1.Now()
-		}
-
-		currentCond := in.GetCondition(condition.Type)
-
-		if currentCond != nil && currentCond.Status == condition.Status && currentCond.Reason == condition.Reason {
-			return
-		}
-		// Do not update lastTransitionTime if the status of the condition doesn't change.
-		if currentCond != nil && currentCond.Status == condition.Status {
-			condition.LastTransitionTime = currentCond.LastTransitionTime
-		}
-
-		in.RemoveCondition(condition.Type)
-		in.Conditions = append(in.Conditions, condition)
-	}
-}
-
-// RemoveCondition removes the resource condition with the provided type.
-func (in *KogitoServerlessPlatformStatus) RemoveCondition(condType PlatformConditionType) {
-	newConditions := in.Conditions[:0]
-	for _, c := range in.Conditions {
-		if c.Type != condType {
-			newConditions = append(newConditions, c)
-		}
-	}
-
-	in.Conditions = newConditions
-}
-
 const (
 	// ServiceTypeUser service user type label marker
 	ServiceTypeUser = "user"
 )
 
-// +kubebuilder:object:generate=false
-// ResourceCondition is a common type for all conditions
-type ResourceCondition interface {
-	GetType() string
-	GetStatus() corev1.ConditionStatus
-	GetLastUpdateTime() metav1.Time
-	GetLastTransitionTime() metav1.Time
-	GetReason() string
-	GetMessage() string
+func (in *KogitoServerlessPlatformStatus) GetTopLevelConditionType() api.ConditionType {
+	return api.SucceedConditionType
 }
 
-var _ ResourceCondition = PlatformCondition{}
-
-// GetConditions --
-func (in *KogitoServerlessPlatformStatus) GetConditions() []ResourceCondition {
-	res := make([]ResourceCondition, 0, len(in.Conditions))
-	for _, c := range in.Conditions {
-		res = append(res, c)
-	}
-	return res
+func (in *KogitoServerlessPlatformStatus) IsReady() bool {
+	return in.GetTopLevelCondition().IsTrue()
 }
 
-// GetType --
-func (c PlatformCondition) GetType() string {
-	return string(c.Type)
+func (in *KogitoServerlessPlatformStatus) GetTopLevelCondition() *api.Condition {
+	return in.GetCondition(in.GetTopLevelConditionType())
 }
 
-// GetStatus --
-func (c PlatformCondition) GetStatus() corev1.ConditionStatus {
-	return c.Status
+func (in *KogitoServerlessPlatformStatus) Manager() api.ConditionsManager {
+	return api.NewConditionManager(in, api.SucceedConditionType, api.BuilderConfigValidConditionType, api.CacheWarmedConditionType, api.RegistryReachableConditionType)
 }
 
-// GetLastUpdateTime --
-func (c PlatformCondition) GetLastUpdateTime() metav1.Time {
-	return c.LastUpdateTime
+// IsDuplicate checks if the platform has been discarded since there's already an active platform in the namespace
+func (in *KogitoServerlessPlatformStatus) IsDuplicate() bool {
+	cond := in.GetTopLevelCondition()
+	return cond.IsFalse() && cond.Reason == api.DuplicatedPlatformReason
 }
 
-// GetLastTransitionTime --
-func (c PlatformCondition) GetLastTransitionTime() metav1.Time {
-	return c.LastTransitionTime
+// IsWarmingCache checks if the platform is waiting for the builder cache to warm up
+func (in *KogitoServerlessPlatformStatus) IsWarmingCache() bool {
+	cond := in.GetCondition(api.CacheWarmedConditionType)
+	return cond.IsUnknown() && cond.GetReason() == api.WarmingCacheReason
 }
 
-// GetReason --
-func (c PlatformCondition) GetReason() string {
-	return c.Reason
+// UpdatePhase sets the Phase derived from the platform conditions
+func (in *KogitoServerlessPlatformStatus) UpdatePhase() {
+	switch {
+	case len(in.Conditions) == 0:
+		in.Phase = PlatformPhaseNone
+	case in.IsReady():
+		in.Phase = PlatformPhaseReady
+	case in.IsDuplicate():
+		in.Phase = PlatformPhaseDuplicate
+	case in.GetTopLevelCondition().IsFalse():
+		in.Phase = PlatformPhaseError
+	case in.IsWarmingCache():
+		in.Phase = PlatformPhaseWarming
+	default:
+		in.Phase = PlatformPhaseCreating
+	}
 }
 
-// GetMessage --
-func (c PlatformCondition) GetMessage() string {
-	return c.Message
+// IsEnabled returns true if the service is declared and not explicitly disabled
+func (in *PlatformServiceSpec) IsEnabled() bool {
+	return in != nil && (in.Enabled == nil || *in.Enabled)
 }
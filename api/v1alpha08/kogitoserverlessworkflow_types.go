@@ -16,6 +16,7 @@ package v1alpha08
 
 import (
 	"github.com/serverlessworkflow/sdk-go/v2/model"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -27,6 +28,308 @@ import (
 type KogitoServerlessWorkflowSpec struct {
 	// +kubebuilder:validation:Required
 	Flow model.Workflow `json:"flow"`
+	// Network describes how the workflow application is exposed outside the cluster.
+	// If not set, the Platform's network configuration is used.
+	// +optional
+	Network *NetworkSpec `json:"network,omitempty"`
+	// Secrets holding sensitive configuration of the workflow application, such as credentials to access OpenAPI services,
+	// Kafka brokers or databases. Besides these, the workflow's companion Secret `<workflow name>-secret-props` is always
+	// mounted in the workflow application. Changes to the referenced Secrets roll out the workflow application.
+	// +optional
+	Secrets []SecretPropertiesSpec `json:"secrets,omitempty"`
+	// Persistence of the workflow instances, so long-running workflows survive restarts of the workflow application.
+	// If not set, the Platform's persistence configuration is used. Used for the prod profile only.
+	// +optional
+	Persistence *PersistenceSpec `json:"persistence,omitempty"`
+	// Monitoring of the workflow application metrics by the Prometheus Operator.
+	// If not set, the Platform's monitoring configuration is used. Used for the prod profile only.
+	// +optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// PlatformRef selects the Platform of the workflow namespace used to build and deploy the workflow, instead of the active one.
+	// The Platform, that can be a secondary one, must exist and be ready. Takes precedence over the `sw.kogito.kie.org/platform` annotation.
+	// +optional
+	PlatformRef *PlatformReference `json:"platformRef,omitempty"`
+	// Build customizes how the workflow is built, overriding the Platform build configuration. Used for the prod profile only.
+	// +optional
+	Build *WorkflowBuildSpec `json:"build,omitempty"`
+	// Promotion deploys an image already built, e.g. for the same workflow in another environment, instead of building the workflow.
+	// The image is copied unchanged to the Platform registry, so every environment runs the exact same image. Used for the prod profile only.
+	// +optional
+	Promotion *PromotionSpec `json:"promotion,omitempty"`
+	// Image deploys a prebuilt image, e.g. built by an external CI, instead of building the workflow. It can't be combined with Promotion.
+	// The image is checked in its registry, then deployed pinned by digest. Used for the prod profile only.
+	// +optional
+	Image *PrebuiltImageSpec `json:"image,omitempty"`
+}
+
+// PrebuiltImageSpec references the prebuilt image deployed for a workflow
+type PrebuiltImageSpec struct {
+	// Name the prebuilt image, by tag or digest, e.g. `quay.io/ci/greeting:1.0`.
+	// A tag is resolved once, change the name to deploy another image.
+	Name string `json:"name"`
+	// RegistrySecretRef the docker config Secret with the credentials pulling the prebuilt image. Anonymous pulls are attempted when not set.
+	// The Secret checks the image and is added to the image pull secrets of the workflow Deployment.
+	// +optional
+	RegistrySecretRef *corev1.LocalObjectReference `json:"registrySecretRef,omitempty"`
+	// Insecure checks the prebuilt image with plain HTTP
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// PromotionSpec references the image promoted for a workflow
+type PromotionSpec struct {
+	// Image the promoted image, pinned by digest, e.g. `quay.io/dev/greeting@sha256:...`.
+	// It's copied to the Platform registry with the workflow image name and tag, then pulled with the Platform registry Secret.
+	// +kubebuilder:validation:Pattern=`^[^@]+@sha256:[a-f0-9]{64}$`
+	Image string `json:"image"`
+	// RegistrySecretRef the docker config Secret with the credentials pulling the promoted image. Anonymous pulls are attempted when not set.
+	// +optional
+	RegistrySecretRef *corev1.LocalObjectReference `json:"registrySecretRef,omitempty"`
+	// Insecure pulls the promoted image with plain HTTP
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// WorkflowBuildSpec describes how to build a workflow
+type WorkflowBuildSpec struct {
+	// Dockerfile template building the workflow instead of the Platform's one.
+	// Its variables are added to the ones of the Platform template, if any.
+	// +optional
+	Dockerfile *DockerfileTemplateSpec `json:"dockerfile,omitempty"`
+	// Extensions Quarkus extensions added to the workflow, as `groupId:artifactId[:version]` coordinates.
+	// They're added to the Platform's extensions, if any.
+	// +optional
+	Extensions []string `json:"extensions,omitempty"`
+	// Addons Kogito add-ons added to the workflow, by name. E.g. `kafka`, `knative-eventing` or `kubernetes`.
+	// They're added to the Platform's add-ons, if any.
+	// +optional
+	Addons []string `json:"addons,omitempty"`
+	// Mode how the workflow is compiled, overriding the Platform's one. `jvm` by default.
+	// +optional
+	Mode BuildMode `json:"mode,omitempty"`
+	// Platforms the `os/arch[/variant]` platforms the workflow image is built for, overriding the Platform's ones
+	// +optional
+	Platforms []string `json:"platforms,omitempty"`
+	// Attestation how the workflow image is attested once pushed, overriding the Platform's one
+	// +optional
+	Attestation *AttestationSpec `json:"attestation,omitempty"`
+}
+
+// PlatformReference references a KogitoServerlessPlatform in the namespace of the referencing object
+type PlatformReference struct {
+	// Name of the KogitoServerlessPlatform
+	Name string `json:"name"`
+}
+
+// PersistenceMigration is how the database schema of the workflow persistence is migrated
+// +kubebuilder:validation:Enum=none;startup;initContainer
+type PersistenceMigration string
+
+const (
+	// PersistenceMigrationNone doesn't migrate the database schema, it must be managed outside the workflow application
+	PersistenceMigrationNone PersistenceMigration = "none"
+	// PersistenceMigrationStartup migrates the database schema with Flyway when the workflow application starts
+	PersistenceMigrationStartup PersistenceMigration = "startup"
+	// PersistenceMigrationInitContainer migrates the database schema with Flyway in an init container of the workflow application
+	PersistenceMigrationInitContainer PersistenceMigration = "initContainer"
+)
+
+// MonitoringType is the kind of Prometheus Operator object scraping the workflow application metrics
+// +kubebuilder:validation:Enum=serviceMonitor;podMonitor
+type MonitoringType string
+
+const (
+	// MonitoringTypeServiceMonitor scrapes the workflow application metrics through its Service
+	MonitoringTypeServiceMonitor MonitoringType = "serviceMonitor"
+	// MonitoringTypePodMonitor scrapes the workflow application metrics directly from its Pods
+	MonitoringTypePodMonitor MonitoringType = "podMonitor"
+)
+
+// MonitoringSpec describes how the workflow application metrics exposed in `/q/metrics` are scraped.
+// Requires the Prometheus Operator installed in the cluster.
+type MonitoringSpec struct {
+	// Enabled creates the Prometheus Operator object scraping the workflow application metrics
+	Enabled bool `json:"enabled,omitempty"`
+	// Type of the Prometheus Operator object, defaults to serviceMonitor
+	// +optional
+	Type MonitoringType `json:"type,omitempty"`
+	// Interval between scrapes, like `30s`. Defaults to the Prometheus global configuration.
+	// +optional
+	Interval string `json:"interval,omitempty"`
+	// Labels added to the Prometheus Operator object, usually to match the Prometheus selectors
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// PersistenceSpec describes the persistence of the workflow instances
+type PersistenceSpec struct {
+	// PostgreSQL database to persist the workflow instances
+	// +optional
+	PostgreSQL *PostgreSQLPersistenceSpec `json:"postgresql,omitempty"`
+	// Migration of the database schema, defaults to startup
+	// +optional
+	Migration PersistenceMigration `json:"migration,omitempty"`
+}
+
+// PostgreSQLPersistenceSpec describes the PostgreSQL database to persist the workflow instances.
+// Either ServiceRef or JdbcURL must be set.
+type PostgreSQLPersistenceSpec struct {
+	// SecretRef to the Secret holding the database credentials, it must be in the workflow namespace
+	// +kubebuilder:validation:Required
+	SecretRef PostgreSQLSecretReference `json:"secretRef"`
+	// ServiceRef to the Service of the database
+	// +optional
+	ServiceRef *PostgreSQLServiceReference `json:"serviceRef,omitempty"`
+	// JdbcURL of the database, like `jdbc:postgresql://host:5432/database`. It takes precedence over the ServiceRef.
+	// +optional
+	JdbcURL string `json:"jdbcUrl,omitempty"`
+}
+
+// PostgreSQLSecretReference references the Secret holding the database credentials
+type PostgreSQLSecretReference struct {
+	// Name of the Secret
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// UserKey of the database user in the Secret, defaults to `username`
+	// +optional
+	UserKey string `json:"userKey,omitempty"`
+	// PasswordKey of the database password in the Secret, defaults to `password`
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// PostgreSQLServiceReference references the Service of the database
+type PostgreSQLServiceReference struct {
+	// Name of the Service
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Namespace of the Service, defaults to the workflow namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Port of the Service, defaults to 5432
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// DatabaseName of the database, defaults to `kogito`
+	// +optional
+	DatabaseName string `json:"databaseName,omitempty"`
+	// DatabaseSchema of the database, defaults to the workflow name
+	// +optional
+	DatabaseSchema string `json:"databaseSchema,omitempty"`
+}
+
+// SecretPropertiesMode is how a Secret is made available to the workflow application
+// +kubebuilder:validation:Enum=file;env
+type SecretPropertiesMode string
+
+const (
+	// SecretPropertiesModeFile mounts a properties file in the Secret and adds it to `quarkus.config.locations`
+	SecretPropertiesModeFile SecretPropertiesMode = "file"
+	// SecretPropertiesModeEnv injects every key of the Secret as an environment variable.
+	// See: https://quarkus.io/guides/config-reference#environment-variables
+	SecretPropertiesModeEnv SecretPropertiesMode = "env"
+)
+
+// SecretPropertiesSpec references a Secret in the workflow namespace holding sensitive configuration of the workflow application
+type SecretPropertiesSpec struct {
+	// Name of the Secret
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Mode of the Secret in the workflow application, defaults to file
+	// +optional
+	Mode SecretPropertiesMode `json:"mode,omitempty"`
+	// Key of the properties file in the Secret in the file mode, defaults to `application.properties`
+	// +optional
+	Key string `json:"key,omitempty"`
+	// Optional specifies whether the workflow application can start without the Secret
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// NetworkMode is the kind of network exposure of the workflow application outside the cluster
+// +kubebuilder:validation:Enum=none;nodePort;ingress;gateway;route
+type NetworkMode string
+
+const (
+	// NetworkModeNone doesn't expose the workflow application outside the cluster
+	NetworkModeNone NetworkMode = "none"
+	// NetworkModeNodePort exposes the workflow application with a NodePort Service
+	NetworkModeNodePort NetworkMode = "nodePort"
+	// NetworkModeIngress exposes the workflow application with a networking.k8s.io/v1 Ingress
+	NetworkModeIngress NetworkMode = "ingress"
+	// NetworkModeGateway exposes the workflow application with a Gateway API HTTPRoute
+	NetworkModeGateway NetworkMode = "gateway"
+	// NetworkModeRoute exposes the workflow application with an OpenShift Route
+	NetworkModeRoute NetworkMode = "route"
+)
+
+// RouteTLSTermination is the kind of TLS termination of an OpenShift Route
+// +kubebuilder:validation:Enum=edge;reencrypt;passthrough
+type RouteTLSTermination string
+
+const (
+	// RouteTLSTerminationEdge terminates TLS in the OpenShift router
+	RouteTLSTerminationEdge RouteTLSTermination = "edge"
+	// RouteTLSTerminationReencrypt terminates TLS in the OpenShift router and re-encrypts the connection to the workflow application
+	RouteTLSTerminationReencrypt RouteTLSTermination = "reencrypt"
+	// RouteTLSTerminationPassthrough sends the encrypted traffic straight to the workflow application
+	RouteTLSTerminationPassthrough RouteTLSTermination = "passthrough"
+)
+
+// NetworkSpec describes how the workflow application is exposed outside the cluster
+type NetworkSpec struct {
+	// Mode of exposure of the workflow application.
+	// If not set, workflows are exposed with a Route on OpenShift. On Kubernetes, workflows in the dev profile are
+	// exposed with a NodePort Service and workflows in the prod profile are not exposed.
+	// The route mode is only available on OpenShift.
+	// +optional
+	Mode NetworkMode `json:"mode,omitempty"`
+	// Host the workflow application is exposed to
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Path the workflow application is exposed to, defaults to the root path
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Annotations added to the Route, Ingress or HTTPRoute exposing the workflow application
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// TLS configuration of the exposed workflow application.
+	// In the gateway mode, TLS is terminated by the Gateway listener, so only the endpoint scheme is affected.
+	// +optional
+	TLS *NetworkTLSSpec `json:"tls,omitempty"`
+	// IngressClassName of the Ingress in the ingress mode
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Gateway the HTTPRoute is attached to, required in the gateway mode
+	// +optional
+	Gateway *GatewayReference `json:"gateway,omitempty"`
+}
+
+// NetworkTLSSpec describes the TLS configuration of the exposed workflow application
+type NetworkTLSSpec struct {
+	// SecretName of the Secret holding the TLS certificate and key for the Host.
+	// The Secret must have the keys `tls.crt` and `tls.key`, and optionally `ca.crt`.
+	// If not set, the default certificate of the Ingress controller or the OpenShift router is used.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// Termination of the TLS connection on OpenShift Routes, defaults to edge
+	// +optional
+	Termination RouteTLSTermination `json:"termination,omitempty"`
+	// DestinationCASecretName of the Secret holding, in the `ca.crt` key, the CA certificate used by the OpenShift router
+	// to validate the workflow application certificate in the reencrypt termination
+	// +optional
+	DestinationCASecretName string `json:"destinationCASecretName,omitempty"`
+}
+
+// GatewayReference identifies the Gateway API Gateway an HTTPRoute is attached to
+type GatewayReference struct {
+	// Name of the Gateway
+	Name string `json:"name"`
+	// Namespace of the Gateway, defaults to the workflow namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName is the name of the Gateway listener to attach to
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
 }
 
 // KogitoServerlessWorkflowStatus defines the observed state of KogitoServerlessWorkflow
@@ -38,6 +341,54 @@ type KogitoServerlessWorkflowStatus struct {
 	// keeps track of how many failure recovers a given workflow had so far
 	RecoverFailureAttempts int       `json:"recoverFailureAttempts,omitempty"`
 	Endpoint               *apis.URL `json:"endpoint,omitempty"`
+	// DevMode describes the information probed from the workflow application running in the dev profile
+	// +optional
+	DevMode *DevModeStatus `json:"devMode,omitempty"`
+	// Promotion describes the image promoted for the workflow, when deployed from an image already built
+	// +optional
+	Promotion *PromotionStatus `json:"promotion,omitempty"`
+	// Prebuilt describes the prebuilt image deployed for the workflow
+	// +optional
+	Prebuilt *PrebuiltImageStatus `json:"prebuilt,omitempty"`
+}
+
+// PrebuiltImageStatus describes the prebuilt image deployed for a workflow
+type PrebuiltImageStatus struct {
+	// Name the prebuilt image, as referenced by the workflow
+	Name string `json:"name"`
+	// Image the prebuilt image pinned by digest, as deployed
+	Image string `json:"image"`
+	// ValidationTime when the image was checked in its registry
+	// +optional
+	ValidationTime *metav1.Time `json:"validationTime,omitempty"`
+}
+
+// PromotionStatus describes the image promoted for a workflow
+type PromotionStatus struct {
+	// Source the promoted image, as referenced by the workflow
+	Source string `json:"source"`
+	// Image the promoted image in the Platform registry, pinned by digest
+	Image string `json:"image"`
+	// PromotionTime when the image was copied to the Platform registry
+	// +optional
+	PromotionTime *metav1.Time `json:"promotionTime,omitempty"`
+}
+
+// DevModeStatus describes the information reported by the Quarkus health and the Kogito process management endpoints
+// of a workflow application running in the dev profile.
+type DevModeStatus struct {
+	// Health is the overall status reported by the Quarkus health endpoint, UP or DOWN
+	// +optional
+	Health string `json:"health,omitempty"`
+	// ProcessIDs are the process definitions deployed in the workflow application
+	// +optional
+	ProcessIDs []string `json:"processIds,omitempty"`
+	// WorkflowVersion is the version of the workflow as reported by the workflow application
+	// +optional
+	WorkflowVersion string `json:"workflowVersion,omitempty"`
+	// BuildError is the error reported by the workflow application, e.g. a failure while generating the workflow code
+	// +optional
+	BuildError string `json:"buildError,omitempty"`
 }
 
 func (s *KogitoServerlessWorkflowStatus) GetTopLevelConditionType() api.ConditionType {
@@ -71,16 +422,43 @@ func (s *KogitoServerlessWorkflowStatus) IsWaitingForBuild() bool {
 	return cond.IsFalse() && cond.Reason == api.WaitingForBuildReason
 }
 
+func (s *KogitoServerlessWorkflowStatus) IsDevModeBuildFailed() bool {
+	cond := s.GetCondition(api.RunningConditionType)
+	return cond.IsFalse() && cond.Reason == api.DevModeBuildErrorReason
+}
+
 func (s *KogitoServerlessWorkflowStatus) IsBuildRunningOrUnknown() bool {
 	cond := s.GetCondition(api.BuiltConditionType)
 	return cond.IsUnknown() || (cond.IsFalse() && cond.Reason == api.BuildIsRunningReason)
 }
 
+// IsPromoted checks if the image promoted by the workflow has been copied to the Platform registry
+func (s *KogitoServerlessWorkflowStatus) IsPromoted(promotion *PromotionSpec) bool {
+	return promotion != nil && s.Promotion != nil && s.Promotion.Source == promotion.Image
+}
+
+// IsPrebuiltImageValidated checks if the prebuilt image of the workflow has been found in its registry
+func (s *KogitoServerlessWorkflowStatus) IsPrebuiltImageValidated(image *PrebuiltImageSpec) bool {
+	return image != nil && s.Prebuilt != nil && s.Prebuilt.Name == image.Name
+}
+
 func (s *KogitoServerlessWorkflowStatus) IsBuildFailed() bool {
 	cond := s.GetCondition(api.BuiltConditionType)
 	return cond.IsFalse() && cond.Reason == api.BuildFailedReason
 }
 
+// IsBuildConfigInvalid checks if the workflow can't be built with its build configuration, as merged with the Platform one
+func (s *KogitoServerlessWorkflowStatus) IsBuildConfigInvalid() bool {
+	cond := s.GetCondition(api.BuiltConditionType)
+	return cond.IsFalse() && cond.Reason == api.BuildConfigInvalidReason
+}
+
+// IsNetworkConfigInvalid checks if the workflow application can't be exposed with its network configuration, as merged with the Platform one
+func (s *KogitoServerlessWorkflowStatus) IsNetworkConfigInvalid() bool {
+	cond := s.GetCondition(api.RunningConditionType)
+	return cond.IsFalse() && cond.Reason == api.NetworkConfigInvalidReason
+}
+
 // KogitoServerlessWorkflow is the Schema for the kogitoserverlessworkflows API
 // +kubebuilder:object:root=true
 // +kubebuilder:object:generate=true
@@ -20,17 +20,53 @@
 package v1alpha08
 
 import (
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttestationSpec) DeepCopyInto(out *AttestationSpec) {
+	*out = *in
+	if in.SigningKeySecretRef != nil {
+		in, out := &in.SigningKeySecretRef, &out.SigningKeySecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttestationSpec.
+func (in *AttestationSpec) DeepCopy() *AttestationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AttestationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildAttestationStatus) DeepCopyInto(out *BuildAttestationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildAttestationStatus.
+func (in *BuildAttestationStatus) DeepCopy() *BuildAttestationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildAttestationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildPlatformTemplate) DeepCopyInto(out *BuildPlatformTemplate) {
 	*out = *in
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.BuildStrategyOptions != nil {
@@ -63,6 +99,31 @@ func (in *BuildTemplate) DeepCopyInto(out *BuildTemplate) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Dockerfile != nil {
+		in, out := &in.Dockerfile, &out.Dockerfile
+		*out = new(DockerfileTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attestation != nil {
+		in, out := &in.Attestation, &out.Attestation
+		*out = new(AttestationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildTemplate.
@@ -75,6 +136,21 @@ func (in *BuildTemplate) DeepCopy() *BuildTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPlatformReference) DeepCopyInto(out *ClusterPlatformReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPlatformReference.
+func (in *ClusterPlatformReference) DeepCopy() *ClusterPlatformReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPlatformReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 	*out = *in
@@ -91,6 +167,88 @@ func (in *ConfigurationSpec) DeepCopy() *ConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevModeStatus) DeepCopyInto(out *DevModeStatus) {
+	*out = *in
+	if in.ProcessIDs != nil {
+		in, out := &in.ProcessIDs, &out.ProcessIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevModeStatus.
+func (in *DevModeStatus) DeepCopy() *DevModeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DevModeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerfileTemplateSpec) DeepCopyInto(out *DockerfileTemplateSpec) {
+	*out = *in
+	out.ConfigMapRef = in.ConfigMapRef
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerfileTemplateSpec.
+func (in *DockerfileTemplateSpec) DeepCopy() *DockerfileTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerfileTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayReference) DeepCopyInto(out *GatewayReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayReference.
+func (in *GatewayReference) DeepCopy() *GatewayReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanikoCacheStatus) DeepCopyInto(out *KanikoCacheStatus) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LastWarmTime != nil {
+		in, out := &in.LastWarmTime, &out.LastWarmTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanikoCacheStatus.
+func (in *KanikoCacheStatus) DeepCopy() *KanikoCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KanikoCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoServerlessBuild) DeepCopyInto(out *KogitoServerlessBuild) {
 	*out = *in
@@ -169,6 +327,24 @@ func (in *KogitoServerlessBuildSpec) DeepCopy() *KogitoServerlessBuildSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoServerlessBuildStatus) DeepCopyInto(out *KogitoServerlessBuildStatus) {
 	*out = *in
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]PlatformImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attestation != nil {
+		in, out := &in.Attestation, &out.Attestation
+		*out = new(BuildAttestationStatus)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
 	in.InnerBuild.DeepCopyInto(&out.InnerBuild)
 }
 
@@ -182,6 +358,64 @@ func (in *KogitoServerlessBuildStatus) DeepCopy() *KogitoServerlessBuildStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoServerlessClusterPlatform) DeepCopyInto(out *KogitoServerlessClusterPlatform) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoServerlessClusterPlatform.
+func (in *KogitoServerlessClusterPlatform) DeepCopy() *KogitoServerlessClusterPlatform {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoServerlessClusterPlatform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KogitoServerlessClusterPlatform) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KogitoServerlessClusterPlatformList) DeepCopyInto(out *KogitoServerlessClusterPlatformList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KogitoServerlessClusterPlatform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoServerlessClusterPlatformList.
+func (in *KogitoServerlessClusterPlatformList) DeepCopy() *KogitoServerlessClusterPlatformList {
+	if in == nil {
+		return nil
+	}
+	out := new(KogitoServerlessClusterPlatformList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KogitoServerlessClusterPlatformList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoServerlessPlatform) DeepCopyInto(out *KogitoServerlessPlatform) {
 	*out = *in
@@ -244,9 +478,43 @@ func (in *KogitoServerlessPlatformList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoServerlessPlatformSpec) DeepCopyInto(out *KogitoServerlessPlatformSpec) {
 	*out = *in
+	if in.ClusterPlatformRef != nil {
+		in, out := &in.ClusterPlatformRef, &out.ClusterPlatformRef
+		*out = new(ClusterPlatformReference)
+		**out = **in
+	}
 	in.BuildTemplate.DeepCopyInto(&out.BuildTemplate)
 	in.BuildPlatform.DeepCopyInto(&out.BuildPlatform)
-	out.Configuration = in.Configuration
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = new(ConfigurationSpec)
+		**out = **in
+	}
+	if in.Configurations != nil {
+		in, out := &in.Configurations, &out.Configurations
+		*out = make([]ConfigurationSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(NetworkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(PersistenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(PlatformServicesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoServerlessPlatformSpec.
@@ -262,13 +530,7 @@ func (in *KogitoServerlessPlatformSpec) DeepCopy() *KogitoServerlessPlatformSpec
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KogitoServerlessPlatformStatus) DeepCopyInto(out *KogitoServerlessPlatformStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]PlatformCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.Status.DeepCopyInto(&out.Status)
 	if in.Info != nil {
 		in, out := &in.Info, &out.Info
 		*out = make(map[string]string, len(*in))
@@ -276,6 +538,26 @@ func (in *KogitoServerlessPlatformStatus) DeepCopyInto(out *KogitoServerlessPlat
 			(*out)[key] = val
 		}
 	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(PlatformServicesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EffectiveSpec != nil {
+		in, out := &in.EffectiveSpec, &out.EffectiveSpec
+		*out = new(KogitoServerlessPlatformSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppliedBuildPlatform != nil {
+		in, out := &in.AppliedBuildPlatform, &out.AppliedBuildPlatform
+		*out = new(BuildPlatformTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KanikoCache != nil {
+		in, out := &in.KanikoCache, &out.KanikoCache
+		*out = new(KanikoCacheStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoServerlessPlatformStatus.
@@ -351,6 +633,46 @@ func (in *KogitoServerlessWorkflowList) DeepCopyObject() runtime.Object {
 func (in *KogitoServerlessWorkflowSpec) DeepCopyInto(out *KogitoServerlessWorkflowSpec) {
 	*out = *in
 	in.Flow.DeepCopyInto(&out.Flow)
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(NetworkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretPropertiesSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(PersistenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PlatformRef != nil {
+		in, out := &in.PlatformRef, &out.PlatformRef
+		*out = new(PlatformReference)
+		**out = **in
+	}
+	if in.Build != nil {
+		in, out := &in.Build, &out.Build
+		*out = new(WorkflowBuildSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Promotion != nil {
+		in, out := &in.Promotion, &out.Promotion
+		*out = new(PromotionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(PrebuiltImageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoServerlessWorkflowSpec.
@@ -374,6 +696,21 @@ func (in *KogitoServerlessWorkflowStatus) DeepCopyInto(out *KogitoServerlessWork
 		*out = new(apis.URL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DevMode != nil {
+		in, out := &in.DevMode, &out.DevMode
+		*out = new(DevModeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Promotion != nil {
+		in, out := &in.Promotion, &out.Promotion
+		*out = new(PromotionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prebuilt != nil {
+		in, out := &in.Prebuilt, &out.Prebuilt
+		*out = new(PrebuiltImageStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KogitoServerlessWorkflowStatus.
@@ -387,18 +724,355 @@ func (in *KogitoServerlessWorkflowStatus) DeepCopy() *KogitoServerlessWorkflowSt
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PlatformCondition) DeepCopyInto(out *PlatformCondition) {
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(NetworkTLSSpec)
+		**out = **in
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkTLSSpec) DeepCopyInto(out *NetworkTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkTLSSpec.
+func (in *NetworkTLSSpec) DeepCopy() *NetworkTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceSpec) DeepCopyInto(out *PersistenceSpec) {
+	*out = *in
+	if in.PostgreSQL != nil {
+		in, out := &in.PostgreSQL, &out.PostgreSQL
+		*out = new(PostgreSQLPersistenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceSpec.
+func (in *PersistenceSpec) DeepCopy() *PersistenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformImageStatus) DeepCopyInto(out *PlatformImageStatus) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformCondition.
-func (in *PlatformCondition) DeepCopy() *PlatformCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformImageStatus.
+func (in *PlatformImageStatus) DeepCopy() *PlatformImageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PlatformCondition)
+	out := new(PlatformImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformReference) DeepCopyInto(out *PlatformReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformReference.
+func (in *PlatformReference) DeepCopy() *PlatformReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformServiceSpec) DeepCopyInto(out *PlatformServiceSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(PersistenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformServiceSpec.
+func (in *PlatformServiceSpec) DeepCopy() *PlatformServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformServiceStatus) DeepCopyInto(out *PlatformServiceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformServiceStatus.
+func (in *PlatformServiceStatus) DeepCopy() *PlatformServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformServicesSpec) DeepCopyInto(out *PlatformServicesSpec) {
+	*out = *in
+	if in.DataIndex != nil {
+		in, out := &in.DataIndex, &out.DataIndex
+		*out = new(PlatformServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobService != nil {
+		in, out := &in.JobService, &out.JobService
+		*out = new(PlatformServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformServicesSpec.
+func (in *PlatformServicesSpec) DeepCopy() *PlatformServicesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformServicesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformServicesStatus) DeepCopyInto(out *PlatformServicesStatus) {
+	*out = *in
+	if in.DataIndex != nil {
+		in, out := &in.DataIndex, &out.DataIndex
+		*out = new(PlatformServiceStatus)
+		**out = **in
+	}
+	if in.JobService != nil {
+		in, out := &in.JobService, &out.JobService
+		*out = new(PlatformServiceStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformServicesStatus.
+func (in *PlatformServicesStatus) DeepCopy() *PlatformServicesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformServicesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLPersistenceSpec) DeepCopyInto(out *PostgreSQLPersistenceSpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(PostgreSQLServiceReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLPersistenceSpec.
+func (in *PostgreSQLPersistenceSpec) DeepCopy() *PostgreSQLPersistenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLPersistenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLSecretReference) DeepCopyInto(out *PostgreSQLSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLSecretReference.
+func (in *PostgreSQLSecretReference) DeepCopy() *PostgreSQLSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLServiceReference) DeepCopyInto(out *PostgreSQLServiceReference) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLServiceReference.
+func (in *PostgreSQLServiceReference) DeepCopy() *PostgreSQLServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrebuiltImageSpec) DeepCopyInto(out *PrebuiltImageSpec) {
+	*out = *in
+	if in.RegistrySecretRef != nil {
+		in, out := &in.RegistrySecretRef, &out.RegistrySecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrebuiltImageSpec.
+func (in *PrebuiltImageSpec) DeepCopy() *PrebuiltImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrebuiltImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrebuiltImageStatus) DeepCopyInto(out *PrebuiltImageStatus) {
+	*out = *in
+	if in.ValidationTime != nil {
+		in, out := &in.ValidationTime, &out.ValidationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrebuiltImageStatus.
+func (in *PrebuiltImageStatus) DeepCopy() *PrebuiltImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrebuiltImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionSpec) DeepCopyInto(out *PromotionSpec) {
+	*out = *in
+	if in.RegistrySecretRef != nil {
+		in, out := &in.RegistrySecretRef, &out.RegistrySecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionSpec.
+func (in *PromotionSpec) DeepCopy() *PromotionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionStatus) DeepCopyInto(out *PromotionStatus) {
+	*out = *in
+	if in.PromotionTime != nil {
+		in, out := &in.PromotionTime, &out.PromotionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionStatus.
+func (in *PromotionStatus) DeepCopy() *PromotionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -417,3 +1091,58 @@ func (in *RegistrySpec) DeepCopy() *RegistrySpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretPropertiesSpec) DeepCopyInto(out *SecretPropertiesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretPropertiesSpec.
+func (in *SecretPropertiesSpec) DeepCopy() *SecretPropertiesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretPropertiesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowBuildSpec) DeepCopyInto(out *WorkflowBuildSpec) {
+	*out = *in
+	if in.Dockerfile != nil {
+		in, out := &in.Dockerfile, &out.Dockerfile
+		*out = new(DockerfileTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attestation != nil {
+		in, out := &in.Attestation, &out.Attestation
+		*out = new(AttestationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowBuildSpec.
+func (in *WorkflowBuildSpec) DeepCopy() *WorkflowBuildSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowBuildSpec)
+	in.DeepCopyInto(out)
+	return out
+}
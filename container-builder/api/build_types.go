@@ -75,6 +75,8 @@ type ContainerRegistrySpec struct {
 type ContainerBuildTask struct {
 	// a KanikoTask, for Kaniko strategy
 	Kaniko *KanikoTask `json:"kaniko,omitempty"`
+	// an AttestTask, run once the image is pushed
+	Attest *AttestTask `json:"attest,omitempty"`
 }
 
 // ContainerBuildBaseTask is a base for the struct hierarchy
@@ -107,6 +109,33 @@ type KanikoTask struct {
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 	// AdditionalFlags -- List of additional flags for  the Kaniko process (see https://github.com/GoogleContainerTools/kaniko/blob/main/README.md#additional-flags)
 	AdditionalFlags []string `json:"additionalFlags,omitempty"`
+	// Platform -- the `os/arch[/variant]` platform the image is built for, e.g. linux/arm64. The build pod runs on a node
+	// of the same platform. When empty, the image is built for the platform of the node the pod is scheduled on.
+	Platform string `json:"platform,omitempty"`
+}
+
+// SBOMFormat the format of the Software Bill of Materials generated for an image
+// +kubebuilder:validation:Enum=spdx;cyclonedx
+type SBOMFormat string
+
+const (
+	// SBOMFormatSPDX the SPDX JSON format
+	SBOMFormatSPDX SBOMFormat = "spdx"
+	// SBOMFormatCycloneDX the CycloneDX JSON format
+	SBOMFormatCycloneDX SBOMFormat = "cyclonedx"
+)
+
+// AttestTask is used to generate the SBOM of the pushed image and to sign them, with cosign compatible signatures.
+// The SBOM and the signature are attached to the image in the registry.
+type AttestTask struct {
+	ContainerBuildBaseTask `json:",inline"`
+	// SBOMFormat the format of the SBOM generated for the image, none is generated when empty
+	SBOMFormat SBOMFormat `json:"sbomFormat,omitempty"`
+	// SigningKeySecret the secret holding the cosign private key, as `cosign.key`, and its password, as `cosign.password`.
+	// The image and its SBOM aren't signed when empty.
+	SigningKeySecret string `json:"signingKeySecret,omitempty"`
+	// Resources -- optional compute resource requirements for the attest containers
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // KanikoTaskCache is used to configure Kaniko cache
@@ -115,6 +144,9 @@ type KanikoTaskCache struct {
 	Enabled *bool `json:"enabled,omitempty"`
 	// the PVC used to store the cache
 	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	// the repository where to push and pull the cached layers, as an alternative or in addition to the PVC.
+	// Kaniko defaults it to the destination image repository suffixed by /cache.
+	Repository string `json:"repository,omitempty"`
 }
 
 // ContainerBuildPhase --
@@ -131,6 +163,8 @@ const (
 	ContainerBuildPhasePending ContainerBuildPhase = "Pending"
 	// ContainerBuildPhaseRunning --
 	ContainerBuildPhaseRunning ContainerBuildPhase = "Running"
+	// ContainerBuildPhaseAttesting the image is pushed, its SBOM and signature are being attached to it
+	ContainerBuildPhaseAttesting ContainerBuildPhase = "Attesting"
 	// ContainerBuildPhaseSucceeded --
 	ContainerBuildPhaseSucceeded ContainerBuildPhase = "Succeeded"
 	// ContainerBuildPhaseFailed --
@@ -186,6 +220,28 @@ type ContainerBuildStatus struct {
 	Duration string `json:"duration,omitempty"`
 	// reference to where the build resources are located
 	ResourceVolume *ContainerBuildResourceVolume `json:"resourceVolume,omitempty"`
+	// statistics of the cache usage, when the cache is enabled
+	Cache *ContainerBuildCacheStatus `json:"cache,omitempty"`
+	// references of the SBOM and the signature attached to the image, when attested
+	Attestation *ContainerBuildAttestationStatus `json:"attestation,omitempty"`
+}
+
+// ContainerBuildAttestationStatus references the artifacts attached to the image by the AttestTask
+type ContainerBuildAttestationStatus struct {
+	// the format of the SBOM
+	SBOMFormat SBOMFormat `json:"sbomFormat,omitempty"`
+	// reference of the signed SBOM attestation attached to the image
+	SBOM string `json:"sbom,omitempty"`
+	// reference of the image signature
+	Signature string `json:"signature,omitempty"`
+}
+
+// ContainerBuildCacheStatus reports how the cached layers were used by the build
+type ContainerBuildCacheStatus struct {
+	// number of commands whose cached layer was used
+	Hits int32 `json:"hits"`
+	// number of commands without cached layer, executed by the build
+	Misses int32 `json:"misses"`
 }
 
 // ContainerBuildFailure represent a message specifying the reason and the time of an event failure
@@ -25,6 +25,8 @@ import (
 type ObjectReference struct {
 	Namespace string `json:"namespace,omitempty"`
 	Name      string `json:"name,omitempty"`
+	// Annotations unstructured key value map attached to the object, for example, to propagate the trace context.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 func (o *ObjectReference) GetName() string {
@@ -35,6 +37,14 @@ func (o *ObjectReference) GetNamespace() string {
 	return o.Namespace
 }
 
+func (o *ObjectReference) GetAnnotations() map[string]string {
+	return o.Annotations
+}
+
+func (o *ObjectReference) SetAnnotations(annotations map[string]string) {
+	o.Annotations = annotations
+}
+
 func (o *ObjectReference) GetObjectKey() types.NamespacedName {
 	return types.NamespacedName{Name: o.Name, Namespace: o.Namespace}
 }
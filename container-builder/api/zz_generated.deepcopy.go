@@ -23,10 +23,27 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttestTask) DeepCopyInto(out *AttestTask) {
+	*out = *in
+	out.ContainerBuildBaseTask = in.ContainerBuildBaseTask
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttestTask.
+func (in *AttestTask) DeepCopy() *AttestTask {
+	if in == nil {
+		return nil
+	}
+	out := new(AttestTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerBuild) DeepCopyInto(out *ContainerBuild) {
 	*out = *in
-	out.ObjectReference = in.ObjectReference
+	in.ObjectReference.DeepCopyInto(&out.ObjectReference)
 	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
@@ -41,6 +58,21 @@ func (in *ContainerBuild) DeepCopy() *ContainerBuild {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerBuildAttestationStatus) DeepCopyInto(out *ContainerBuildAttestationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerBuildAttestationStatus.
+func (in *ContainerBuildAttestationStatus) DeepCopy() *ContainerBuildAttestationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerBuildAttestationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerBuildBaseTask) DeepCopyInto(out *ContainerBuildBaseTask) {
 	*out = *in
@@ -56,6 +88,21 @@ func (in *ContainerBuildBaseTask) DeepCopy() *ContainerBuildBaseTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerBuildCacheStatus) DeepCopyInto(out *ContainerBuildCacheStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerBuildCacheStatus.
+func (in *ContainerBuildCacheStatus) DeepCopy() *ContainerBuildCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerBuildCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerBuildCondition) DeepCopyInto(out *ContainerBuildCondition) {
 	*out = *in
@@ -168,6 +215,16 @@ func (in *ContainerBuildStatus) DeepCopyInto(out *ContainerBuildStatus) {
 		*out = new(ContainerBuildResourceVolume)
 		**out = **in
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(ContainerBuildCacheStatus)
+		**out = **in
+	}
+	if in.Attestation != nil {
+		in, out := &in.Attestation, &out.Attestation
+		*out = new(ContainerBuildAttestationStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerBuildStatus.
@@ -188,6 +245,11 @@ func (in *ContainerBuildTask) DeepCopyInto(out *ContainerBuildTask) {
 		*out = new(KanikoTask)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Attest != nil {
+		in, out := &in.Attest, &out.Attest
+		*out = new(AttestTask)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerBuildTask.
@@ -267,6 +329,13 @@ func (in *KanikoTaskCache) DeepCopy() *KanikoTaskCache {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
@@ -282,7 +351,7 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlatformContainerBuild) DeepCopyInto(out *PlatformContainerBuild) {
 	*out = *in
-	out.ObjectReference = in.ObjectReference
+	in.ObjectReference.DeepCopyInto(&out.ObjectReference)
 	in.Spec.DeepCopyInto(&out.Spec)
 }
 
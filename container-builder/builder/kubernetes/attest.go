@@ -0,0 +1,338 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/client"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/defaults"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/tracing"
+)
+
+const (
+	// CosignKeySecretKey the key of the cosign private key in the signing key secret
+	CosignKeySecretKey = "cosign.key"
+	// CosignPasswordSecretKey the key of the password of the cosign private key in the signing key secret, if encrypted
+	CosignPasswordSecretKey = "cosign.password"
+
+	attestWorkspaceDir    = "/workspace"
+	attestWorkspaceVolume = "attest-workspace"
+	cosignKeyDir          = "/var/run/cosign"
+	cosignKeyVolume       = "cosign-key"
+	attestDockerConfigDir = "/var/run/docker"
+)
+
+var attestRegistrySecrets = []registrySecret{
+	{
+		fileName:    "config.json",
+		mountPath:   attestDockerConfigDir,
+		destination: "config.json",
+	},
+	{
+		fileName:    corev1.DockerConfigJsonKey,
+		mountPath:   attestDockerConfigDir,
+		destination: "config.json",
+	},
+}
+
+// sbomFormat how a SBOM format is named by syft and by cosign
+type sbomFormat struct {
+	// the syft output format
+	output string
+	// the cosign predicate type, when the SBOM is attested
+	predicateType string
+	// the cosign SBOM type, when the SBOM is attached unsigned
+	attachType string
+}
+
+var sbomFormats = map[api.SBOMFormat]sbomFormat{
+	api.SBOMFormatSPDX:      {output: "spdx-json", predicateType: "spdxjson", attachType: "spdx"},
+	api.SBOMFormatCycloneDX: {output: "cyclonedx-json", predicateType: "cyclonedx", attachType: "cyclonedx"},
+}
+
+func newAttestImageAction() Action {
+	return &attestImageAction{}
+}
+
+// attestImageAction generates the SBOM of the image pushed by the build and signs them, in a pod running syft and cosign
+type attestImageAction struct {
+	baseAction
+}
+
+// Name returns a common name of the action.
+func (action *attestImageAction) Name() string {
+	return "attest-image"
+}
+
+// CanHandle tells whether this action can handle the build.
+func (action *attestImageAction) CanHandle(build *api.ContainerBuild) bool {
+	return build.Status.Phase == api.ContainerBuildPhaseAttesting
+}
+
+// Handle handles the builds.
+func (action *attestImageAction) Handle(ctx context.Context, build *api.ContainerBuild) (*api.ContainerBuild, error) {
+	task := getAttestTask(build)
+	if task == nil {
+		build.Status.Phase = api.ContainerBuildPhaseSucceeded
+		return build, nil
+	}
+
+	pod, err := getAttestPod(ctx, action.client, build)
+	if err != nil {
+		return nil, err
+	}
+
+	if pod == nil {
+		if len(build.Status.Digest) == 0 {
+			build.Status.Phase = api.ContainerBuildPhaseFailed
+			build.Status.Error = "cannot attest the image, its digest is unknown"
+			return build, nil
+		}
+		if pod, err = newAttestPod(ctx, action.client, build, task); err != nil {
+			return nil, err
+		}
+		if err = action.client.Create(ctx, pod); err != nil {
+			return nil, errors.Wrap(err, "cannot create attest pod")
+		}
+		return build, nil
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		build.Status.Phase = api.ContainerBuildPhaseSucceeded
+		build.Status.Attestation = newAttestationStatus(build, task)
+	case corev1.PodFailed:
+		build.Status.Phase = api.ContainerBuildPhaseFailed
+		build.Status.Error = "Attest pod failed"
+		if terminationMessage := getTerminationMessage(pod); terminationMessage != "" {
+			build.Status.Error = terminationMessage
+		}
+	}
+
+	return build, nil
+}
+
+// getAttestTask gets the AttestTask of the build, nil if the build doesn't attest the image
+func getAttestTask(build *api.ContainerBuild) *api.AttestTask {
+	for _, task := range build.Spec.Tasks {
+		if t := task.Attest; t != nil && (len(t.SBOMFormat) > 0 || len(t.SigningKeySecret) > 0) {
+			return t
+		}
+	}
+	return nil
+}
+
+// getAttestedImage gets the repository of the image pushed by the Kaniko task of the build, without tag
+func getAttestedImage(build *api.ContainerBuild) string {
+	for _, task := range build.Spec.Tasks {
+		if t := task.Kaniko; t != nil {
+			image := t.Registry.Address + "/" + t.Image
+			if colon := strings.LastIndex(image, ":"); colon > strings.LastIndex(image, "/") {
+				image = image[:colon]
+			}
+			return image
+		}
+	}
+	return ""
+}
+
+// newAttestationStatus references the artifacts cosign attaches to the image, tagged after its digest in the image repository
+func newAttestationStatus(build *api.ContainerBuild, task *api.AttestTask) *api.ContainerBuildAttestationStatus {
+	tag := getAttestedImage(build) + ":" + strings.Replace(build.Status.Digest, ":", "-", 1)
+	status := &api.ContainerBuildAttestationStatus{}
+	if len(task.SigningKeySecret) > 0 {
+		status.Signature = tag + ".sig"
+	}
+	if len(task.SBOMFormat) > 0 {
+		status.SBOMFormat = task.SBOMFormat
+		if len(task.SigningKeySecret) > 0 {
+			status.SBOM = tag + ".att"
+		} else {
+			status.SBOM = tag + ".sbom"
+		}
+	}
+	return status
+}
+
+// newAttestPod creates the pod running the attest steps in sequence, all but the last one as init containers:
+// the SBOM generation, the image signature, then the SBOM attestation, or attachment when not signed.
+func newAttestPod(ctx context.Context, c client.Client, build *api.ContainerBuild, task *api.AttestTask) (*corev1.Pod, error) {
+	image := getAttestedImage(build) + "@" + build.Status.Digest
+	insecure := false
+	registrySecret := ""
+	for _, t := range build.Spec.Tasks {
+		if t.Kaniko != nil {
+			insecure = t.Kaniko.Registry.Insecure
+			registrySecret = t.Kaniko.Registry.Secret
+		}
+	}
+
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: build.Namespace,
+			Name:      attestPodName(build),
+			Labels: map[string]string{
+				"kie.kogito.org/containerBuildContext": build.Name,
+				"kie.kogito.org/component":             "attest",
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Volumes: []corev1.Volume{{
+				Name:         attestWorkspaceVolume,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}},
+		},
+	}
+
+	env := []corev1.EnvVar{{Name: "DOCKER_CONFIG", Value: attestDockerConfigDir}}
+	volumeMounts := []corev1.VolumeMount{{Name: attestWorkspaceVolume, MountPath: attestWorkspaceDir}}
+	if registrySecret != "" {
+		secret, err := getRegistrySecret(ctx, c, build.Namespace, registrySecret, attestRegistrySecrets)
+		if err != nil {
+			return nil, err
+		}
+		addRegistrySecret(registrySecret, secret, &pod.Spec.Volumes, &volumeMounts, &env)
+	}
+	env = append(env, proxyFromEnvironment()...)
+
+	var steps []corev1.Container
+	sbomFile := path.Join(attestWorkspaceDir, "sbom.json")
+	format, withSBOM := sbomFormats[task.SBOMFormat]
+	if len(task.SBOMFormat) > 0 && !withSBOM {
+		return nil, fmt.Errorf("unsupported SBOM format %q", task.SBOMFormat)
+	}
+	if withSBOM {
+		sbomEnv := env
+		if insecure {
+			sbomEnv = append(append([]corev1.EnvVar{}, env...),
+				corev1.EnvVar{Name: "SYFT_REGISTRY_INSECURE_USE_HTTP", Value: "true"},
+				corev1.EnvVar{Name: "SYFT_REGISTRY_INSECURE_SKIP_TLS_VERIFY", Value: "true"})
+		}
+		steps = append(steps, newAttestContainer("sbom", defaults.SyftImage,
+			[]string{"registry:" + image, "--output", format.output + "=" + sbomFile}, sbomEnv, volumeMounts, task))
+	}
+
+	cosignFlags := []string{"--tlog-upload=false", "--yes"}
+	if insecure {
+		cosignFlags = append(cosignFlags, "--allow-insecure-registry", "--allow-http-registry")
+	}
+	if task.SigningKeySecret != "" {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: cosignKeyVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: task.SigningKeySecret,
+					Items:      []corev1.KeyToPath{{Key: CosignKeySecretKey, Path: CosignKeySecretKey}},
+				},
+			},
+		})
+		signVolumeMounts := append(append([]corev1.VolumeMount{}, volumeMounts...),
+			corev1.VolumeMount{Name: cosignKeyVolume, MountPath: cosignKeyDir, ReadOnly: true})
+		signEnv := append(append([]corev1.EnvVar{}, env...), corev1.EnvVar{
+			Name: "COSIGN_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: task.SigningKeySecret},
+					Key:                  CosignPasswordSecretKey,
+					Optional:             util.Pbool(true),
+				},
+			},
+		})
+		key := path.Join(cosignKeyDir, CosignKeySecretKey)
+		steps = append(steps, newAttestContainer("sign", defaults.CosignImage,
+			append(append([]string{"sign", "--key", key}, cosignFlags...), image), signEnv, signVolumeMounts, task))
+		if withSBOM {
+			steps = append(steps, newAttestContainer("attest", defaults.CosignImage,
+				append(append([]string{"attest", "--key", key, "--type", format.predicateType, "--predicate", sbomFile}, cosignFlags...), image),
+				signEnv, signVolumeMounts, task))
+		}
+	} else if withSBOM {
+		args := []string{"attach", "sbom", "--sbom", sbomFile, "--type", format.attachType}
+		if insecure {
+			args = append(args, "--allow-insecure-registry")
+		}
+		steps = append(steps, newAttestContainer("attach", defaults.CosignImage, append(args, image), env, volumeMounts, task))
+	}
+
+	pod.Spec.InitContainers = steps[:len(steps)-1]
+	pod.Spec.Containers = steps[len(steps)-1:]
+	tracing.Inject(ctx, pod)
+
+	return pod, nil
+}
+
+func newAttestContainer(name, image string, args []string, env []corev1.EnvVar, volumeMounts []corev1.VolumeMount, task *api.AttestTask) corev1.Container {
+	return corev1.Container{
+		Name:            name,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Args:            args,
+		Env:             env,
+		VolumeMounts:    volumeMounts,
+		Resources:       task.Resources,
+	}
+}
+
+func attestPodName(build *api.ContainerBuild) string {
+	return "kogito-" + strings.ToLower(build.Name) + "-attest"
+}
+
+func getAttestPod(ctx context.Context, c client.Client, build *api.ContainerBuild) (*corev1.Pod, error) {
+	pod := corev1.Pod{}
+	err := c.Get(ctx, types.NamespacedName{Name: attestPodName(build), Namespace: build.Namespace}, &pod)
+	if err != nil && k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pod, nil
+}
+
+func deleteAttestPod(ctx context.Context, c client.Client, build *api.ContainerBuild) error {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: build.Namespace,
+			Name:      attestPodName(build),
+		},
+	}
+
+	err := c.Delete(ctx, &pod)
+	if err != nil && k8serrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
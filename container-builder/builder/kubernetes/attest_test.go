@@ -0,0 +1,135 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/client"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/defaults"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/test"
+)
+
+const attestTestDigest = "sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+
+func newAttestTestBuild(t *testing.T, c client.Client, task api.AttestTask) *api.ContainerBuild {
+	platform := api.PlatformContainerBuild{
+		ObjectReference: api.ObjectReference{Namespace: "test", Name: "testPlatform"},
+		Spec: api.PlatformContainerBuildSpec{
+			BuildStrategy:   api.ContainerBuildStrategyPod,
+			PublishStrategy: api.PlatformBuildPublishStrategyKaniko,
+			Timeout:         &metav1.Duration{Duration: 5 * time.Minute},
+			Registry:        api.ContainerRegistrySpec{Address: "quay.io", Secret: "regcred"},
+		},
+	}
+	assert.NoError(t, c.Create(context.TODO(), &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "regcred"},
+		Type:       v1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{v1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+	}))
+	build, err := NewBuild(ContainerBuilderInfo{FinalImageName: "kiegroup/buildexample:latest", BuildUniqueName: "build1", Platform: platform}).
+		WithProperty(ImageAttestation, task).
+		WithResource("Dockerfile", []byte("FROM scratch")).
+		WithClient(c).
+		Schedule()
+	assert.NoError(t, err)
+
+	// the build pod pushes the image
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	pod := &v1.Pod{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: buildPodName(build), Namespace: "test"}, pod))
+	pod.Status.Phase = v1.PodSucceeded
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name:  "kanikotask",
+		State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0, Message: attestTestDigest}},
+	}}
+	assert.NoError(t, c.Update(context.TODO(), pod))
+
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, api.ContainerBuildPhaseAttesting, build.Status.Phase)
+	assert.Equal(t, attestTestDigest, build.Status.Digest)
+	return build
+}
+
+func TestAttestImage_SignedSBOM(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.NoError(t, err)
+	build := newAttestTestBuild(t, c, api.AttestTask{SBOMFormat: api.SBOMFormatSPDX, SigningKeySecret: "cosign"})
+
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, api.ContainerBuildPhaseAttesting, build.Status.Phase)
+
+	pod := &v1.Pod{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: attestPodName(build), Namespace: "test"}, pod))
+	image := "quay.io/kiegroup/buildexample@" + attestTestDigest
+	assert.Len(t, pod.Spec.InitContainers, 2)
+	assert.Equal(t, defaults.SyftImage, pod.Spec.InitContainers[0].Image)
+	assert.Equal(t, []string{"registry:" + image, "--output", "spdx-json=/workspace/sbom.json"}, pod.Spec.InitContainers[0].Args)
+	assert.Equal(t, defaults.CosignImage, pod.Spec.InitContainers[1].Image)
+	assert.Equal(t, []string{"sign", "--key", "/var/run/cosign/cosign.key", "--tlog-upload=false", "--yes", image}, pod.Spec.InitContainers[1].Args)
+	assert.Len(t, pod.Spec.Containers, 1)
+	assert.Equal(t, []string{"attest", "--key", "/var/run/cosign/cosign.key", "--type", "spdxjson", "--predicate", "/workspace/sbom.json", "--tlog-upload=false", "--yes", image}, pod.Spec.Containers[0].Args)
+	assert.Contains(t, pod.Spec.Containers[0].VolumeMounts, v1.VolumeMount{Name: "registry-secret", MountPath: "/var/run/docker", ReadOnly: true})
+	assert.Contains(t, pod.Spec.Containers[0].VolumeMounts, v1.VolumeMount{Name: "cosign-key", MountPath: "/var/run/cosign", ReadOnly: true})
+	assert.Contains(t, pod.Spec.Containers[0].Env, v1.EnvVar{Name: "DOCKER_CONFIG", Value: "/var/run/docker"})
+
+	pod.Status.Phase = v1.PodSucceeded
+	assert.NoError(t, c.Update(context.TODO(), pod))
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, api.ContainerBuildPhaseSucceeded, build.Status.Phase)
+	tag := "quay.io/kiegroup/buildexample:sha256-4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+	assert.Equal(t, &api.ContainerBuildAttestationStatus{SBOMFormat: api.SBOMFormatSPDX, SBOM: tag + ".att", Signature: tag + ".sig"}, build.Status.Attestation)
+}
+
+func TestAttestImage_UnsignedSBOM(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.NoError(t, err)
+	build := newAttestTestBuild(t, c, api.AttestTask{SBOMFormat: api.SBOMFormatCycloneDX})
+
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	pod := &v1.Pod{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: attestPodName(build), Namespace: "test"}, pod))
+	assert.Len(t, pod.Spec.InitContainers, 1)
+	assert.Equal(t, []string{"attach", "sbom", "--sbom", "/workspace/sbom.json", "--type", "cyclonedx", "quay.io/kiegroup/buildexample@" + attestTestDigest}, pod.Spec.Containers[0].Args)
+
+	pod.Status.Phase = v1.PodFailed
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name:  "attach",
+		State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Message: "UNAUTHORIZED"}},
+	}}
+	assert.NoError(t, c.Update(context.TODO(), pod))
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, api.ContainerBuildPhaseFailed, build.Status.Phase)
+	assert.Equal(t, "UNAUTHORIZED", build.Status.Error)
+	assert.Nil(t, build.Status.Attestation)
+}
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/client"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/tracing"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -67,6 +68,7 @@ func newBuildPod(ctx context.Context, c client.Client, build *api.ContainerBuild
 			}
 		}
 	}
+	tracing.Inject(ctx, pod)
 
 	return pod, nil
 }
@@ -18,17 +18,24 @@ import (
 	"context"
 	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/client"
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/util"
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/log"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/tracing"
 )
 
 type BuilderProperty string
 
-const KanikoCache BuilderProperty = "kaniko-cache"
+const (
+	KanikoCache BuilderProperty = "kaniko-cache"
+	// ImageAttestation an api.AttestTask generating the SBOM of the pushed image and signing them
+	ImageAttestation BuilderProperty = "image-attestation"
+)
 
 type ContainerBuilderInfo struct {
 	FinalImageName  string
@@ -72,6 +79,8 @@ type Scheduler interface {
 	// WithResource the actual file/resource to add to the builder. Might be called multiple times.
 	WithResource(target string, content []byte) Scheduler
 	WithClient(client client.Client) Scheduler
+	// WithContext the context used to schedule the build. Its trace context, if any, is propagated to the objects created by the builder.
+	WithContext(ctx context.Context) Scheduler
 	// WithResourceRequirements Kubernetes resource requirements to be passed to the underlying builder if necessary. For example, a builder pod might require specific resources underneath.
 	WithResourceRequirements(res corev1.ResourceRequirements) Scheduler
 	// WithAdditionalArgs array of strings to pass to the underlying builder. For example "--myarg=myvalue" or "MY_ENV=MY_VALUE". The args are passed separated by spaces.
@@ -83,6 +92,8 @@ type Scheduler interface {
 
 type ContainerBuilder interface {
 	WithClient(client client.Client) ContainerBuilder
+	// WithContext the context used to reconcile the build. If it doesn't carry a span, the trace context stored in the ContainerBuild annotations is used instead.
+	WithContext(ctx context.Context) ContainerBuilder
 	CancelBuild() (*api.ContainerBuild, error)
 	Reconcile() (*api.ContainerBuild, error)
 }
@@ -122,6 +133,11 @@ func (s *scheduler) WithClient(client client.Client) Scheduler {
 	return s.Scheduler
 }
 
+func (s *scheduler) WithContext(ctx context.Context) Scheduler {
+	s.builder.WithContext(ctx)
+	return s.Scheduler
+}
+
 func (s *scheduler) WithResource(target string, content []byte) Scheduler {
 	s.Resources = append(s.Resources, resource{target, content})
 	return s.Scheduler
@@ -148,6 +164,7 @@ func (s *scheduler) Schedule() (*api.ContainerBuild, error) {
 	if err := mountResourcesWithConfigMap(&s.builder.Context, &s.Resources); err != nil {
 		return nil, err
 	}
+	tracing.Inject(s.builder.Context.C, s.builder.Context.ContainerBuild)
 	return s.builder.Reconcile()
 }
 
@@ -156,9 +173,24 @@ func (b *builder) WithClient(client client.Client) ContainerBuilder {
 	return b
 }
 
+func (b *builder) WithContext(ctx context.Context) ContainerBuilder {
+	b.Context.C = ctx
+	return b
+}
+
 // Reconcile idempotent build flow control.
 // Can be called many times to check/update the current status of the build instance, indexed by the Platform and ContainerBuild Name.
-func (b *builder) Reconcile() (*api.ContainerBuild, error) {
+func (b *builder) Reconcile() (build *api.ContainerBuild, err error) {
+	ctx := b.Context.C
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = tracing.Extract(ctx, b.Context.ContainerBuild)
+	}
+	ctx, span := tracing.Tracer().Start(ctx, "ContainerBuilder.Reconcile", trace.WithAttributes(
+		attribute.String("build.name", b.Context.ContainerBuild.Name),
+		attribute.String("build.namespace", b.Context.ContainerBuild.Namespace),
+		attribute.String("build.phase", string(b.Context.ContainerBuild.Status.Phase))))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	var actions []Action
 	switch b.Context.ContainerBuild.Spec.Strategy {
 	case api.ContainerBuildStrategyPod:
@@ -167,6 +199,7 @@ func (b *builder) Reconcile() (*api.ContainerBuild, error) {
 			newInitializePodAction(),
 			newScheduleAction(),
 			newMonitorPodAction(),
+			newAttestImageAction(),
 			newErrorRecoveryAction(),
 		}
 	}
@@ -179,7 +212,7 @@ func (b *builder) Reconcile() (*api.ContainerBuild, error) {
 
 		if a.CanHandle(target) {
 			b.L.Infof("Invoking action %s", a.Name())
-			newTarget, err := a.Handle(b.Context.C, target)
+			newTarget, err := b.handle(ctx, a, target)
 			if err != nil {
 				b.L.Errorf(err, "Failed to invoke action %s", a.Name())
 				return nil, err
@@ -203,6 +236,13 @@ func (b *builder) Reconcile() (*api.ContainerBuild, error) {
 	return target, nil
 }
 
+// handle invokes the given action within its own span.
+func (b *builder) handle(ctx context.Context, a Action, target *api.ContainerBuild) (newTarget *api.ContainerBuild, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Action.Handle", trace.WithAttributes(attribute.String("action", a.Name())))
+	defer func() { tracing.EndSpan(span, err) }()
+	return a.Handle(ctx, target)
+}
+
 func (b *builder) CancelBuild() (*api.ContainerBuild, error) {
 	//TODO implement me
 	panic("implement me")
@@ -27,6 +27,7 @@ import (
 type kanikoScheduler struct {
 	*scheduler
 	KanikoTask *api.KanikoTask
+	AttestTask *api.AttestTask
 }
 
 type kanikoSchedulerHandler struct {
@@ -66,6 +67,7 @@ func (k kanikoSchedulerHandler) CreateScheduler(info ContainerBuilderInfo, build
 			Resources: make([]resource, 0),
 		},
 		&kanikoTask,
+		nil,
 	}
 	// we hold our own reference for the default methods to return the right object
 	sched.Scheduler = sched
@@ -80,6 +82,11 @@ func (sk *kanikoScheduler) WithProperty(property BuilderProperty, object interfa
 	if property == KanikoCache {
 		sk.KanikoTask.Cache = object.(api.KanikoTaskCache)
 	}
+	if property == ImageAttestation {
+		attestTask := object.(api.AttestTask)
+		attestTask.Name = "AttestTask"
+		sk.AttestTask = &attestTask
+	}
 	return sk
 }
 
@@ -101,5 +108,8 @@ func (sk *kanikoScheduler) Schedule() (*api.ContainerBuild, error) {
 			break
 		}
 	}
+	if sk.AttestTask != nil {
+		sk.builder.Context.ContainerBuild.Spec.Tasks = append(sk.builder.Context.ContainerBuild.Spec.Tasks, api.ContainerBuildTask{Attest: sk.AttestTask})
+	}
 	return sk.scheduler.Schedule()
 }
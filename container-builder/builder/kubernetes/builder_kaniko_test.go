@@ -102,7 +102,13 @@ func TestNewBuildWithKanikoCustomizations(t *testing.T) {
 	err = c.Get(context.TODO(), types.NamespacedName{Name: podName, Namespace: ns}, pod)
 	assert.NoError(t, err)
 	assert.NotNil(t, pod)
-	assert.Len(t, pod.Spec.Volumes, 1)
+	assert.Len(t, pod.Spec.Volumes, 2)
+	assert.Contains(t, pod.Spec.Volumes, v1.Volume{
+		Name:         "kaniko-cache",
+		VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "kaniko-cache-pv"}},
+	})
+	assert.Contains(t, pod.Spec.Containers[0].VolumeMounts, v1.VolumeMount{Name: "kaniko-cache", MountPath: "/kaniko/cache", ReadOnly: true})
 
 	assert.Subset(t, pod.Spec.Containers[0].Args, addFlags)
+	assert.Subset(t, pod.Spec.Containers[0].Args, []string{"--cache=true", "--cache-dir=/kaniko/cache"})
 }
@@ -23,11 +23,16 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/test"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/tracing"
 )
 
 func TestNewBuild(t *testing.T) {
@@ -81,3 +86,55 @@ func TestNewBuild(t *testing.T) {
 	assert.NotNil(t, pod)
 	assert.Len(t, pod.Spec.Volumes, 1)
 }
+
+func TestNewBuild_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	ns := "test"
+	c, err := test.NewFakeClient()
+	assert.NoError(t, err)
+
+	platform := api.PlatformContainerBuild{
+		ObjectReference: api.ObjectReference{Namespace: ns, Name: "testPlatform"},
+		Spec: api.PlatformContainerBuildSpec{
+			BuildStrategy:   api.ContainerBuildStrategyPod,
+			PublishStrategy: api.PlatformBuildPublishStrategyKaniko,
+			Timeout:         &metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+	ctx, parent := provider.Tracer("test").Start(context.TODO(), "parent")
+	build, err := NewBuild(ContainerBuilderInfo{FinalImageName: "quay.io/kiegroup/buildexample:latest", BuildUniqueName: "build1", Platform: platform}).
+		WithClient(c).
+		WithContext(ctx).
+		WithResource("Dockerfile", []byte("FROM scratch")).
+		Schedule()
+	parent.End()
+	assert.NoError(t, err)
+	assert.Contains(t, build.Annotations, tracing.AnnotationPrefix+"traceparent")
+
+	// the trace context is recovered from the build annotations
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, api.ContainerBuildPhasePending, build.Status.Phase)
+	build, err = FromBuild(build).WithClient(c).Reconcile()
+	assert.NoError(t, err)
+
+	pod := &v1.Pod{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: buildPodName(build), Namespace: ns}, pod))
+	assert.Contains(t, pod.Annotations, tracing.AnnotationPrefix+"traceparent")
+
+	traceID := parent.SpanContext().TraceID()
+	var actions []string
+	for _, span := range recorder.Ended() {
+		assert.Equal(t, traceID, span.SpanContext().TraceID())
+		for _, attr := range span.Attributes() {
+			if attr.Key == "action" {
+				actions = append(actions, attr.Value.AsString())
+			}
+		}
+	}
+	assert.Equal(t, []string{"initialize-pod", "schedule", "monitor-pod"}, actions)
+}
@@ -47,6 +47,9 @@ func (action *initializePodAction) Handle(ctx context.Context, build *api.Contai
 	if err := deleteBuilderPod(ctx, action.client, build); err != nil {
 		return nil, errors.Wrap(err, "cannot delete build pod")
 	}
+	if err := deleteAttestPod(ctx, action.client, build); err != nil {
+		return nil, errors.Wrap(err, "cannot delete attest pod")
+	}
 
 	pod, err := getBuilderPod(ctx, action.client, build)
 	if err != nil || pod != nil {
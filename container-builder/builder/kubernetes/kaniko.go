@@ -15,7 +15,9 @@
 package kubernetes
 
 import (
+	"bufio"
 	"context"
+	"io"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +29,19 @@ import (
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/registry"
 )
 
+const (
+	// kanikoCacheDir where the base images warmed by the platform are mounted in the build pod
+	kanikoCacheDir    = "/kaniko/cache"
+	kanikoCacheVolume = "kaniko-cache"
+
+	// the Kaniko output lines reporting whether a command was served from the cache
+	kanikoCacheHitLog  = "Using caching version of cmd"
+	kanikoCacheMissLog = "No cached layer found for cmd"
+
+	// kanikoDigestFile Kaniko writes the digest of the pushed image to the termination message of its container
+	kanikoDigestFile = "/dev/termination-log"
+)
+
 var (
 	gcrKanikoRegistrySecret = registrySecret{
 		fileName:    "kaniko-secret.json",
@@ -78,6 +93,7 @@ func addKanikoTaskToPod(ctx context.Context, c client.Client, build *api.Contain
 		"--dockerfile=Dockerfile",
 		"--context=dir://" + task.ContextDir,
 		"--destination=" + task.Registry.Address + "/" + task.Image,
+		"--digest-file=" + kanikoDigestFile,
 	}
 
 	if task.AdditionalFlags != nil && len(task.AdditionalFlags) > 0 {
@@ -89,6 +105,15 @@ func addKanikoTaskToPod(ctx context.Context, c client.Client, build *api.Contain
 	}
 
 	affinity := &corev1.Affinity{}
+	var nodeSelector map[string]string
+	if task.Platform != "" {
+		platform, err := registry.ParsePlatform(task.Platform)
+		if err != nil {
+			return err
+		}
+		nodeSelector = platform.NodeSelector()
+		args = append(args, "--custom-platform="+task.Platform)
+	}
 	env := make([]corev1.EnvVar, 0)
 	volumes := make([]corev1.Volume, 0)
 	volumeMounts := make([]corev1.VolumeMount, 0)
@@ -106,6 +131,13 @@ func addKanikoTaskToPod(ctx context.Context, c client.Client, build *api.Contain
 		args = append(args, "--insecure-pull")
 	}
 
+	if task.Cache.Enabled != nil && *task.Cache.Enabled {
+		args = append(args, kanikoCacheArgs(task.Cache)...)
+		if task.Cache.PersistentVolumeClaim != "" {
+			addKanikoCacheVolume(task.Cache.PersistentVolumeClaim, &volumes, &volumeMounts)
+		}
+	}
+
 	// TODO: should be handled by a mount build context handler instead since we can have many possibilities
 	if err := addResourcesToVolume(ctx, c, task.PublishTask, build, &volumes, &volumeMounts); err != nil {
 		return err
@@ -127,8 +159,58 @@ func addKanikoTaskToPod(ctx context.Context, c client.Client, build *api.Contain
 
 	// We may want to handle possible conflicts
 	pod.Spec.Affinity = affinity
+	pod.Spec.NodeSelector = nodeSelector
 	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
 	pod.Spec.Containers = append(pod.Spec.Containers, container)
 
 	return nil
 }
+
+// kanikoCacheArgs the Kaniko flags enabling the cache: the base images are read from the cache directory if mounted,
+// and the layers pulled from and pushed to the cache repository.
+func kanikoCacheArgs(cache api.KanikoTaskCache) []string {
+	args := []string{"--cache=true"}
+	if cache.PersistentVolumeClaim != "" {
+		args = append(args, "--cache-dir="+kanikoCacheDir)
+	}
+	if cache.Repository != "" {
+		args = append(args, "--cache-repo="+cache.Repository)
+	}
+	return args
+}
+
+func addKanikoCacheVolume(pvcName string, volumes *[]corev1.Volume, volumeMounts *[]corev1.VolumeMount) {
+	*volumes = append(*volumes, corev1.Volume{
+		Name: kanikoCacheVolume,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: pvcName,
+			},
+		},
+	})
+	// the cache is warmed by the platform, builds only read it
+	*volumeMounts = append(*volumeMounts, corev1.VolumeMount{
+		Name:      kanikoCacheVolume,
+		MountPath: kanikoCacheDir,
+		ReadOnly:  true,
+	})
+}
+
+// parseKanikoCacheStatus counts the commands served from the cache, or not, in the Kaniko output
+func parseKanikoCacheStatus(output io.Reader) (*api.ContainerBuildCacheStatus, error) {
+	status := &api.ContainerBuildCacheStatus{}
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.Contains(line, kanikoCacheHitLog):
+			status.Hits++
+		case strings.Contains(line, kanikoCacheMissLog):
+			status.Misses++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
+)
+
+func Test_kanikoCacheArgs(t *testing.T) {
+	assert.Equal(t, []string{"--cache=true", "--cache-dir=/kaniko/cache"}, kanikoCacheArgs(api.KanikoTaskCache{PersistentVolumeClaim: "cache"}))
+	assert.Equal(t, []string{"--cache=true", "--cache-repo=quay.io/kiegroup/cache"}, kanikoCacheArgs(api.KanikoTaskCache{Repository: "quay.io/kiegroup/cache"}))
+}
+
+func Test_parseKanikoCacheStatus(t *testing.T) {
+	output := `INFO[0000] Retrieving image manifest quay.io/kiegroup/kogito-swf-builder:latest
+INFO[0001] Checking for cached layer quay.io/kiegroup/cache:4b0ec5a2...
+INFO[0001] Using caching version of cmd: COPY --chown=1001 . ./resources
+INFO[0001] Checking for cached layer quay.io/kiegroup/cache:77e1f2a8...
+INFO[0002] Using caching version of cmd: RUN /home/kogito/launch/build-app.sh ./resources
+INFO[0002] Checking for cached layer quay.io/kiegroup/cache:0cb8a1d2...
+INFO[0002] No cached layer found for cmd RUN ls -la ./resources
+INFO[0003] Pushing image to quay.io/kiegroup/greeting:latest`
+
+	status, err := parseKanikoCacheStatus(strings.NewReader(output))
+	assert.NoError(t, err)
+	assert.Equal(t, &api.ContainerBuildCacheStatus{Hits: 2, Misses: 1}, status)
+}
+
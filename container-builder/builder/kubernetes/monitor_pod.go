@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-version"
@@ -122,14 +123,20 @@ func (action *monitorPodAction) Handle(ctx context.Context, build *api.Container
 		for _, task := range build.Spec.Tasks {
 			if t := task.Kaniko; t != nil {
 				build.Status.Image = t.Image
+				build.Status.Digest = action.getKanikoDigest(pod, t)
+				build.Status.Cache = action.getKanikoCacheStatus(ctx, pod, t)
 				break
 			}
 		}
+		// the image is pushed, it still has to be attested before the build is over
+		if getAttestTask(build) != nil {
+			build.Status.Phase = api.ContainerBuildPhaseAttesting
+		}
 
 	case corev1.PodFailed:
 		phase := api.ContainerBuildPhaseFailed
 		message := "Pod failed"
-		if terminationMessage := action.getTerminationMessage(pod); terminationMessage != "" {
+		if terminationMessage := getTerminationMessage(pod); terminationMessage != "" {
 			message = terminationMessage
 		}
 		if pod.DeletionTimestamp != nil {
@@ -238,6 +245,25 @@ func (action *monitorPodAction) patchPod(ctx context.Context, pod *corev1.Pod, m
 	return nil
 }
 
+// getKanikoCacheStatus parses the cache statistics from the Kaniko container logs, nil if the cache is disabled or the logs unavailable
+func (action *monitorPodAction) getKanikoCacheStatus(ctx context.Context, pod *corev1.Pod, task *api.KanikoTask) *api.ContainerBuildCacheStatus {
+	if task.Cache.Enabled == nil || !*task.Cache.Enabled {
+		return nil
+	}
+	logs, err := action.client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: strings.ToLower(task.Name)}).Stream(ctx)
+	if err != nil {
+		action.L.Error(err, "Cannot read the Kaniko logs to report the cache usage", "pod", pod.Name)
+		return nil
+	}
+	defer logs.Close()
+	status, err := parseKanikoCacheStatus(logs)
+	if err != nil {
+		action.L.Error(err, "Cannot parse the Kaniko logs to report the cache usage", "pod", pod.Name)
+		return nil
+	}
+	return status
+}
+
 func (action *monitorPodAction) getTerminatedTime(pod *corev1.Pod) metav1.Time {
 	var finishedAt metav1.Time
 
@@ -258,7 +284,18 @@ func (action *monitorPodAction) getTerminatedTime(pod *corev1.Pod) metav1.Time {
 	return finishedAt
 }
 
-func (action *monitorPodAction) getTerminationMessage(pod *corev1.Pod) string {
+// getKanikoDigest gets the digest of the pushed image, written by Kaniko to the termination message of its container
+func (action *monitorPodAction) getKanikoDigest(pod *corev1.Pod, task *api.KanikoTask) string {
+	for _, container := range pod.Status.ContainerStatuses {
+		if t := container.State.Terminated; container.Name == strings.ToLower(task.Name) && t != nil && t.ExitCode == 0 {
+			return strings.TrimSpace(t.Message)
+		}
+	}
+	return ""
+}
+
+// getTerminationMessage gets the termination messages of the failed containers of the pod
+func getTerminationMessage(pod *corev1.Pod) string {
 	var terminationMessages []terminationMessage
 
 	var containers []corev1.ContainerStatus
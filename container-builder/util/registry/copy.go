@@ -0,0 +1,296 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MediaTypeDockerManifestList is the media type of a Docker manifest list, the multi-platform Docker image
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	defaultCopyTimeout = 10 * time.Minute
+	defaultCopyWorkers = 4
+)
+
+// RegistryAccess configures how a registry is contacted
+type RegistryAccess struct {
+	// Insecure uses plain HTTP to contact the registry
+	Insecure bool
+	// RootCAs trusted when contacting the registry, the system ones are used when nil
+	RootCAs *x509.CertPool
+	// Username and Password to authenticate with, anonymous access is attempted when empty
+	Username string
+	Password string
+}
+
+// CopyImageOptions configures the copy of an image from a repository to another, in the same registry or not
+type CopyImageOptions struct {
+	// Source is the copied image, pinned by digest, e.g. quay.io/dev/greeting@sha256:...
+	Source       string
+	SourceAccess RegistryAccess
+	// Target is the name of the copy, e.g. quay.io/prod/greeting:1.0
+	Target       string
+	TargetAccess RegistryAccess
+	// Timeout of each request sent to the registries, including the blob transfers. 10 minutes by default.
+	Timeout time.Duration
+	// Workers is the number of blobs transferred in parallel. 4 by default.
+	Workers int
+}
+
+// manifestReferences the content referenced by an image manifest, an OCI image index or a Docker manifest list
+type manifestReferences struct {
+	Config    *manifestReference  `json:"config,omitempty"`
+	Layers    []manifestReference `json:"layers,omitempty"`
+	Manifests []manifestReference `json:"manifests,omitempty"`
+}
+
+type manifestReference struct {
+	MediaType string   `json:"mediaType"`
+	Digest    string   `json:"digest"`
+	URLs      []string `json:"urls,omitempty"`
+}
+
+// CopyImage copies the source image to the target, without pulling it: its blobs are transferred from registry to registry,
+// then its manifests are pushed unchanged, so the copy keeps the digest of the source. The blobs already in the target
+// repository are skipped. Multi-platform images are copied with the images of all their platforms.
+// It returns the digest of the copied image.
+func CopyImage(ctx context.Context, options CopyImageOptions) (string, error) {
+	name, digest, found := strings.Cut(options.Source, "@")
+	if !found || !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("invalid image %q, expected registry/repository@sha256:digest", options.Source)
+	}
+	source, err := newCopyEndpoint(ctx, name, options.SourceAccess, options.Timeout)
+	if err != nil {
+		return "", err
+	}
+	defer source.close()
+	target, err := newCopyEndpoint(ctx, options.Target, options.TargetAccess, options.Timeout)
+	if err != nil {
+		return "", err
+	}
+	defer target.close()
+	workers := options.Workers
+	if workers <= 0 {
+		workers = defaultCopyWorkers
+	}
+	_, _, tag, _ := splitImage(options.Target)
+	if err = copyManifest(ctx, source, target, digest, tag, workers); err != nil {
+		return "", err
+	}
+	return digest, nil
+}
+
+func newCopyEndpoint(ctx context.Context, image string, access RegistryAccess, timeout time.Duration) (*preflight, error) {
+	host, repository, _, err := splitImage(image)
+	if err != nil {
+		return nil, err
+	}
+	scheme := "https"
+	if access.Insecure {
+		scheme = "http"
+	}
+	if timeout == 0 {
+		timeout = defaultCopyTimeout
+	}
+	p := newPreflight(scheme, host, repository, PreflightOptions{RegistryAccess: access, Timeout: timeout})
+	if err = p.ping(ctx); err != nil {
+		p.close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// copyManifest copies the content referenced by the manifest with the given digest, then the manifest itself, tagged with the reference
+func copyManifest(ctx context.Context, source, target *preflight, digest, reference string, workers int) error {
+	mediaType, content, err := source.getManifest(ctx, digest)
+	if err != nil {
+		return err
+	}
+	references := manifestReferences{}
+	if err = json.Unmarshal(content, &references); err != nil {
+		return fmt.Errorf("invalid manifest %s@%s: %v", source.repository, digest, err)
+	}
+	for _, manifest := range references.Manifests {
+		if err = copyManifest(ctx, source, target, manifest.Digest, manifest.Digest, workers); err != nil {
+			return err
+		}
+	}
+	blobs := references.Layers
+	if references.Config != nil {
+		blobs = append(blobs, *references.Config)
+	}
+	if err = copyBlobs(ctx, source, target, blobs, workers); err != nil {
+		return err
+	}
+
+	manifest := target.base.JoinPath("/v2/", target.repository, "/manifests/", reference).String()
+	resp, err := target.send(ctx, http.MethodPut, manifest, http.Header{"Content-Type": {mediaType}}, content)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("%w: unexpected status %s when pushing the manifest %s/%s@%s", ErrPushDenied, resp.Status, target.base.Host, target.repository, digest)
+	}
+	return nil
+}
+
+// copyBlobs copies the blobs with the given number of workers, stopping at the first failure
+func copyBlobs(ctx context.Context, source, target *preflight, blobs []manifestReference, workers int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	digests := make(chan string)
+	errs := make(chan error, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for digest := range digests {
+				if err := copyBlob(ctx, source, target, digest); err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	for _, blob := range blobs {
+		// foreign layers are downloaded from their URLs, they aren't pushed to the registries
+		if len(blob.URLs) > 0 {
+			continue
+		}
+		select {
+		case digests <- blob.Digest:
+		case <-ctx.Done():
+		}
+	}
+	close(digests)
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// getManifest gets the media type and the content of the manifest with the given tag or digest. When referenced by digest,
+// the content is verified against the digest.
+func (p *preflight) getManifest(ctx context.Context, reference string) (string, []byte, error) {
+	image := p.base.Host + "/" + p.repository + ":" + reference
+	if strings.HasPrefix(reference, "sha256:") {
+		image = p.base.Host + "/" + p.repository + "@" + reference
+	}
+	target := p.base.JoinPath("/v2/", p.repository, "/manifests/", reference).String()
+	accept := http.Header{"Accept": {MediaTypeImageIndex, MediaTypeImageManifest, MediaTypeDockerManifestList, MediaTypeDockerManifest}}
+	resp, err := p.send(ctx, http.MethodGet, target, accept, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("unexpected status %s for the manifest %s", resp.Status, image)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	if actual := fmt.Sprintf("sha256:%x", sha256.Sum256(content)); strings.HasPrefix(reference, "sha256:") && actual != reference {
+		return "", nil, fmt.Errorf("the manifest %s doesn't match its digest, got %s", image, actual)
+	}
+	mediaType := resp.Header.Get("Content-Type")
+	if mediaType == "" {
+		mediaType = MediaTypeDockerManifest
+	}
+	return mediaType, content, nil
+}
+
+// copyBlob copies the blob with the given digest unless the target repository already has it. Within the same registry,
+// the blob is mounted from the source repository, otherwise it's streamed from the source to the target registry.
+func copyBlob(ctx context.Context, source, target *preflight, digest string) error {
+	blob := target.base.JoinPath("/v2/", target.repository, "/blobs/", digest).String()
+	resp, err := target.do(ctx, http.MethodHead, blob)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	uploads := target.base.JoinPath("/v2/", target.repository, "/blobs/uploads/")
+	if source.base.Host == target.base.Host {
+		uploads.RawQuery = url.Values{"mount": {digest}, "from": {source.repository}}.Encode()
+	}
+	if resp, err = target.do(ctx, http.MethodPost, uploads.String()); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		// mounted
+		return nil
+	case http.StatusAccepted:
+	default:
+		return fmt.Errorf("%w: unexpected status %s when pushing to %s/%s", ErrPushDenied, resp.Status, target.base.Host, target.repository)
+	}
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return fmt.Errorf("invalid upload location from %s: %v", target.base.Host, err)
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	content, err := source.do(ctx, http.MethodGet, source.base.JoinPath("/v2/", source.repository, "/blobs/", digest).String())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	defer content.Body.Close()
+	if content.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s for the blob %s/%s@%s", content.Status, source.base.Host, source.repository, digest)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, location.String(), content.Body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = content.ContentLength
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if target.authorization != "" {
+		req.Header.Set("Authorization", target.authorization)
+	}
+	if resp, err = target.client.Do(req); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("%w: unexpected status %s when pushing the blob %s/%s@%s", ErrPushDenied, resp.Status, target.base.Host, target.repository, digest)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/test"
+)
+
+func TestCopyImage(t *testing.T) {
+	dev := &test.RegistryStandIn{Username: "dev", Password: "secret", Bearer: true}
+	devServer := dev.Start(true)
+	defer devServer.Close()
+	prod := &test.RegistryStandIn{Username: "prod", Password: "secret"}
+	prodServer := prod.Start(true)
+	defer prodServer.Close()
+
+	config := dev.PutBlob("dev/greeting", []byte(`{"architecture":"amd64"}`))
+	layer := dev.PutBlob("dev/greeting", []byte("layer"))
+	manifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"config":{"mediaType":"application/vnd.docker.container.image.v1+json","digest":%q},"layers":[{"digest":%q},{"digest":"sha256:foreign","urls":["https://example.com/layer"]}]}`,
+		MediaTypeDockerManifest, config, layer))
+	image := dev.PutManifest("dev/greeting", "", MediaTypeDockerManifest, manifest)
+	index := []byte(fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"manifests":[{"mediaType":%q,"digest":%q}]}`, MediaTypeImageIndex, MediaTypeDockerManifest, image))
+	digest := dev.PutManifest("dev/greeting", "1.0", MediaTypeImageIndex, index)
+
+	copied, err := CopyImage(context.TODO(), CopyImageOptions{
+		Source:       hostOf(devServer.URL) + "/dev/greeting:1.0@" + digest,
+		SourceAccess: RegistryAccess{Insecure: true, Username: "dev", Password: "secret"},
+		Target:       hostOf(prodServer.URL) + "/prod/greeting:1.0",
+		TargetAccess: RegistryAccess{Insecure: true, Username: "prod", Password: "secret"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, digest, copied)
+
+	mediaType, content, found := prod.Manifest("prod/greeting", "1.0")
+	assert.True(t, found)
+	assert.Equal(t, MediaTypeImageIndex, mediaType)
+	assert.Equal(t, index, content)
+	_, content, found = prod.Manifest("prod/greeting", image)
+	assert.True(t, found)
+	assert.Equal(t, manifest, content)
+	for _, blob := range []string{config, layer} {
+		_, found = prod.Blob("prod/greeting", blob)
+		assert.True(t, found, blob)
+	}
+
+	t.Run("same registry", func(t *testing.T) {
+		_, err := CopyImage(context.TODO(), CopyImageOptions{
+			Source:       hostOf(devServer.URL) + "/dev/greeting@" + image,
+			SourceAccess: RegistryAccess{Insecure: true, Username: "dev", Password: "secret"},
+			Target:       hostOf(devServer.URL) + "/staging/greeting",
+			TargetAccess: RegistryAccess{Insecure: true, Username: "dev", Password: "secret"},
+		})
+		assert.NoError(t, err)
+		_, _, found := dev.Manifest("staging/greeting", "latest")
+		assert.True(t, found)
+		_, found = dev.Blob("staging/greeting", layer)
+		assert.True(t, found)
+		// the blobs are mounted, not uploaded
+		assert.NotContains(t, dev.Uploads(), "staging/greeting")
+	})
+	t.Run("not pinned by digest", func(t *testing.T) {
+		_, err := CopyImage(context.TODO(), CopyImageOptions{
+			Source: hostOf(devServer.URL) + "/dev/greeting:1.0",
+			Target: hostOf(prodServer.URL) + "/prod/greeting:1.0",
+		})
+		assert.ErrorContains(t, err, "expected registry/repository@sha256:digest")
+	})
+	t.Run("unknown digest", func(t *testing.T) {
+		_, err := CopyImage(context.TODO(), CopyImageOptions{
+			Source:       hostOf(devServer.URL) + "/dev/greeting@sha256:0000",
+			SourceAccess: RegistryAccess{Insecure: true, Username: "dev", Password: "secret"},
+			Target:       hostOf(prodServer.URL) + "/prod/greeting:1.0",
+			TargetAccess: RegistryAccess{Insecure: true, Username: "prod", Password: "secret"},
+		})
+		assert.ErrorContains(t, err, "404")
+	})
+	t.Run("blob push denied", func(t *testing.T) {
+		denied := &test.RegistryStandIn{PushDenied: true}
+		deniedServer := denied.Start(true)
+		defer deniedServer.Close()
+
+		_, err := CopyImage(context.TODO(), CopyImageOptions{
+			Source:       hostOf(devServer.URL) + "/dev/greeting@" + image,
+			SourceAccess: RegistryAccess{Insecure: true, Username: "dev", Password: "secret"},
+			Target:       hostOf(deniedServer.URL) + "/prod/greeting:1.0",
+			TargetAccess: RegistryAccess{Insecure: true},
+			Workers:      2,
+		})
+		assert.ErrorIs(t, err, ErrPushDenied)
+	})
+}
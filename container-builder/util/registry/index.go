@@ -0,0 +1,152 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MediaTypeImageIndex is the media type of the OCI image index referencing the per-platform images
+	MediaTypeImageIndex = "application/vnd.oci.image.index.v1+json"
+	// MediaTypeImageManifest is the media type of an OCI image manifest
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+	// MediaTypeDockerManifest is the media type of a Docker image manifest, as pushed by Kaniko
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// ImageIndexManifest is an image of the index, built for a single platform
+type ImageIndexManifest struct {
+	// Digest of the image manifest, in the same repository as the index
+	Digest string
+	// Platform the image is built for, as `os/arch[/variant]`, e.g. linux/arm64
+	Platform string
+}
+
+// ImageIndexOptions configures the push of an OCI image index
+type ImageIndexOptions struct {
+	// Image is the name of the index, e.g. quay.io/kiegroup/greeting:latest
+	Image string
+	// Manifests are the per-platform images referenced by the index
+	Manifests []ImageIndexManifest
+	RegistryAccess
+	// Timeout of each request sent to the registry
+	Timeout time.Duration
+}
+
+type imageIndex struct {
+	SchemaVersion int                  `json:"schemaVersion"`
+	MediaType     string               `json:"mediaType"`
+	Manifests     []imageIndexManifest `json:"manifests"`
+}
+
+type imageIndexManifest struct {
+	MediaType string   `json:"mediaType"`
+	Digest    string   `json:"digest"`
+	Size      int64    `json:"size"`
+	Platform  Platform `json:"platform"`
+}
+
+// PushImageIndex pushes an OCI image index referencing the per-platform images, already pushed in the repository of the index.
+// It returns the digest of the index.
+func PushImageIndex(ctx context.Context, options ImageIndexOptions) (string, error) {
+	host, repository, tag, err := splitImage(options.Image)
+	if err != nil {
+		return "", err
+	}
+	scheme := "https"
+	if options.Insecure {
+		scheme = "http"
+	}
+	p := newPreflight(scheme, host, repository, PreflightOptions{RegistryAccess: options.RegistryAccess, Timeout: options.Timeout})
+	defer p.close()
+	if err = p.ping(ctx); err != nil {
+		return "", err
+	}
+
+	index := imageIndex{SchemaVersion: 2, MediaType: MediaTypeImageIndex}
+	for _, manifest := range options.Manifests {
+		platform, err := ParsePlatform(manifest.Platform)
+		if err != nil {
+			return "", err
+		}
+		mediaType, size, err := p.describeManifest(ctx, manifest.Digest)
+		if err != nil {
+			return "", err
+		}
+		index.Manifests = append(index.Manifests, imageIndexManifest{MediaType: mediaType, Digest: manifest.Digest, Size: size, Platform: platform})
+	}
+	content, err := json.Marshal(index)
+	if err != nil {
+		return "", err
+	}
+	target := p.base.JoinPath("/v2/", repository, "/manifests/", tag).String()
+	resp, err := p.send(ctx, http.MethodPut, target, http.Header{"Content-Type": {MediaTypeImageIndex}}, content)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("%w: unexpected status %s when pushing the image index %s", ErrPushDenied, resp.Status, options.Image)
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content)), nil
+}
+
+// describeManifest gets the media type and the size of the manifest with the given digest
+func (p *preflight) describeManifest(ctx context.Context, digest string) (string, int64, error) {
+	target := p.base.JoinPath("/v2/", p.repository, "/manifests/", digest).String()
+	resp, err := p.send(ctx, http.MethodHead, target, http.Header{"Accept": {MediaTypeImageManifest, MediaTypeDockerManifest}}, nil)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("unexpected status %s for the manifest %s@%s", resp.Status, p.repository, digest)
+	}
+	size, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid size of the manifest %s@%s: %v", p.repository, digest, err)
+	}
+	mediaType := resp.Header.Get("Content-Type")
+	if mediaType == "" {
+		mediaType = MediaTypeDockerManifest
+	}
+	return mediaType, size, nil
+}
+
+// splitImage splits an image name like quay.io/kiegroup/greeting:latest into the registry host, the repository and the tag
+func splitImage(image string) (string, string, string, error) {
+	host, name, found := strings.Cut(image, "/")
+	if !found || host == "" || name == "" {
+		return "", "", "", fmt.Errorf("invalid image name %q, expected registry/repository[:tag]", image)
+	}
+	if host == dockerHubHost {
+		host = dockerHubAPIHost
+	}
+	tag := "latest"
+	if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+		name, tag = name[:colon], name[colon+1:]
+	}
+	return host, name, tag, nil
+}
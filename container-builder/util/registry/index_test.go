@@ -0,0 +1,74 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/test"
+)
+
+func TestPushImageIndex(t *testing.T) {
+	registry := &test.RegistryStandIn{Username: "user", Password: "secret", Bearer: true}
+	server := registry.Start(true)
+	defer server.Close()
+	amd64 := registry.PutManifest("kiegroup/greeting", "latest-linux-amd64", MediaTypeDockerManifest, []byte(`{"arch":"amd64"}`))
+	arm64 := registry.PutManifest("kiegroup/greeting", "latest-linux-arm64", MediaTypeImageManifest, []byte(`{"arch":"arm64"}`))
+
+	digest, err := PushImageIndex(context.TODO(), ImageIndexOptions{
+		Image: hostOf(server.URL) + "/kiegroup/greeting:latest",
+		Manifests: []ImageIndexManifest{
+			{Digest: amd64, Platform: "linux/amd64"},
+			{Digest: arm64, Platform: "linux/arm64/v8"},
+		},
+		RegistryAccess: RegistryAccess{Insecure: true, Username: "user", Password: "secret"},
+	})
+	assert.NoError(t, err)
+
+	mediaType, content, found := registry.Manifest("kiegroup/greeting", "latest")
+	assert.True(t, found)
+	assert.Equal(t, MediaTypeImageIndex, mediaType)
+	_, _, found = registry.Manifest("kiegroup/greeting", digest)
+	assert.True(t, found)
+	index := imageIndex{}
+	assert.NoError(t, json.Unmarshal(content, &index))
+	assert.Equal(t, []imageIndexManifest{
+		{MediaType: MediaTypeDockerManifest, Digest: amd64, Size: 16, Platform: Platform{OS: "linux", Architecture: "amd64"}},
+		{MediaType: MediaTypeImageManifest, Digest: arm64, Size: 16, Platform: Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}},
+	}, index.Manifests)
+
+	t.Run("missing manifest", func(t *testing.T) {
+		_, err := PushImageIndex(context.TODO(), ImageIndexOptions{
+			Image:          hostOf(server.URL) + "/kiegroup/greeting:latest",
+			Manifests:      []ImageIndexManifest{{Digest: "sha256:0000", Platform: "linux/amd64"}},
+			RegistryAccess: RegistryAccess{Insecure: true, Username: "user", Password: "secret"},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("invalid platform", func(t *testing.T) {
+		_, err := PushImageIndex(context.TODO(), ImageIndexOptions{
+			Image:          hostOf(server.URL) + "/kiegroup/greeting:latest",
+			Manifests:      []ImageIndexManifest{{Digest: amd64, Platform: "amd64"}},
+			RegistryAccess: RegistryAccess{Insecure: true, Username: "user", Password: "secret"},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Platform an image is built for, as referenced by an OCI image index
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// ParsePlatform parses a platform in the `os/arch[/variant]` format, e.g. linux/arm64/v8
+func ParsePlatform(platform string) (Platform, error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return Platform{}, fmt.Errorf("invalid platform %q, expected os/arch[/variant]", platform)
+	}
+	result := Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		result.Variant = parts[2]
+	}
+	return result, nil
+}
+
+// NodeSelector selects the nodes matching the operating system and the architecture of the platform.
+// Kubernetes has no well-known label for the variant, so it's not part of the selector.
+func (p Platform) NodeSelector() map[string]string {
+	return map[string]string{corev1.LabelOSStable: p.OS, corev1.LabelArchStable: p.Architecture}
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPlatformNodeSelector(t *testing.T) {
+	platform, err := ParsePlatform("linux/arm64/v8")
+	assert.NoError(t, err)
+	assert.Equal(t, Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}, platform)
+	assert.Equal(t, map[string]string{corev1.LabelOSStable: "linux", corev1.LabelArchStable: "arm64"}, platform.NodeSelector())
+
+	_, err = ParsePlatform("arm64")
+	assert.Error(t, err)
+}
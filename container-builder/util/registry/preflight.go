@@ -0,0 +1,391 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PreflightImage is the repository name, below the organization, used to check the push permission
+	PreflightImage = "kogito-serverless-preflight"
+
+	defaultPreflightTimeout = 10 * time.Second
+	dockerHubHost           = "docker.io"
+	dockerHubAPIHost        = "registry-1.docker.io"
+)
+
+var (
+	// ErrUnreachable the registry can't be contacted or doesn't implement the Docker Registry HTTP API V2
+	ErrUnreachable = errors.New("registry unreachable")
+	// ErrUnauthorized the registry rejected the given credentials
+	ErrUnauthorized = errors.New("registry authentication failed")
+	// ErrPushDenied the credentials aren't allowed to push to the organization
+	ErrPushDenied = errors.New("registry push denied")
+)
+
+// sharedTransport is reused by the requests trusting the system CAs, so that their connections are pooled
+var sharedTransport = newTransport(nil)
+
+// DockerConfig is the content of a docker config file, as stored in a kubernetes.io/dockerconfigjson Secret
+type DockerConfig struct {
+	Auths map[string]DockerAuth `json:"auths"`
+}
+
+// DockerAuth holds the credentials of a single registry in a DockerConfig
+type DockerAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Auth is the base64 encoding of "username:password"
+	Auth string `json:"auth,omitempty"`
+}
+
+// Credentials returns the username and the password, decoding Auth when they're not set
+func (a DockerAuth) Credentials() (string, string, error) {
+	if a.Username != "" || a.Auth == "" {
+		return a.Username, a.Password, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(a.Auth)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid auth field: %w", err)
+	}
+	username, password, found := strings.Cut(string(decoded), ":")
+	if !found {
+		return "", "", errors.New("invalid auth field: expected username:password")
+	}
+	return username, password, nil
+}
+
+// ParseDockerConfig reads the docker config stored in a registry Secret.
+// The same keys mounted by the Kaniko builder are supported: .dockerconfigjson, config.json and the legacy .dockercfg.
+func ParseDockerConfig(data map[string][]byte) (*DockerConfig, error) {
+	config := &DockerConfig{}
+	if content, ok := data[corev1.DockerConfigJsonKey]; ok {
+		if err := json.Unmarshal(content, config); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", corev1.DockerConfigJsonKey, err)
+		}
+		return config, nil
+	}
+	if content, ok := data["config.json"]; ok {
+		if err := json.Unmarshal(content, config); err != nil {
+			return nil, fmt.Errorf("failed to parse config.json: %w", err)
+		}
+		return config, nil
+	}
+	if content, ok := data[corev1.DockerConfigKey]; ok {
+		if err := json.Unmarshal(content, &config.Auths); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", corev1.DockerConfigKey, err)
+		}
+		return config, nil
+	}
+	return nil, errors.New("unsupported secret type for registry authentication")
+}
+
+// AuthFor returns the credentials matching the given registry host, if any
+func (c *DockerConfig) AuthFor(host string) (DockerAuth, bool) {
+	host = normalizeHost(host)
+	for key, auth := range c.Auths {
+		if normalizeHost(key) == host {
+			return auth, true
+		}
+	}
+	return DockerAuth{}, false
+}
+
+// normalizeHost strips the scheme and path from a docker config key, handling the Docker Hub aliases
+func normalizeHost(key string) string {
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	key, _, _ = strings.Cut(key, "/")
+	switch key {
+	case "index.docker.io", dockerHubAPIHost:
+		return dockerHubHost
+	}
+	return key
+}
+
+// PreflightOptions configures the registry preflight check
+type PreflightOptions struct {
+	// Address of the registry as used in the image names, e.g. quay.io/kiegroup or localhost:5000
+	Address string
+	// Organization below the address where the images are pushed to, if any
+	Organization string
+	RegistryAccess
+	// Timeout of each request sent to the registry
+	Timeout time.Duration
+}
+
+// Preflight verifies that the registry implements the Docker Registry HTTP API V2, that the credentials are accepted,
+// and that they grant the permission to push to the organization.
+// The returned error wraps ErrUnreachable, ErrUnauthorized or ErrPushDenied according to the failed step.
+func Preflight(ctx context.Context, options PreflightOptions) error {
+	host, namespace, _ := strings.Cut(options.Address, "/")
+	if host == "" {
+		return fmt.Errorf("%w: no registry address", ErrUnreachable)
+	}
+	if host == dockerHubHost {
+		host = dockerHubAPIHost
+	}
+	scheme := "https"
+	if options.Insecure {
+		scheme = "http"
+	}
+	repository := PreflightImage
+	for _, prefix := range []string{options.Organization, namespace} {
+		if prefix = strings.Trim(prefix, "/"); prefix != "" {
+			repository = prefix + "/" + repository
+		}
+	}
+	p := newPreflight(scheme, host, repository, options)
+	defer p.close()
+	if err := p.ping(ctx); err != nil {
+		return err
+	}
+	return p.checkPush(ctx)
+}
+
+func newPreflight(scheme, host, repository string, options PreflightOptions) *preflight {
+	timeout := options.Timeout
+	if timeout == 0 {
+		timeout = defaultPreflightTimeout
+	}
+	transport := sharedTransport
+	if options.RootCAs != nil {
+		transport = newTransport(options.RootCAs)
+	}
+	return &preflight{
+		base:       &url.URL{Scheme: scheme, Host: host},
+		repository: repository,
+		options:    options,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+		},
+	}
+}
+
+// newTransport clones the default transport, trusting the given CAs, the system ones when nil
+func newTransport(rootCAs *x509.CertPool) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	return transport
+}
+
+// close releases the connections of the transport dedicated to the preflight, if any
+func (p *preflight) close() {
+	if transport := p.client.Transport.(*http.Transport); transport != sharedTransport {
+		transport.CloseIdleConnections()
+	}
+}
+
+type preflight struct {
+	base       *url.URL
+	repository string
+	options    PreflightOptions
+	client     *http.Client
+	// authorization is the header value sent along the requests once authenticated
+	authorization string
+}
+
+// ping calls the /v2/ endpoint, authenticating against the challenge returned by the registry
+func (p *preflight) ping(ctx context.Context) error {
+	resp, err := p.do(ctx, http.MethodGet, p.base.JoinPath("/v2/").String())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized:
+		if err := p.authenticate(ctx, resp.Header.Get("WWW-Authenticate")); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unexpected status %s from %s", ErrUnreachable, resp.Status, resp.Request.URL)
+	}
+
+	resp, err = p.do(ctx, http.MethodGet, p.base.JoinPath("/v2/").String())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: credentials rejected by %s", ErrUnauthorized, p.base.Host)
+	default:
+		return fmt.Errorf("%w: unexpected status %s from %s", ErrUnreachable, resp.Status, resp.Request.URL)
+	}
+}
+
+// authenticate handles the Basic and Bearer challenges of the Docker Registry HTTP API V2
+func (p *preflight) authenticate(ctx context.Context, challenge string) error {
+	if p.options.Username == "" && p.options.Password == "" {
+		return fmt.Errorf("%w: %s requires credentials", ErrUnauthorized, p.base.Host)
+	}
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		p.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(p.options.Username+":"+p.options.Password))
+		return nil
+	case "bearer":
+		token, err := p.fetchToken(ctx, params)
+		if err != nil {
+			return err
+		}
+		p.authorization = "Bearer " + token
+		return nil
+	}
+	return fmt.Errorf("%w: unsupported authentication challenge %q", ErrUnauthorized, challenge)
+}
+
+// fetchToken requests a token with the push scope on the preflight repository to the realm of a Bearer challenge
+func (p *preflight) fetchToken(ctx context.Context, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("%w: invalid token realm %q", ErrUnreachable, params["realm"])
+	}
+	query := realm.Query()
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	query.Set("scope", "repository:"+p.repository+":pull,push")
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	req.SetBasicAuth(p.options.Username, p.options.Password)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", fmt.Errorf("%w: credentials rejected by %s", ErrUnauthorized, realm.Host)
+	default:
+		return "", fmt.Errorf("%w: unexpected status %s from %s", ErrUnreachable, resp.Status, realm.Host)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("%w: invalid token response from %s: %v", ErrUnreachable, realm.Host, err)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", fmt.Errorf("%w: no token returned by %s", ErrUnauthorized, realm.Host)
+}
+
+// checkPush starts a blob upload in the preflight repository, and cancels it right away
+func (p *preflight) checkPush(ctx context.Context) error {
+	uploads := p.base.JoinPath("/v2/", p.repository, "/blobs/uploads/").String()
+	resp, err := p.do(ctx, http.MethodPost, uploads)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	resp.Body.Close()
+	// registries allowing anonymous pulls only challenge the push requests
+	if resp.StatusCode == http.StatusUnauthorized && p.authorization == "" {
+		if err := p.authenticate(ctx, resp.Header.Get("WWW-Authenticate")); err != nil {
+			return err
+		}
+		if resp, err = p.do(ctx, http.MethodPost, uploads); err != nil {
+			return fmt.Errorf("%w: %v", ErrUnreachable, err)
+		}
+		resp.Body.Close()
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("%w: unexpected status %s when pushing to %s/%s", ErrPushDenied, resp.Status, p.base.Host, p.repository)
+	}
+	if location, err := resp.Request.URL.Parse(resp.Header.Get("Location")); err == nil && location.Path != "" {
+		// the upload is garbage collected by the registry anyway, a failed cancellation can be ignored
+		if resp, err := p.do(ctx, http.MethodDelete, location.String()); err == nil {
+			resp.Body.Close()
+		}
+	}
+	return nil
+}
+
+func (p *preflight) do(ctx context.Context, method, target string) (*http.Response, error) {
+	return p.send(ctx, method, target, nil, nil)
+}
+
+// send sends a request with the given headers and body, authenticated once the registry challenge has been answered
+func (p *preflight) send(ctx context.Context, method, target string, header http.Header, body []byte) (*http.Response, error) {
+	var content io.Reader
+	if body != nil {
+		content = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, content)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if p.authorization != "" {
+		req.Header.Set("Authorization", p.authorization)
+	}
+	return p.client.Do(req)
+}
+
+// parseChallenge splits a WWW-Authenticate header like `Bearer realm="https://auth",service="registry",scope="repository:a:pull,push"`
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			params[strings.ToLower(key)] = value
+		}
+	}
+	return scheme, params
+}
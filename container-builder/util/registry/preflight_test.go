@@ -0,0 +1,131 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/test"
+)
+
+func TestParseDockerConfig(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("user:secret"))
+	config, err := ParseDockerConfig(map[string][]byte{".dockerconfigjson": []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"` + auth + `"},"quay.io":{"username":"robot","password":"token"}}}`)})
+	assert.NoError(t, err)
+
+	dockerHub, found := config.AuthFor("docker.io")
+	assert.True(t, found)
+	username, password, err := dockerHub.Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "secret", password)
+
+	quay, found := config.AuthFor("quay.io")
+	assert.True(t, found)
+	username, password, err = quay.Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "robot", username)
+	assert.Equal(t, "token", password)
+
+	_, found = config.AuthFor("ghcr.io")
+	assert.False(t, found)
+
+	config, err = ParseDockerConfig(map[string][]byte{".dockercfg": []byte(`{"localhost:5000":{"auth":"` + auth + `"}}`)})
+	assert.NoError(t, err)
+	_, found = config.AuthFor("localhost:5000")
+	assert.True(t, found)
+
+	_, err = ParseDockerConfig(map[string][]byte{"kaniko-secret.json": []byte(`{}`)})
+	assert.Error(t, err)
+}
+
+func TestPreflight(t *testing.T) {
+	t.Run("anonymous insecure registry", func(t *testing.T) {
+		registry := &test.RegistryStandIn{}
+		server := registry.Start(true)
+		defer server.Close()
+
+		err := Preflight(context.TODO(), PreflightOptions{Address: hostOf(server.URL), Organization: "kiegroup", RegistryAccess: RegistryAccess{Insecure: true}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"kiegroup/" + PreflightImage}, registry.Uploads())
+	})
+	t.Run("basic authentication with a custom CA", func(t *testing.T) {
+		registry := &test.RegistryStandIn{Username: "user", Password: "secret"}
+		server := registry.Start(false)
+		defer server.Close()
+		pool := x509.NewCertPool()
+		pool.AddCert(server.Certificate())
+
+		err := Preflight(context.TODO(), PreflightOptions{Address: hostOf(server.URL) + "/kiegroup", RegistryAccess: RegistryAccess{RootCAs: pool, Username: "user", Password: "secret"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"kiegroup/" + PreflightImage}, registry.Uploads())
+	})
+	t.Run("token authentication", func(t *testing.T) {
+		registry := &test.RegistryStandIn{Username: "user", Password: "secret", Bearer: true}
+		server := registry.Start(true)
+		defer server.Close()
+
+		err := Preflight(context.TODO(), PreflightOptions{Address: hostOf(server.URL), RegistryAccess: RegistryAccess{Insecure: true, Username: "user", Password: "secret"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{PreflightImage}, registry.Uploads())
+	})
+	t.Run("untrusted certificate", func(t *testing.T) {
+		server := (&test.RegistryStandIn{}).Start(false)
+		defer server.Close()
+
+		err := Preflight(context.TODO(), PreflightOptions{Address: hostOf(server.URL)})
+		assert.ErrorIs(t, err, ErrUnreachable)
+	})
+	t.Run("wrong credentials", func(t *testing.T) {
+		for _, bearer := range []bool{false, true} {
+			server := (&test.RegistryStandIn{Username: "user", Password: "secret", Bearer: bearer}).Start(true)
+
+			err := Preflight(context.TODO(), PreflightOptions{Address: hostOf(server.URL), RegistryAccess: RegistryAccess{Insecure: true, Username: "user", Password: "wrong"}})
+			assert.ErrorIs(t, err, ErrUnauthorized)
+			err = Preflight(context.TODO(), PreflightOptions{Address: hostOf(server.URL), RegistryAccess: RegistryAccess{Insecure: true}})
+			assert.ErrorIs(t, err, ErrUnauthorized)
+			server.Close()
+		}
+	})
+	t.Run("push denied", func(t *testing.T) {
+		server := (&test.RegistryStandIn{Username: "user", Password: "secret", PushDenied: true}).Start(true)
+		defer server.Close()
+
+		err := Preflight(context.TODO(), PreflightOptions{Address: hostOf(server.URL), RegistryAccess: RegistryAccess{Insecure: true, Username: "user", Password: "secret"}})
+		assert.ErrorIs(t, err, ErrPushDenied)
+	})
+}
+
+func TestPreflightTransport(t *testing.T) {
+	shared := newPreflight("https", "quay.io", PreflightImage, PreflightOptions{})
+	assert.Same(t, sharedTransport, shared.client.Transport)
+	shared.close()
+
+	dedicated := newPreflight("https", "quay.io", PreflightImage, PreflightOptions{RegistryAccess: RegistryAccess{RootCAs: x509.NewCertPool()}})
+	assert.NotSame(t, sharedTransport, dedicated.client.Transport)
+	dedicated.close()
+}
+
+func hostOf(url string) string {
+	return url[strings.Index(url, "://")+3:]
+}
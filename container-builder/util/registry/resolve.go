@@ -0,0 +1,50 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+// ResolveImage checks that the image exists in its registry, without pulling it, and returns its digest.
+// A tag is resolved to the digest of the manifest it currently references, an image pinned by digest is verified against its manifest.
+func ResolveImage(ctx context.Context, image string, access RegistryAccess) (string, error) {
+	name, digest, pinned := strings.Cut(image, "@")
+	if pinned && !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("invalid image %q, expected registry/repository[:tag][@sha256:digest]", image)
+	}
+	p, err := newCopyEndpoint(ctx, name, access, defaultPreflightTimeout)
+	if err != nil {
+		return "", err
+	}
+	defer p.close()
+	if pinned {
+		if _, _, err = p.getManifest(ctx, digest); err != nil {
+			return "", err
+		}
+		return digest, nil
+	}
+	_, _, tag, _ := splitImage(name)
+	_, content, err := p.getManifest(ctx, tag)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content)), nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/test"
+)
+
+func TestResolveImage(t *testing.T) {
+	registry := &test.RegistryStandIn{Username: "ci", Password: "secret", Bearer: true}
+	server := registry.Start(true)
+	defer server.Close()
+	digest := registry.PutManifest("ci/greeting", "1.0", MediaTypeImageManifest, []byte(`{"schemaVersion":2}`))
+	access := RegistryAccess{Insecure: true, Username: "ci", Password: "secret"}
+
+	resolved, err := ResolveImage(context.TODO(), hostOf(server.URL)+"/ci/greeting:1.0", access)
+	assert.NoError(t, err)
+	assert.Equal(t, digest, resolved)
+
+	resolved, err = ResolveImage(context.TODO(), hostOf(server.URL)+"/ci/greeting@"+digest, access)
+	assert.NoError(t, err)
+	assert.Equal(t, digest, resolved)
+
+	_, err = ResolveImage(context.TODO(), hostOf(server.URL)+"/ci/greeting:2.0", access)
+	assert.ErrorContains(t, err, "404")
+
+	_, err = ResolveImage(context.TODO(), hostOf(server.URL)+"/ci/greeting:1.0", RegistryAccess{Insecure: true})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = ResolveImage(context.TODO(), hostOf(server.URL)+"/ci/greeting@1.0", access)
+	assert.ErrorContains(t, err, "invalid image")
+}
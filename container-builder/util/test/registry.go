@@ -0,0 +1,247 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+const registryStandInToken = "stand-in-token"
+
+// RegistryStandIn is a minimal Docker Registry HTTP API V2 implementation, enough to verify the registry clients without a real registry
+type RegistryStandIn struct {
+	// Username and Password required by the registry, anonymous access is allowed when both are empty
+	Username string
+	Password string
+	// Bearer challenges the clients with the token authentication instead of the basic one
+	Bearer bool
+	// PushDenied rejects the blob uploads
+	PushDenied bool
+
+	lock      sync.Mutex
+	uploads   []string
+	manifests map[string]standInManifest
+	blobs     map[string][]byte
+}
+
+type standInManifest struct {
+	mediaType string
+	content   []byte
+}
+
+// Start serves the registry over TLS, or plain HTTP when insecure, the caller must close the returned server
+func (r *RegistryStandIn) Start(insecure bool) *httptest.Server {
+	if insecure {
+		return httptest.NewServer(r)
+	}
+	return httptest.NewTLSServer(r)
+}
+
+// Uploads returns the repositories where a blob upload has been started
+func (r *RegistryStandIn) Uploads() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.uploads...)
+}
+
+// PutManifest stores a manifest in the repository under its digest, and the tag if any. It returns the digest.
+func (r *RegistryStandIn) PutManifest(repository, tag, mediaType string, content []byte) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.manifests == nil {
+		r.manifests = map[string]standInManifest{}
+	}
+	digest := fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+	manifest := standInManifest{mediaType: mediaType, content: content}
+	r.manifests[repository+"@"+digest] = manifest
+	if tag != "" {
+		r.manifests[repository+":"+tag] = manifest
+	}
+	return digest
+}
+
+// PutBlob stores a blob in the repository. It returns the digest.
+func (r *RegistryStandIn) PutBlob(repository string, content []byte) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.putBlob(repository, content)
+}
+
+func (r *RegistryStandIn) putBlob(repository string, content []byte) string {
+	if r.blobs == nil {
+		r.blobs = map[string][]byte{}
+	}
+	digest := fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+	r.blobs[repository+"@"+digest] = content
+	return digest
+}
+
+// Blob returns the content of the blob with the given digest in the repository
+func (r *RegistryStandIn) Blob(repository, digest string) ([]byte, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	content, found := r.blobs[repository+"@"+digest]
+	return content, found
+}
+
+// Manifest returns the media type and the content of the manifest with the given digest or tag in the repository
+func (r *RegistryStandIn) Manifest(repository, reference string) (string, []byte, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	separator := ":"
+	if strings.HasPrefix(reference, "sha256:") {
+		separator = "@"
+	}
+	manifest, found := r.manifests[repository+separator+reference]
+	return manifest.mediaType, manifest.content, found
+}
+
+func (r *RegistryStandIn) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.Path == "/token":
+		if username, password, _ := req.BasicAuth(); username != r.Username || password != r.Password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"token":%q}`, registryStandInToken)
+	case !r.authorized(req):
+		r.challenge(w, req)
+	case req.URL.Path == "/v2/" && req.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+	case strings.HasSuffix(req.URL.Path, "/blobs/uploads/") && req.Method == http.MethodPost:
+		if r.PushDenied {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		repository := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/v2/"), "/blobs/uploads/")
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if mount, from := req.URL.Query().Get("mount"), req.URL.Query().Get("from"); mount != "" {
+			if content, found := r.blobs[from+"@"+mount]; found {
+				r.putBlob(repository, content)
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+		}
+		r.uploads = append(r.uploads, repository)
+		w.Header().Set("Location", req.URL.Path+"stand-in-upload")
+		w.WriteHeader(http.StatusAccepted)
+	case strings.Contains(req.URL.Path, "/blobs/uploads/") && req.Method == http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	case strings.Contains(req.URL.Path, "/blobs/uploads/") && req.Method == http.MethodPut:
+		repository, _, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/v2/"), "/blobs/uploads/")
+		content, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if fmt.Sprintf("sha256:%x", sha256.Sum256(content)) != req.URL.Query().Get("digest") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.PutBlob(repository, content)
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(req.URL.Path, "/blobs/"):
+		r.serveBlob(w, req)
+	case strings.Contains(req.URL.Path, "/manifests/"):
+		r.serveManifest(w, req)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *RegistryStandIn) serveManifest(w http.ResponseWriter, req *http.Request) {
+	repository, reference, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/v2/"), "/manifests/")
+	switch req.Method {
+	case http.MethodPut:
+		content, err := io.ReadAll(req.Body)
+		if err != nil || r.PushDenied {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		tag := reference
+		if strings.HasPrefix(reference, "sha256:") {
+			tag = ""
+		}
+		w.Header().Set("Docker-Content-Digest", r.PutManifest(repository, tag, req.Header.Get("Content-Type"), content))
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodHead, http.MethodGet:
+		mediaType, content, found := r.Manifest(repository, reference)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", mediaType)
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+		w.WriteHeader(http.StatusOK)
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *RegistryStandIn) serveBlob(w http.ResponseWriter, req *http.Request) {
+	repository, digest, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/v2/"), "/blobs/")
+	content, found := r.Blob(repository, digest)
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch req.Method {
+	case http.MethodHead, http.MethodGet:
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+		w.WriteHeader(http.StatusOK)
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *RegistryStandIn) authorized(req *http.Request) bool {
+	if r.Username == "" && r.Password == "" {
+		return true
+	}
+	if r.Bearer {
+		return req.Header.Get("Authorization") == "Bearer "+registryStandInToken
+	}
+	username, password, ok := req.BasicAuth()
+	return ok && username == r.Username && password == r.Password
+}
+
+func (r *RegistryStandIn) challenge(w http.ResponseWriter, req *http.Request) {
+	if r.Bearer {
+		scheme := "https"
+		if req.TLS == nil {
+			scheme = "http"
+		}
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s://%s/token",service="stand-in"`, scheme, req.Host))
+	} else {
+		w.Header().Set("WWW-Authenticate", `Basic realm="stand-in"`)
+	}
+	w.WriteHeader(http.StatusUnauthorized)
+}
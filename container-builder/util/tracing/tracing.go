@@ -0,0 +1,102 @@
+/*
+ * Copyright 2023 Red Hat, Inc. and/or its affiliates.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util"
+)
+
+// AnnotationPrefix prefix of the annotations holding the trace context propagated across the objects created by the builder.
+// For example, "sw.kogito.kie.org/trace.traceparent".
+const AnnotationPrefix = "sw.kogito.kie.org/trace."
+
+// Annotated any object holding annotations, like Kubernetes objects or the ContainerBuild.
+type Annotated interface {
+	GetAnnotations() map[string]string
+	SetAnnotations(annotations map[string]string)
+}
+
+// propagator always use the W3C Trace Context format, so the annotations are readable by any other component regardless of the global propagator.
+var propagator = propagation.TraceContext{}
+
+// Tracer returns the tracer used by the builder, backed by the global TracerProvider.
+// If no provider has been set, spans are no-ops.
+func Tracer() trace.Tracer {
+	return otel.Tracer(util.ComponentName)
+}
+
+// EndSpan records the given error, if any, in the span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Inject writes the trace context carried by ctx into the object's annotations.
+// Nothing is written if the context doesn't carry a valid span.
+func Inject(ctx context.Context, obj Annotated) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	propagator.Inject(ctx, annotationCarrier(annotations))
+	obj.SetAnnotations(annotations)
+}
+
+// Extract returns a copy of ctx carrying the remote span context stored in the object's annotations, if any.
+func Extract(ctx context.Context, obj Annotated) context.Context {
+	if len(obj.GetAnnotations()) == 0 {
+		return ctx
+	}
+	return propagator.Extract(ctx, annotationCarrier(obj.GetAnnotations()))
+}
+
+// annotationCarrier adapts a map of annotations to a propagation.TextMapCarrier.
+type annotationCarrier map[string]string
+
+var _ propagation.TextMapCarrier = annotationCarrier{}
+
+func (a annotationCarrier) Get(key string) string {
+	return a[AnnotationPrefix+key]
+}
+
+func (a annotationCarrier) Set(key string, value string) {
+	a[AnnotationPrefix+key] = value
+}
+
+func (a annotationCarrier) Keys() []string {
+	keys := make([]string, 0, len(a))
+	for k := range a {
+		if strings.HasPrefix(k, AnnotationPrefix) {
+			keys = append(keys, strings.TrimPrefix(k, AnnotationPrefix))
+		}
+	}
+	return keys
+}
@@ -17,19 +17,44 @@ package builder
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
 
+	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
 )
 
+const (
+	// quarkusExtensionsBuildArg is the Dockerfile build argument holding the comma separated list of Quarkus extensions
+	// to add to the workflow application
+	quarkusExtensionsBuildArg = "QUARKUS_EXTENSIONS"
+	// defaultBuildTimeout is how long a JVM build can take when the build doesn't set a timeout
+	defaultBuildTimeout = 5 * time.Minute
+	// defaultNativeBuildTimeout is how long a native build can take when the build doesn't set a timeout
+	defaultNativeBuildTimeout = 60 * time.Minute
+)
+
+// nativeBuildResources are the builder resources of native builds that don't set any, native-image compilation
+// being CPU and memory hungry
+var nativeBuildResources = v1.ResourceRequirements{
+	Requests: v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("2"),
+		v1.ResourceMemory: resource.MustParse("6Gi"),
+	},
+	Limits: v1.ResourceList{
+		v1.ResourceMemory: resource.MustParse("8Gi"),
+	},
+}
+
 type buildManagerContext struct {
 	ctx          context.Context
 	client       client.Client
@@ -40,19 +65,22 @@ type buildManagerContext struct {
 type BuildManager interface {
 	Schedule(build *operatorapi.KogitoServerlessBuild) error
 	Reconcile(build *operatorapi.KogitoServerlessBuild) error
+	// Strategy used by the manager to build the workflows
+	Strategy() operatorapi.BuildStrategy
 }
 
-func NewBuildManager(ctx context.Context, client client.Client, cliConfig *rest.Config, targetName, targetNamespace string) (BuildManager, error) {
+// NewBuildManager creates the BuildManager for the given build, backed by the platform selected by its workflow.
+func NewBuildManager(ctx context.Context, client client.Client, cliConfig *rest.Config, build *operatorapi.KogitoServerlessBuild) (BuildManager, error) {
 	logger := ctrllog.FromContext(ctx)
-	p, err := platform.GetActivePlatform(ctx, client, targetNamespace)
+	p, err := platform.GetSelectedPlatform(ctx, client, build.Namespace, build.Annotations[metadata.PlatformAnnotation])
 	if err != nil {
-		if errors.IsNotFound(err) {
+		if platform.IsPlatformUnavailable(err) {
 			return nil, err
 		}
-		logger.Error(err, fmt.Sprintf("Error retrieving the active platform. Workflow %s build cannot be performed!", targetName))
+		logger.Error(err, fmt.Sprintf("Error retrieving the platform. Workflow %s build cannot be performed!", build.Name))
 		return nil, err
 	}
-	commonConfig, err := GetCommonConfigMap(client, targetNamespace)
+	commonConfig, err := GetCommonConfigMap(client, build.Namespace)
 	if err != nil {
 		logger.Error(err, "Failed to get common configMap for Workflow Builder. Make sure that kogito-serverless-operator-builder-config is present in the operator namespace.")
 		return nil, err
@@ -74,19 +102,50 @@ func NewBuildManager(ctx context.Context, client client.Client, cliConfig *rest.
 	}
 }
 
+func (b *buildManagerContext) Strategy() operatorapi.BuildStrategy {
+	return b.platform.Spec.BuildPlatform.BuildStrategy
+}
+
 // fetchWorkflowDefinitionAndImageTag fetches the workflow instance by name and namespace and convert it to JSON bytes.
-func (b *buildManagerContext) fetchWorkflowDefinitionAndImageTag(build *operatorapi.KogitoServerlessBuild) (workflowDef []byte, imageTag string, err error) {
-	instance, err := b.fetchWorkflowForBuild(build)
-	if err != nil {
-		return nil, "", err
+func (b *buildManagerContext) fetchWorkflowDefinitionAndImageTag(build *operatorapi.KogitoServerlessBuild) (workflow *operatorapi.KogitoServerlessWorkflow, workflowDef []byte, imageTag string, err error) {
+	if workflow, err = b.fetchWorkflowForBuild(build); err != nil {
+		return nil, nil, "", err
 	}
-	if workflowDef, err = workflowdef.GetJSONWorkflow(instance, b.ctx); err != nil {
-		return nil, "", err
+	if workflowDef, err = workflowdef.GetJSONWorkflow(workflow, b.ctx); err != nil {
+		return nil, nil, "", err
 	}
-	imageTag = workflowdef.GetWorkflowAppImageNameTag(instance)
+	imageTag = workflowdef.GetWorkflowAppImageNameTag(workflow)
 	return
 }
 
+// getBuildArgs gets the arguments of the workflow Dockerfile build
+func (b *buildManagerContext) getBuildArgs(workflow *operatorapi.KogitoServerlessWorkflow) []v1.EnvVar {
+	var args []v1.EnvVar
+	if extensions := workflowdef.GetQuarkusExtensions(workflow, b.platform); len(extensions) > 0 {
+		args = append(args, v1.EnvVar{Name: quarkusExtensionsBuildArg, Value: strings.Join(extensions, ",")})
+	}
+	return args
+}
+
+// getBuildTimeout gets how long the build can take, defaulting to a longer timeout in the native mode
+func getBuildTimeout(build *operatorapi.KogitoServerlessBuild, mode operatorapi.BuildMode) time.Duration {
+	if build.Spec.Timeout.Duration > 0 {
+		return build.Spec.Timeout.Duration
+	}
+	if mode == operatorapi.BuildModeNative {
+		return defaultNativeBuildTimeout
+	}
+	return defaultBuildTimeout
+}
+
+// getBuildResources gets the builder resources, defaulting to nativeBuildResources in the native mode
+func getBuildResources(build *operatorapi.KogitoServerlessBuild, mode operatorapi.BuildMode) v1.ResourceRequirements {
+	if mode == operatorapi.BuildModeNative && len(build.Spec.Resources.Requests) == 0 && len(build.Spec.Resources.Limits) == 0 {
+		return *nativeBuildResources.DeepCopy()
+	}
+	return build.Spec.Resources
+}
+
 // fetchWorkflowForBuild fetches the k8s API for the workflow from the given build
 func (b *buildManagerContext) fetchWorkflowForBuild(build *operatorapi.KogitoServerlessBuild) (workflow *operatorapi.KogitoServerlessWorkflow, err error) {
 	workflow = &operatorapi.KogitoServerlessWorkflow{}
@@ -35,9 +35,11 @@ const (
 	ConfigMapName                       = "kogito-serverless-operator-builder-config"
 	configKeyDefaultExtension           = "DEFAULT_WORKFLOW_EXTENSION"
 	configKeyDefaultBuilderResourceName = "DEFAULT_BUILDER_RESOURCE_NAME"
-	configKeyBuildNamespace             = "build-namespace"
-	configKeyRegistrySecret             = "registry-secret"
-	configKeyRegistryAddress            = "registry-address"
+	// configKeyNativeBuilderResourceName the key of the Dockerfile for native builds, optional
+	configKeyNativeBuilderResourceName = "NATIVE_BUILDER_RESOURCE_NAME"
+	configKeyBuildNamespace            = "build-namespace"
+	configKeyRegistrySecret            = "registry-secret"
+	configKeyRegistryAddress           = "registry-address"
 )
 
 func NewCustomConfig(platform operatorapi.KogitoServerlessPlatform) (map[string]string, error) {
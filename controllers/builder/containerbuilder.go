@@ -15,6 +15,8 @@
 package builder
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -25,7 +27,7 @@ import (
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 	clientr "github.com/kiegroup/kogito-serverless-operator/container-builder/client"
 	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
-	"github.com/kiegroup/kogito-serverless-operator/utils"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
 
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
 	builder "github.com/kiegroup/kogito-serverless-operator/container-builder/builder/kubernetes"
@@ -45,22 +47,54 @@ type containerBuilderManager struct {
 }
 
 func (c *containerBuilderManager) Schedule(build *operatorapi.KogitoServerlessBuild) error {
-	workflowDef, imageNameTag, err := c.fetchWorkflowDefinitionAndImageTag(build)
+	workflow, workflowDef, imageNameTag, err := c.fetchWorkflowDefinitionAndImageTag(build)
 	if err != nil {
 		return err
 	}
-	kanikoTaskCache := api.KanikoTaskCache{}
-	if platform.IsKanikoCacheEnabled(c.platform) {
-		kanikoTaskCache.Enabled = utils.Pbool(true)
+	if err = validateBuildPlatforms(workflowdef.GetBuildPlatforms(workflow, c.platform)); err != nil {
+		build.Status.BuildPhase = operatorapi.BuildPhaseFailed
+		build.Status.Error = err.Error()
+		return nil
+	}
+	if err = workflowdef.ValidateBuildExtensions(workflow, c.platform); err != nil {
+		build.Status.BuildPhase = operatorapi.BuildPhaseFailed
+		build.Status.Error = err.Error()
+		return nil
+	}
+	dockerfile, err := c.getDockerfile(workflow)
+	if err != nil {
+		build.Status.BuildPhase = operatorapi.BuildPhaseFailed
+		build.Status.Error = err.Error()
+		return nil
+	}
+	mode := workflowdef.GetBuildMode(workflow, c.platform)
+	additionalFlags := append([]string{}, build.Spec.Arguments...)
+	for _, arg := range c.getBuildArgs(workflow) {
+		additionalFlags = append(additionalFlags, fmt.Sprintf("--build-arg=%s=%s", arg.Name, arg.Value))
 	}
 	kanikoTask := &api.KanikoTask{
 		ContainerBuildBaseTask: api.ContainerBuildBaseTask{Name: "kaniko"},
 		PublishTask:            api.PublishTask{},
-		Cache:                  kanikoTaskCache,
-		Resources:              build.Spec.Resources,
-		AdditionalFlags:        build.Spec.Arguments,
+		Cache:                  platform.GetKanikoTaskCache(c.platform),
+		Resources:              getBuildResources(build, mode),
+		AdditionalFlags:        additionalFlags,
+	}
+	build.Status.ImageDigest = ""
+	build.Status.Platforms = nil
+	build.Status.Attestation = nil
+	platforms := workflowdef.GetBuildPlatforms(workflow, c.platform)
+	attestation := workflowdef.GetBuildAttestation(workflow, c.platform)
+	if len(platforms) > 1 {
+		// the workflow reconciler doesn't build multi-platform images requiring an attestation, see workflowdef.ValidateBuildAttestation
+		return c.scheduleMultiPlatformBuild(build, imageNameTag, workflowDef, []byte(dockerfile), kanikoTask, getBuildTimeout(build, mode), platforms)
 	}
-	containerBuilder, err := c.scheduleNewKanikoBuildWithContainerFile(build.Name, imageNameTag, workflowDef, kanikoTask)
+	if len(platforms) == 1 {
+		kanikoTask.Platform = platforms[0]
+	}
+	ib := c.getImageBuilderForKaniko(build.Name, imageNameTag, workflowDef, []byte(dockerfile), kanikoTask)
+	ib.WithTimeout(getBuildTimeout(build, mode))
+	ib.WithAttestation(newAttestTask(attestation))
+	containerBuilder, err := c.buildImage(ib.Build())
 	if err = build.Status.SetInnerBuild(containerBuilder); err != nil {
 		return err
 	}
@@ -71,17 +105,22 @@ func (c *containerBuilderManager) Schedule(build *operatorapi.KogitoServerlessBu
 }
 
 func (c *containerBuilderManager) Reconcile(build *operatorapi.KogitoServerlessBuild) error {
+	containerCli, _ := clientr.FromCtrlClientSchemeAndConfig(c.client, c.client.Scheme(), c.restConfig)
+	if len(build.Status.Platforms) > 0 {
+		return c.reconcileMultiPlatformBuild(build, containerCli)
+	}
 	containerBuild := &api.ContainerBuild{}
 	if err := build.Status.GetInnerBuild(containerBuild); err != nil {
 		return err
 	}
-	containerCli, _ := clientr.FromCtrlClientSchemeAndConfig(c.client, c.client.Scheme(), c.restConfig)
 	containerBuild, err := c.reconcileBuild(containerBuild, containerCli)
 	if err != nil {
 		return err
 	}
 	build.Status.BuildPhase = operatorapi.BuildPhase(containerBuild.Status.Phase)
 	build.Status.Error = containerBuild.Status.Error
+	build.Status.ImageDigest = containerBuild.Status.Digest
+	build.Status.Attestation = newBuildAttestationStatus(containerBuild.Status.Attestation)
 	if err = build.Status.SetInnerBuild(containerBuild); err != nil {
 		return err
 	}
@@ -95,9 +134,8 @@ func newContainerBuilderManager(managerContext buildManagerContext, config *rest
 	}
 }
 
-func (c *containerBuilderManager) getImageBuilderForKaniko(workflowID string, imageNameTag string, workflowDefinition []byte, task *api.KanikoTask) imageBuilder {
-	containerFile := c.commonConfig.Data[c.commonConfig.Data[configKeyDefaultBuilderResourceName]]
-	ib := NewImageBuilder(workflowID, workflowDefinition, []byte(containerFile))
+func (c *containerBuilderManager) getImageBuilderForKaniko(workflowID string, imageNameTag string, workflowDefinition []byte, containerFile []byte, task *api.KanikoTask) imageBuilder {
+	ib := NewImageBuilder(workflowID, workflowDefinition, containerFile)
 	ib.OnNamespace(c.platform.Namespace)
 	ib.WithPodMiddleName(workflowID)
 	ib.WithInsecureRegistry(false)
@@ -110,14 +148,31 @@ func (c *containerBuilderManager) getImageBuilderForKaniko(workflowID string, im
 	return ib
 }
 
-func (c *containerBuilderManager) scheduleNewKanikoBuildWithContainerFile(workflowName string, imageNameTag string, workflowDefinition []byte, task *api.KanikoTask) (*api.ContainerBuild, error) {
-	ib := c.getImageBuilderForKaniko(workflowName, imageNameTag, workflowDefinition, task)
-	ib.WithTimeout(5 * time.Minute)
-	return c.buildImage(ib.Build())
+// newAttestTask converts the attestation of the workflow image into the container-builder task, nil if the image isn't attested
+func newAttestTask(attestation *operatorapi.AttestationSpec) *api.AttestTask {
+	if attestation == nil {
+		return nil
+	}
+	task := &api.AttestTask{SBOMFormat: api.SBOMFormat(attestation.SBOM)}
+	if attestation.SigningKeySecretRef != nil {
+		task.SigningKeySecret = attestation.SigningKeySecretRef.Name
+	}
+	return task
+}
+
+func newBuildAttestationStatus(attestation *api.ContainerBuildAttestationStatus) *operatorapi.BuildAttestationStatus {
+	if attestation == nil {
+		return nil
+	}
+	return &operatorapi.BuildAttestationStatus{
+		SBOMFormat: operatorapi.SBOMFormat(attestation.SBOMFormat),
+		SBOM:       attestation.SBOM,
+		Signature:  attestation.Signature,
+	}
 }
 
 func (c *containerBuilderManager) reconcileBuild(build *api.ContainerBuild, cli client.Client) (*api.ContainerBuild, error) {
-	result, err := builder.FromBuild(build).WithClient(cli).Reconcile()
+	result, err := builder.FromBuild(build).WithClient(cli).WithContext(c.ctx).Reconcile()
 	return result, err
 }
 
@@ -143,7 +198,7 @@ func (c *containerBuilderManager) buildImage(kb internalBuilder) (*api.Container
 		},
 	}
 
-	build, err := newBuild(kb, plat, c.commonConfig.Data[configKeyDefaultExtension], cli)
+	build, err := newBuild(c.ctx, kb, plat, c.commonConfig.Data[configKeyDefaultExtension], cli)
 	if err != nil {
 		log.Error(err, err.Error())
 		return nil, err
@@ -173,7 +228,7 @@ func (c *containerBuilderManager) scheduleBuild(kb internalBuilder) (*api.Contai
 		},
 	}
 
-	build, err := newBuild(kb, plat, c.commonConfig.Data[configKeyDefaultExtension], cli)
+	build, err := newBuild(c.ctx, kb, plat, c.commonConfig.Data[configKeyDefaultExtension], cli)
 	if err != nil {
 		log.Error(err, err.Error())
 		return nil, err
@@ -182,13 +237,18 @@ func (c *containerBuilderManager) scheduleBuild(kb internalBuilder) (*api.Contai
 }
 
 // Helper function to create a new container-builder build and schedule it
-func newBuild(kb internalBuilder, platform api.PlatformContainerBuild, defaultExtension string, cli client.Client) (*api.ContainerBuild, error) {
+func newBuild(ctx context.Context, kb internalBuilder, platform api.PlatformContainerBuild, defaultExtension string, cli client.Client) (*api.ContainerBuild, error) {
 	buildInfo := builder.ContainerBuilderInfo{FinalImageName: kb.ImageName, BuildUniqueName: kb.PodMiddleName, Platform: platform}
 
-	return builder.NewBuild(buildInfo).
+	scheduler := builder.NewBuild(buildInfo)
+	if kb.Attestation != nil {
+		scheduler = scheduler.WithProperty(builder.ImageAttestation, *kb.Attestation)
+	}
+	return scheduler.
 		WithResource(resourceDockerfile, kb.ContainerFile).
 		WithResource(kb.WorkflowID+defaultExtension, kb.WorkflowDefinition).
 		WithClient(cli).
+		WithContext(ctx).
 		Schedule()
 }
 
@@ -209,6 +269,7 @@ type internalBuilder struct {
 	Cache                api.KanikoTaskCache
 	Resources            corev1.ResourceRequirements
 	AdditionalFlags      []string
+	Attestation          *api.AttestTask
 }
 
 type imageBuilder struct {
@@ -268,6 +329,11 @@ func (ib *imageBuilder) WithAdditionalFlags(flags []string) *imageBuilder {
 	return ib
 }
 
+func (ib *imageBuilder) WithAttestation(attestation *api.AttestTask) *imageBuilder {
+	ib.builder.Attestation = attestation
+	return ib
+}
+
 func (ib *imageBuilder) Build() internalBuilder {
 	return *ib.builder
 }
@@ -0,0 +1,47 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
+)
+
+func Test_newAttestTask(t *testing.T) {
+	assert.Nil(t, newAttestTask(nil))
+	assert.Equal(t, &api.AttestTask{SBOMFormat: api.SBOMFormatCycloneDX}, newAttestTask(&operatorapi.AttestationSpec{SBOM: operatorapi.SBOMFormatCycloneDX}))
+	assert.Equal(t, &api.AttestTask{SBOMFormat: api.SBOMFormatSPDX, SigningKeySecret: "cosign"}, newAttestTask(&operatorapi.AttestationSpec{
+		SBOM:                operatorapi.SBOMFormatSPDX,
+		SigningKeySecretRef: &corev1.LocalObjectReference{Name: "cosign"},
+	}))
+}
+
+func Test_newBuildAttestationStatus(t *testing.T) {
+	assert.Nil(t, newBuildAttestationStatus(nil))
+	assert.Equal(t, &operatorapi.BuildAttestationStatus{
+		SBOMFormat: operatorapi.SBOMFormatSPDX,
+		SBOM:       "quay.io/kiegroup/greeting:sha256-abc.att",
+		Signature:  "quay.io/kiegroup/greeting:sha256-abc.sig",
+	}, newBuildAttestationStatus(&api.ContainerBuildAttestationStatus{
+		SBOMFormat: api.SBOMFormatSPDX,
+		SBOM:       "quay.io/kiegroup/greeting:sha256-abc.att",
+		Signature:  "quay.io/kiegroup/greeting:sha256-abc.sig",
+	}))
+}
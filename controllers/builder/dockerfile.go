@@ -0,0 +1,142 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+// defaultDockerfileTemplateKey the key of the Dockerfile template in its ConfigMap when not set
+const defaultDockerfileTemplateKey = "Dockerfile"
+
+// dockerfileTemplateData the values available to the Dockerfile templates
+type dockerfileTemplateData struct {
+	// BaseImage the builder image of the platform
+	BaseImage string
+	// Mode how the workflow is compiled, either `jvm` or `native`
+	Mode string
+	// QuarkusExtensions the Quarkus extensions added to the workflow application
+	QuarkusExtensions []string
+	// BuildArgs the arguments of the Dockerfile build, by name
+	BuildArgs map[string]string
+	// Vars the variables of the platform template, overridden by the workflow ones
+	Vars map[string]string
+}
+
+// getDockerfile gets the Dockerfile building the workflow: the workflow template, the platform one, or the operator's default
+// Dockerfile of the workflow build mode.
+// The templates are rendered and validated, an error means the workflow can't be built until they're fixed.
+func (b *buildManagerContext) getDockerfile(workflow *operatorapi.KogitoServerlessWorkflow) (string, error) {
+	vars := map[string]string{}
+	dockerfile := b.platform.Spec.BuildTemplate.Dockerfile
+	if dockerfile != nil {
+		for k, v := range dockerfile.Vars {
+			vars[k] = v
+		}
+	}
+	if workflow.Spec.Build != nil && workflow.Spec.Build.Dockerfile != nil {
+		dockerfile = workflow.Spec.Build.Dockerfile
+		for k, v := range dockerfile.Vars {
+			vars[k] = v
+		}
+	}
+	mode := workflowdef.GetBuildMode(workflow, b.platform)
+	if dockerfile == nil {
+		return b.getDefaultDockerfile(mode)
+	}
+
+	key := dockerfile.Key
+	if len(key) == 0 {
+		key = defaultDockerfileTemplateKey
+	}
+	cm := &corev1.ConfigMap{}
+	if err := b.client.Get(b.ctx, client.ObjectKey{Namespace: workflow.Namespace, Name: dockerfile.ConfigMapRef.Name}, cm); err != nil {
+		return "", fmt.Errorf("cannot get the Dockerfile template ConfigMap %s: %w", dockerfile.ConfigMapRef.Name, err)
+	}
+	source, ok := cm.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in the Dockerfile template ConfigMap %s", key, cm.Name)
+	}
+
+	data := dockerfileTemplateData{
+		BaseImage:         b.platform.Spec.BuildPlatform.BaseImage,
+		Mode:              string(mode),
+		QuarkusExtensions: workflowdef.GetQuarkusExtensions(workflow, b.platform),
+		BuildArgs:         map[string]string{},
+		Vars:              vars,
+	}
+	if len(data.BaseImage) == 0 {
+		data.BaseImage = workflowdef.GetDefaultWorkflowBuilderImageTag()
+	}
+	for _, arg := range b.getBuildArgs(workflow) {
+		data.BuildArgs[arg.Name] = arg.Value
+	}
+	rendered, err := renderDockerfile(source, data)
+	if err != nil {
+		return "", fmt.Errorf("invalid Dockerfile template %s/%s: %w", cm.Name, key, err)
+	}
+	return rendered, nil
+}
+
+// getDefaultDockerfile gets the operator's Dockerfile for the build mode from the builder ConfigMap
+func (b *buildManagerContext) getDefaultDockerfile(mode operatorapi.BuildMode) (string, error) {
+	if mode != operatorapi.BuildModeNative {
+		return b.commonConfig.Data[b.commonConfig.Data[configKeyDefaultBuilderResourceName]], nil
+	}
+	dockerfile := b.commonConfig.Data[b.commonConfig.Data[configKeyNativeBuilderResourceName]]
+	if len(dockerfile) == 0 {
+		return "", fmt.Errorf("unable to find the %s Dockerfile for native builds in the builder ConfigMap %s", configKeyNativeBuilderResourceName, b.commonConfig.Name)
+	}
+	return dockerfile, nil
+}
+
+// renderDockerfile renders the Dockerfile template, failing on missing variables or when the result doesn't build any image
+func renderDockerfile(source string, data dockerfileTemplateData) (string, error) {
+	tpl, err := template.New(defaultDockerfileTemplateKey).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err = tpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	rendered := out.String()
+	if !hasFromInstruction(rendered) {
+		return "", fmt.Errorf("the rendered Dockerfile has no FROM instruction")
+	}
+	return rendered, nil
+}
+
+func hasFromInstruction(dockerfile string) bool {
+	scanner := bufio.NewScanner(strings.NewReader(dockerfile))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 1 && strings.EqualFold(fields[0], "FROM") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/test"
+)
+
+func Test_buildManagerContext_getDockerfile(t *testing.T) {
+	ns := t.Name()
+	templates := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "dockerfiles", Namespace: ns},
+		Data: map[string]string{
+			"Dockerfile": "FROM {{ .BaseImage }} AS builder\nLABEL team={{ .Vars.team }}\n",
+			"custom":     "# {{ .Vars.team }}\nFROM registry.local/{{ .Vars.image }}\n",
+			"nofrom":     "RUN echo {{ .Vars.team }}\n",
+			"missing":    "FROM {{ .Vars.unknown }}\n",
+		},
+	}
+	newContext := func(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) *buildManagerContext {
+		return &buildManagerContext{
+			ctx:          context.TODO(),
+			client:       test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform, templates).Build(),
+			platform:     platform,
+			commonConfig: test.GetKogitoServerlessOperatorBuilderConfig("../../", ns),
+		}
+	}
+
+	t.Run("default Dockerfile", func(t *testing.T) {
+		workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, ns)
+		platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, ns)
+		dockerfile, err := newContext(workflow, platform).getDockerfile(workflow)
+		assert.NoError(t, err)
+		assert.Contains(t, dockerfile, "FROM quay.io/kiegroup/kogito-swf-builder-nightly:latest AS builder")
+	})
+
+	t.Run("platform template", func(t *testing.T) {
+		workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, ns)
+		platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, ns)
+		platform.Spec.BuildPlatform.BaseImage = "quay.io/custom/builder:1.0"
+		platform.Spec.BuildTemplate.Dockerfile = &operatorapi.DockerfileTemplateSpec{
+			ConfigMapRef: corev1.LocalObjectReference{Name: templates.Name},
+			Vars:         map[string]string{"team": "platform"},
+		}
+		dockerfile, err := newContext(workflow, platform).getDockerfile(workflow)
+		assert.NoError(t, err)
+		assert.Equal(t, "FROM quay.io/custom/builder:1.0 AS builder\nLABEL team=platform\n", dockerfile)
+	})
+
+	t.Run("workflow template overrides the platform one", func(t *testing.T) {
+		workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, ns)
+		workflow.Spec.Build = &operatorapi.WorkflowBuildSpec{
+			Dockerfile: &operatorapi.DockerfileTemplateSpec{
+				ConfigMapRef: corev1.LocalObjectReference{Name: templates.Name},
+				Key:          "custom",
+				Vars:         map[string]string{"team": "workflow"},
+			},
+		}
+		platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, ns)
+		platform.Spec.BuildTemplate.Dockerfile = &operatorapi.DockerfileTemplateSpec{
+			ConfigMapRef: corev1.LocalObjectReference{Name: templates.Name},
+			Vars:         map[string]string{"team": "platform", "image": "app:1.0"},
+		}
+		dockerfile, err := newContext(workflow, platform).getDockerfile(workflow)
+		assert.NoError(t, err)
+		assert.Equal(t, "# workflow\nFROM registry.local/app:1.0\n", dockerfile)
+	})
+
+	t.Run("invalid templates", func(t *testing.T) {
+		for _, key := range []string{"nofrom", "missing", "notfound"} {
+			workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, ns)
+			platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, ns)
+			platform.Spec.BuildTemplate.Dockerfile = &operatorapi.DockerfileTemplateSpec{
+				ConfigMapRef: corev1.LocalObjectReference{Name: templates.Name},
+				Key:          key,
+				Vars:         map[string]string{"team": "platform"},
+			}
+			_, err := newContext(workflow, platform).getDockerfile(workflow)
+			assert.Error(t, err, key)
+		}
+	})
+}
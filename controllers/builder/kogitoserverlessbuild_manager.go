@@ -16,13 +16,18 @@ package builder
 
 import (
 	"context"
+	"strings"
 
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/tracing"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
 
+	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 )
 
@@ -34,6 +39,13 @@ type kogitoServerlessBuildManager struct {
 }
 
 func (k *kogitoServerlessBuildManager) MarkToRestart(build *operatorapi.KogitoServerlessBuild) error {
+	// the restarted build joins the trace of the caller
+	if trace.SpanContextFromContext(k.ctx).IsValid() {
+		tracing.Inject(k.ctx, build)
+		if err := k.client.Update(k.ctx, build); err != nil {
+			return err
+		}
+	}
 	build.Status.BuildPhase = operatorapi.BuildPhaseNone
 	return k.client.Status().Update(k.ctx, build)
 }
@@ -46,13 +58,16 @@ func (k *kogitoServerlessBuildManager) GetOrCreateBuild(workflow *operatorapi.Ko
 	if err := k.client.Get(k.ctx, client.ObjectKeyFromObject(workflow), buildInstance); err != nil {
 		if errors.IsNotFound(err) {
 			plat := &operatorapi.KogitoServerlessPlatform{}
-			if plat, err = platform.GetActivePlatform(k.ctx, k.client, workflow.Namespace); err != nil {
+			if plat, err = platform.GetWorkflowPlatform(k.ctx, k.client, workflow); err != nil {
 				return nil, err
 			}
 			buildInstance.Spec.BuildTemplate = plat.Spec.BuildTemplate
+			setBuildPlatform(buildInstance, workflow)
+			setBuildExtensions(buildInstance, getQuarkusExtensions(workflow, plat))
 			if err = controllerutil.SetControllerReference(workflow, buildInstance, k.client.Scheme()); err != nil {
 				return nil, err
 			}
+			tracing.Inject(k.ctx, buildInstance)
 			if err = k.client.Create(k.ctx, buildInstance); err != nil {
 				return nil, err
 			}
@@ -60,10 +75,62 @@ func (k *kogitoServerlessBuildManager) GetOrCreateBuild(workflow *operatorapi.Ko
 		}
 		return nil, err
 	}
+	// the workflow may have selected another platform since the build was created, the next build must follow its template
+	if buildInstance.Annotations[metadata.PlatformAnnotation] != platform.SelectedPlatformName(workflow) {
+		plat, err := platform.GetWorkflowPlatform(k.ctx, k.client, workflow)
+		if err != nil {
+			return nil, err
+		}
+		buildInstance.Spec.BuildTemplate = plat.Spec.BuildTemplate
+		setBuildPlatform(buildInstance, workflow)
+		if err := k.client.Update(k.ctx, buildInstance); err != nil {
+			return nil, err
+		}
+	}
 
 	return buildInstance, nil
 }
 
+func (k *kogitoServerlessBuildManager) RefreshExtensions(build *operatorapi.KogitoServerlessBuild, workflow *operatorapi.KogitoServerlessWorkflow, plat *operatorapi.KogitoServerlessPlatform) (bool, error) {
+	extensions := getQuarkusExtensions(workflow, plat)
+	previous, found := build.Annotations[metadata.QuarkusExtensionsAnnotation]
+	if found && previous == extensions {
+		return false, nil
+	}
+	setBuildExtensions(build, extensions)
+	if err := k.client.Update(k.ctx, build); err != nil {
+		return false, err
+	}
+	// builds created before the extensions were recorded are assumed to be up-to-date
+	return found, nil
+}
+
+// getQuarkusExtensions gets the Quarkus extensions added to the workflow application, as recorded in the build
+func getQuarkusExtensions(workflow *operatorapi.KogitoServerlessWorkflow, plat *operatorapi.KogitoServerlessPlatform) string {
+	return strings.Join(workflowdef.GetQuarkusExtensions(workflow, plat), ",")
+}
+
+// setBuildExtensions records in the build the Quarkus extensions added to the workflow application
+func setBuildExtensions(build *operatorapi.KogitoServerlessBuild, extensions string) {
+	if build.Annotations == nil {
+		build.Annotations = map[string]string{}
+	}
+	build.Annotations[metadata.QuarkusExtensionsAnnotation] = extensions
+}
+
+// setBuildPlatform records in the build the platform selected by the workflow, so the build controller resolves the same one
+func setBuildPlatform(build *operatorapi.KogitoServerlessBuild, workflow *operatorapi.KogitoServerlessWorkflow) {
+	name := platform.SelectedPlatformName(workflow)
+	if len(name) == 0 {
+		delete(build.Annotations, metadata.PlatformAnnotation)
+		return
+	}
+	if build.Annotations == nil {
+		build.Annotations = map[string]string{}
+	}
+	build.Annotations[metadata.PlatformAnnotation] = name
+}
+
 type KogitoServerlessBuildManager interface {
 	// GetOrCreateBuild gets or creates a new instance of KogitoServerlessBuild for the given KogitoServerlessWorkflow.
 	//
@@ -71,6 +138,9 @@ type KogitoServerlessBuildManager interface {
 	GetOrCreateBuild(workflow *operatorapi.KogitoServerlessWorkflow) (*operatorapi.KogitoServerlessBuild, error)
 	// MarkToRestart tell the controller to restart this build in the next iteration
 	MarkToRestart(build *operatorapi.KogitoServerlessBuild) error
+	// RefreshExtensions records in the build the Quarkus extensions required by the workflow with the given platform,
+	// e.g. for its persistence or the platform services. It returns true when they changed, so the build must be restarted.
+	RefreshExtensions(build *operatorapi.KogitoServerlessBuild, workflow *operatorapi.KogitoServerlessWorkflow, plat *operatorapi.KogitoServerlessPlatform) (bool, error)
 }
 
 // NewKogitoServerlessBuildManager entry point to manage KogitoServerlessBuild instances.
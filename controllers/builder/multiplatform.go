@@ -0,0 +1,161 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
+	clientr "github.com/kiegroup/kogito-serverless-operator/container-builder/client"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/registry"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
+)
+
+// scheduleMultiPlatformBuild schedules a Kaniko build per platform, each pushing the image with the workflow image tag suffixed by the platform.
+// Once they all succeed, the images are referenced by an OCI image index pushed with the workflow image tag, see reconcileMultiPlatformBuild.
+func (c *containerBuilderManager) scheduleMultiPlatformBuild(build *operatorapi.KogitoServerlessBuild, imageNameTag string, workflowDefinition []byte, containerFile []byte, task *api.KanikoTask, timeout time.Duration, platforms []string) error {
+	build.Status.ImageTag = imageNameTag
+	if len(c.platform.Spec.BuildPlatform.Registry.Address) == 0 {
+		build.Status.BuildPhase = operatorapi.BuildPhaseFailed
+		build.Status.Error = "multi-platform builds require the registry address of the platform to push the image index"
+		return nil
+	}
+	var containerBuilds []*api.ContainerBuild
+	for _, p := range platforms {
+		suffix := strings.ReplaceAll(p, "/", "-")
+		platformTask := newPlatformTask(task, p)
+		ib := c.getImageBuilderForKaniko(build.Name, imageNameTag+"-"+suffix, workflowDefinition, containerFile, &platformTask)
+		ib.WithPodMiddleName(build.Name + "-" + suffix)
+		ib.WithTimeout(timeout)
+		containerBuild, err := c.buildImage(ib.Build())
+		if err != nil {
+			return err
+		}
+		containerBuilds = append(containerBuilds, containerBuild)
+		build.Status.Platforms = append(build.Status.Platforms, operatorapi.PlatformImageStatus{
+			Platform:   p,
+			ImageTag:   imageNameTag + "-" + suffix,
+			BuildPhase: operatorapi.BuildPhase(containerBuild.Status.Phase),
+		})
+	}
+	if err := build.Status.SetInnerBuild(containerBuilds); err != nil {
+		return err
+	}
+	build.Status.BuildPhase, build.Status.Error = aggregateBuildPhase(build.Status.Platforms, containerBuilds)
+	return nil
+}
+
+// newPlatformTask copies the Kaniko task to build the image of the given platform.
+// The per-platform pods run in parallel on different nodes, so they can't share the ReadWriteOnce cache volume, only the cache repository.
+func newPlatformTask(task *api.KanikoTask, platform string) api.KanikoTask {
+	platformTask := *task
+	platformTask.Platform = platform
+	platformTask.Cache.PersistentVolumeClaim = ""
+	return platformTask
+}
+
+// reconcileMultiPlatformBuild reconciles the build of each platform, and pushes the image index once they've all succeeded
+func (c *containerBuilderManager) reconcileMultiPlatformBuild(build *operatorapi.KogitoServerlessBuild, cli clientr.Client) error {
+	var containerBuilds []*api.ContainerBuild
+	if err := build.Status.GetInnerBuild(&containerBuilds); err != nil {
+		return err
+	}
+	if len(containerBuilds) != len(build.Status.Platforms) {
+		return fmt.Errorf("build %s has %d inner builds for %d platforms", build.Name, len(containerBuilds), len(build.Status.Platforms))
+	}
+	for i, containerBuild := range containerBuilds {
+		reconciled, err := c.reconcileBuild(containerBuild, cli)
+		if err != nil {
+			return err
+		}
+		containerBuilds[i] = reconciled
+		build.Status.Platforms[i].BuildPhase = operatorapi.BuildPhase(reconciled.Status.Phase)
+		build.Status.Platforms[i].Digest = reconciled.Status.Digest
+	}
+	if err := build.Status.SetInnerBuild(containerBuilds); err != nil {
+		return err
+	}
+	phase, message := aggregateBuildPhase(build.Status.Platforms, containerBuilds)
+	if phase == operatorapi.BuildPhaseSucceeded && len(build.Status.ImageDigest) == 0 {
+		digest, err := c.pushImageIndex(build)
+		if err != nil {
+			phase = operatorapi.BuildPhaseFailed
+			message = fmt.Sprintf("failed to push the image index: %v", err)
+		}
+		build.Status.ImageDigest = digest
+	}
+	build.Status.BuildPhase = phase
+	build.Status.Error = message
+	return nil
+}
+
+// aggregateBuildPhase gets the phase of a multi-platform build: failed as soon as a platform fails, succeeded when they all
+// succeed, running otherwise. The error names the failed platform.
+func aggregateBuildPhase(platforms []operatorapi.PlatformImageStatus, containerBuilds []*api.ContainerBuild) (operatorapi.BuildPhase, string) {
+	succeeded := 0
+	phase := operatorapi.BuildPhaseNone
+	for i, containerBuild := range containerBuilds {
+		switch containerBuild.Status.Phase {
+		case api.ContainerBuildPhaseFailed, api.ContainerBuildPhaseError, api.ContainerBuildPhaseInterrupted:
+			return operatorapi.BuildPhase(containerBuild.Status.Phase), fmt.Sprintf("%s: %s", platforms[i].Platform, containerBuild.Status.Error)
+		case api.ContainerBuildPhaseSucceeded:
+			succeeded++
+		case api.ContainerBuildPhaseRunning:
+			phase = operatorapi.BuildPhaseRunning
+		default:
+			if phase == operatorapi.BuildPhaseNone {
+				phase = operatorapi.BuildPhase(containerBuild.Status.Phase)
+			}
+		}
+	}
+	if succeeded == len(containerBuilds) {
+		return operatorapi.BuildPhaseSucceeded, ""
+	}
+	if phase == operatorapi.BuildPhaseNone {
+		// some platforms are done, the others are yet to be reported
+		phase = operatorapi.BuildPhaseRunning
+	}
+	return phase, ""
+}
+
+// pushImageIndex pushes the OCI image index referencing the images of every platform, returning its digest
+func (c *containerBuilderManager) pushImageIndex(build *operatorapi.KogitoServerlessBuild) (string, error) {
+	registrySpec := c.platform.Spec.BuildPlatform.Registry
+	access, err := platform.GetRegistryAccess(c.ctx, c.client, c.platform.Namespace, registrySpec)
+	if err != nil {
+		return "", err
+	}
+	options := registry.ImageIndexOptions{Image: registrySpec.Address + "/" + build.Status.ImageTag, RegistryAccess: access}
+	for _, p := range build.Status.Platforms {
+		if len(p.Digest) == 0 {
+			return "", fmt.Errorf("no digest reported for the %s image", p.Platform)
+		}
+		options.Manifests = append(options.Manifests, registry.ImageIndexManifest{Digest: p.Digest, Platform: p.Platform})
+	}
+	return registry.PushImageIndex(c.ctx, options)
+}
+
+// validateBuildPlatforms verifies that the platforms the workflow image is built for are valid `os/arch[/variant]` platforms
+func validateBuildPlatforms(platforms []string) error {
+	for _, p := range platforms {
+		if _, err := registry.ParsePlatform(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
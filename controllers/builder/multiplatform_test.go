@@ -0,0 +1,117 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/registry"
+	cbtest "github.com/kiegroup/kogito-serverless-operator/container-builder/util/test"
+	"github.com/kiegroup/kogito-serverless-operator/test"
+)
+
+func Test_aggregateBuildPhase(t *testing.T) {
+	platforms := []operatorapi.PlatformImageStatus{{Platform: "linux/amd64"}, {Platform: "linux/arm64"}}
+	newBuilds := func(phases ...api.ContainerBuildPhase) []*api.ContainerBuild {
+		var builds []*api.ContainerBuild
+		for _, phase := range phases {
+			builds = append(builds, &api.ContainerBuild{Status: api.ContainerBuildStatus{Phase: phase, Error: "boom"}})
+		}
+		return builds
+	}
+
+	phase, message := aggregateBuildPhase(platforms, newBuilds(api.ContainerBuildPhaseScheduling, api.ContainerBuildPhasePending))
+	assert.Equal(t, operatorapi.BuildPhaseScheduling, phase)
+	assert.Empty(t, message)
+	phase, _ = aggregateBuildPhase(platforms, newBuilds(api.ContainerBuildPhasePending, api.ContainerBuildPhaseRunning))
+	assert.Equal(t, operatorapi.BuildPhaseRunning, phase)
+	phase, _ = aggregateBuildPhase(platforms, newBuilds(api.ContainerBuildPhaseSucceeded, api.ContainerBuildPhaseRunning))
+	assert.Equal(t, operatorapi.BuildPhaseRunning, phase)
+	phase, message = aggregateBuildPhase(platforms, newBuilds(api.ContainerBuildPhaseRunning, api.ContainerBuildPhaseFailed))
+	assert.Equal(t, operatorapi.BuildPhaseFailed, phase)
+	assert.Equal(t, "linux/arm64: boom", message)
+	phase, message = aggregateBuildPhase(platforms, newBuilds(api.ContainerBuildPhaseSucceeded, api.ContainerBuildPhaseSucceeded))
+	assert.Equal(t, operatorapi.BuildPhaseSucceeded, phase)
+	assert.Empty(t, message)
+}
+
+func Test_newPlatformTask(t *testing.T) {
+	enabled := true
+	task := &api.KanikoTask{Cache: api.KanikoTaskCache{Enabled: &enabled, PersistentVolumeClaim: "kaniko-cache", Repository: "quay.io/kiegroup/cache"}}
+	platformTask := newPlatformTask(task, "linux/arm64")
+	assert.Equal(t, "linux/arm64", platformTask.Platform)
+	assert.Empty(t, platformTask.Cache.PersistentVolumeClaim)
+	assert.Equal(t, "quay.io/kiegroup/cache", platformTask.Cache.Repository)
+	// the task of the single platform build is left untouched
+	assert.Equal(t, "kaniko-cache", task.Cache.PersistentVolumeClaim)
+}
+
+func Test_containerBuilderManager_pushImageIndex(t *testing.T) {
+	ns := t.Name()
+	standIn := &cbtest.RegistryStandIn{Username: "user", Password: "secret"}
+	server := standIn.Start(true)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	amd64 := standIn.PutManifest("kiegroup/greeting", "latest-linux-amd64", registry.MediaTypeDockerManifest, []byte(`{"arch":"amd64"}`))
+	arm64 := standIn.PutManifest("kiegroup/greeting", "latest-linux-arm64", registry.MediaTypeDockerManifest, []byte(`{"arch":"arm64"}`))
+
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, ns)
+	platform.Spec.BuildPlatform.Registry = operatorapi.RegistrySpec{Address: host + "/kiegroup", Insecure: true, Secret: "regcred"}
+	auth := base64.StdEncoding.EncodeToString([]byte("user:secret"))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "regcred", Namespace: ns},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(fmt.Sprintf(`{"auths":{%q:{"auth":%q}}}`, host, auth))},
+	}
+	manager := &containerBuilderManager{buildManagerContext: buildManagerContext{
+		ctx:      context.TODO(),
+		client:   test.NewKogitoClientBuilder().WithRuntimeObjects(platform, secret).Build(),
+		platform: platform,
+	}}
+
+	build := test.GetNewEmptyKogitoServerlessBuild("greeting", ns)
+	build.Status.ImageTag = "greeting:latest"
+	build.Status.Platforms = []operatorapi.PlatformImageStatus{
+		{Platform: "linux/amd64", ImageTag: "greeting:latest-linux-amd64", Digest: amd64},
+		{Platform: "linux/arm64", ImageTag: "greeting:latest-linux-arm64"},
+	}
+	_, err := manager.pushImageIndex(build)
+	assert.ErrorContains(t, err, "linux/arm64")
+
+	build.Status.Platforms[1].Digest = arm64
+	digest, err := manager.pushImageIndex(build)
+	assert.NoError(t, err)
+	mediaType, _, found := standIn.Manifest("kiegroup/greeting", "latest")
+	assert.True(t, found)
+	assert.Equal(t, registry.MediaTypeImageIndex, mediaType)
+	_, _, found = standIn.Manifest("kiegroup/greeting", digest)
+	assert.True(t, found)
+}
+
+func Test_validateBuildPlatforms(t *testing.T) {
+	assert.NoError(t, validateBuildPlatforms(nil))
+	assert.NoError(t, validateBuildPlatforms([]string{"linux/amd64", "linux/arm64/v8"}))
+	assert.Error(t, validateBuildPlatforms([]string{"linux/amd64", "arm64"}))
+}
@@ -29,8 +29,10 @@ import (
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/registry"
 	kubeutil "github.com/kiegroup/kogito-serverless-operator/utils/kubernetes"
 
+	"github.com/kiegroup/kogito-serverless-operator/controllers/tracing"
 	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
 	"github.com/kiegroup/kogito-serverless-operator/utils"
 
@@ -103,8 +105,34 @@ func (o *openshiftBuilderManager) Schedule(build *operatorapi.KogitoServerlessBu
 	if err != nil {
 		return err
 	}
+	if err = validateBuildPlatforms(workflowdef.GetBuildPlatforms(workflow, o.platform)); err != nil {
+		build.Status.BuildPhase = operatorapi.BuildPhaseFailed
+		build.Status.Error = err.Error()
+		return nil
+	}
+	if err = workflowdef.ValidateBuildExtensions(workflow, o.platform); err != nil {
+		build.Status.BuildPhase = operatorapi.BuildPhaseFailed
+		build.Status.Error = err.Error()
+		return nil
+	}
+	dockerfile, err := o.getDockerfile(workflow)
+	if err != nil {
+		build.Status.BuildPhase = operatorapi.BuildPhaseFailed
+		build.Status.Error = err.Error()
+		return nil
+	}
+	if len(workflowdef.GetBuildPlatforms(workflow, o.platform)) > 1 {
+		build.Status.BuildPhase = operatorapi.BuildPhaseFailed
+		build.Status.Error = "multi-platform builds require the operator build strategy"
+		return nil
+	}
+	if workflowdef.GetBuildAttestation(workflow, o.platform) != nil {
+		build.Status.BuildPhase = operatorapi.BuildPhaseFailed
+		build.Status.Error = "image attestation requires the operator build strategy"
+		return nil
+	}
 	build.Status.ImageTag = workflowdef.GetWorkflowAppImageNameTag(workflow)
-	bc := o.newDefaultBuildConfig(build)
+	bc := o.newDefaultBuildConfig(build, workflow, dockerfile)
 	if err = o.addExternalResources(bc, workflow); err != nil {
 		return err
 	}
@@ -125,10 +153,11 @@ func (o *openshiftBuilderManager) Schedule(build *operatorapi.KogitoServerlessBu
 		return err
 	}
 	if _, err = controllerutil.CreateOrPatch(o.ctx, o.client, bc, func() error {
+		tracing.Inject(o.ctx, bc)
 		if kubeutil.IsObjectNew(bc) {
 			return nil
 		}
-		referenceBC := o.newDefaultBuildConfig(build)
+		referenceBC := o.newDefaultBuildConfig(build, workflow, dockerfile)
 		bc.Spec = *referenceBC.Spec.DeepCopy()
 		return o.addExternalResources(bc, workflow)
 	}); err != nil {
@@ -139,9 +168,22 @@ func (o *openshiftBuilderManager) Schedule(build *operatorapi.KogitoServerlessBu
 	return nil
 }
 
-func (o *openshiftBuilderManager) newDefaultBuildConfig(build *operatorapi.KogitoServerlessBuild) *buildv1.BuildConfig {
+func (o *openshiftBuilderManager) newDefaultBuildConfig(build *operatorapi.KogitoServerlessBuild, workflow *operatorapi.KogitoServerlessWorkflow, dockerFile string) *buildv1.BuildConfig {
 	optimizationPol := buildv1.ImageOptimizationSkipLayers
-	dockerFile := o.commonConfig.Data[o.commonConfig.Data[configKeyDefaultBuilderResourceName]]
+	mode := workflowdef.GetBuildMode(workflow, o.platform)
+	// OpenShift builds have no deadline unless the build sets a timeout or is native
+	var completionDeadline *int64
+	if build.Spec.Timeout.Duration > 0 || mode == operatorapi.BuildModeNative {
+		seconds := int64(getBuildTimeout(build, mode).Seconds())
+		completionDeadline = &seconds
+	}
+	// the build runs on a node of the platform the image is built for, if any
+	var nodeSelector buildv1.OptionalNodeSelector
+	if platforms := workflowdef.GetBuildPlatforms(workflow, o.platform); len(platforms) == 1 {
+		if platform, err := registry.ParsePlatform(platforms[0]); err == nil {
+			nodeSelector = platform.NodeSelector()
+		}
+	}
 	return &buildv1.BuildConfig{
 		ObjectMeta: metav1.ObjectMeta{Namespace: build.Namespace, Name: build.Name},
 		Spec: buildv1.BuildConfigSpec{
@@ -157,6 +199,7 @@ func (o *openshiftBuilderManager) newDefaultBuildConfig(build *operatorapi.Kogit
 					Type: buildv1.DockerBuildStrategyType,
 					DockerStrategy: &buildv1.DockerBuildStrategy{
 						ImageOptimizationPolicy: &optimizationPol,
+						BuildArgs:               o.getBuildArgs(workflow),
 					},
 				},
 				Output: buildv1.BuildOutput{
@@ -166,7 +209,9 @@ func (o *openshiftBuilderManager) newDefaultBuildConfig(build *operatorapi.Kogit
 						Kind:      imageStreamTagKind,
 					},
 				},
-				Resources: build.Spec.Resources,
+				Resources:                 getBuildResources(build, mode),
+				CompletionDeadlineSeconds: completionDeadline,
+				NodeSelector:              nodeSelector,
 			},
 		},
 	}
@@ -17,12 +17,14 @@ package builder
 import (
 	"context"
 	"testing"
+	"time"
 
 	buildv1 "github.com/openshift/api/build/v1"
 	imgv1 "github.com/openshift/api/image/v1"
 	buildfake "github.com/openshift/client-go/build/clientset/versioned/fake"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -126,3 +128,116 @@ func Test_openshiftbuilder_externalCMs(t *testing.T) {
 
 	assert.Len(t, bc.Spec.Source.ConfigMaps, 1)
 }
+
+func Test_openshiftbuilder_persistenceBuildArgs(t *testing.T) {
+	ns := t.Name()
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, ns)
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, ns)
+	platform.Spec.Persistence = &operatorapi.PersistenceSpec{
+		PostgreSQL: &operatorapi.PostgreSQLPersistenceSpec{
+			SecretRef: operatorapi.PostgreSQLSecretReference{Name: "postgresql"},
+			JdbcURL:   "jdbc:postgresql://postgresql:5432/kogito",
+		},
+	}
+	config := test.GetKogitoServerlessOperatorBuilderConfig("../../", ns)
+	client := test.NewKogitoClientBuilderWithOpenShift().WithRuntimeObjects(workflow, platform, config).Build()
+	managerContext := buildManagerContext{
+		ctx:          context.TODO(),
+		client:       client,
+		platform:     platform,
+		commonConfig: config,
+	}
+	buildManager := newOpenShiftBuilderManagerWithClient(managerContext, buildfake.NewSimpleClientset().BuildV1())
+
+	kbuild, err := NewKogitoServerlessBuildManager(context.TODO(), client).GetOrCreateBuild(workflow)
+	assert.NoError(t, err)
+	assert.NoError(t, buildManager.Schedule(kbuild))
+
+	bc := &buildv1.BuildConfig{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, bc))
+	assert.Len(t, bc.Spec.Strategy.DockerStrategy.BuildArgs, 1)
+	assert.Equal(t, quarkusExtensionsBuildArg, bc.Spec.Strategy.DockerStrategy.BuildArgs[0].Name)
+	assert.Equal(t, "org.kie.kogito:kogito-addons-quarkus-persistence-jdbc,io.quarkus:quarkus-jdbc-postgresql,io.quarkus:quarkus-agroal,io.quarkus:quarkus-flyway",
+		bc.Spec.Strategy.DockerStrategy.BuildArgs[0].Value)
+}
+
+func Test_openshiftbuilder_nativeMode(t *testing.T) {
+	ns := t.Name()
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, ns)
+	workflow.Spec.Build = &operatorapi.WorkflowBuildSpec{Mode: operatorapi.BuildModeNative}
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, ns)
+	config := test.GetKogitoServerlessOperatorBuilderConfig("../../", ns)
+	client := test.NewKogitoClientBuilderWithOpenShift().WithRuntimeObjects(workflow, platform, config).Build()
+	managerContext := buildManagerContext{
+		ctx:          context.TODO(),
+		client:       client,
+		platform:     platform,
+		commonConfig: config,
+	}
+	buildManager := newOpenShiftBuilderManagerWithClient(managerContext, buildfake.NewSimpleClientset().BuildV1())
+
+	kbuild, err := NewKogitoServerlessBuildManager(context.TODO(), client).GetOrCreateBuild(workflow)
+	assert.NoError(t, err)
+	assert.NoError(t, buildManager.Schedule(kbuild))
+	assert.Equal(t, operatorapi.BuildPhaseInitialization, kbuild.Status.BuildPhase)
+
+	bc := &buildv1.BuildConfig{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, bc))
+	assert.Contains(t, *bc.Spec.Source.Dockerfile, "AS native")
+	assert.Contains(t, *bc.Spec.Source.Dockerfile, "FROM registry.access.redhat.com/ubi8/ubi-minimal:latest")
+	assert.Equal(t, nativeBuildResources, bc.Spec.Resources)
+	assert.Equal(t, int64(defaultNativeBuildTimeout.Seconds()), *bc.Spec.CompletionDeadlineSeconds)
+
+	// the build resources and timeout win over the native defaults
+	kbuild.Spec.Timeout = metav1.Duration{Duration: 90 * time.Minute}
+	kbuild.Spec.Resources = v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("16Gi")}}
+	assert.NoError(t, buildManager.Schedule(kbuild))
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, bc))
+	assert.Equal(t, kbuild.Spec.Resources, bc.Spec.Resources)
+	assert.Equal(t, int64(5400), *bc.Spec.CompletionDeadlineSeconds)
+
+	// without a native Dockerfile the build fails
+	delete(config.Data, config.Data[configKeyNativeBuilderResourceName])
+	assert.NoError(t, buildManager.Schedule(kbuild))
+	assert.Equal(t, operatorapi.BuildPhaseFailed, kbuild.Status.BuildPhase)
+	assert.Contains(t, kbuild.Status.Error, configKeyNativeBuilderResourceName)
+}
+
+func Test_openshiftbuilder_platforms(t *testing.T) {
+	ns := t.Name()
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, ns)
+	workflow.Spec.Build = &operatorapi.WorkflowBuildSpec{Platforms: []string{"linux/arm64"}}
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, ns)
+	config := test.GetKogitoServerlessOperatorBuilderConfig("../../", ns)
+	client := test.NewKogitoClientBuilderWithOpenShift().WithRuntimeObjects(workflow, platform, config).Build()
+	managerContext := buildManagerContext{
+		ctx:          context.TODO(),
+		client:       client,
+		platform:     platform,
+		commonConfig: config,
+	}
+	buildManager := newOpenShiftBuilderManagerWithClient(managerContext, buildfake.NewSimpleClientset().BuildV1())
+
+	kbuild, err := NewKogitoServerlessBuildManager(context.TODO(), client).GetOrCreateBuild(workflow)
+	assert.NoError(t, err)
+	assert.NoError(t, buildManager.Schedule(kbuild))
+	bc := &buildv1.BuildConfig{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, bc))
+	assert.Equal(t, buildv1.OptionalNodeSelector{"kubernetes.io/os": "linux", "kubernetes.io/arch": "arm64"}, bc.Spec.NodeSelector)
+
+	// OpenShift builds a single platform at once
+	workflow.Spec.Build.Platforms = []string{"linux/amd64", "linux/arm64"}
+	assert.NoError(t, client.Update(context.TODO(), workflow))
+	assert.NoError(t, buildManager.Schedule(kbuild))
+	assert.Equal(t, operatorapi.BuildPhaseFailed, kbuild.Status.BuildPhase)
+	assert.Contains(t, kbuild.Status.Error, "operator build strategy")
+
+	// OpenShift builds don't attest the image
+	workflow.Spec.Build.Platforms = nil
+	workflow.Spec.Build.Attestation = &operatorapi.AttestationSpec{SBOM: operatorapi.SBOMFormatSPDX}
+	assert.NoError(t, client.Update(context.TODO(), workflow))
+	kbuild.Status.Error = ""
+	assert.NoError(t, buildManager.Schedule(kbuild))
+	assert.Equal(t, operatorapi.BuildPhaseFailed, kbuild.Status.BuildPhase)
+	assert.Equal(t, "image attestation requires the operator build strategy", kbuild.Status.Error)
+}
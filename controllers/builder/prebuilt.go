@@ -0,0 +1,51 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/registry"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
+)
+
+// ResolvePrebuiltWorkflowImage checks that the prebuilt image of the workflow exists in its registry.
+// It returns the image pinned by digest, so the deployed image doesn't change when its tag is pushed again.
+func ResolvePrebuiltWorkflowImage(ctx context.Context, c client.Client, workflow *operatorapi.KogitoServerlessWorkflow) (string, error) {
+	prebuilt := workflow.Spec.Image
+	if prebuilt == nil {
+		return "", fmt.Errorf("the workflow %s doesn't reference a prebuilt image", workflow.Name)
+	}
+	registrySpec := operatorapi.RegistrySpec{Address: prebuilt.Name, Insecure: prebuilt.Insecure}
+	if prebuilt.RegistrySecretRef != nil {
+		registrySpec.Secret = prebuilt.RegistrySecretRef.Name
+	}
+	access, err := platform.GetRegistryAccess(ctx, c, workflow.Namespace, registrySpec)
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := registry.ResolveImage(ctx, prebuilt.Name, access)
+	if err != nil {
+		return "", err
+	}
+	name, _, _ := strings.Cut(prebuilt.Name, "@")
+	return imageRepository(name) + "@" + digest, nil
+}
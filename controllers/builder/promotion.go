@@ -0,0 +1,121 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/registry"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
+)
+
+// promotionTimeout bounds the copy of a promoted image, all its blobs included
+const promotionTimeout = 30 * time.Minute
+
+// promotions holds the image copies running in the background, by workflow
+var promotions = struct {
+	sync.Mutex
+	copies map[types.NamespacedName]*promotionCopy
+}{copies: map[types.NamespacedName]*promotionCopy{}}
+
+// promotionCopy is an image copy running in the background, its result is set once done is closed
+type promotionCopy struct {
+	source string
+	target string
+	done   chan struct{}
+	digest string
+	err    error
+}
+
+// PromoteWorkflowImage copies the image promoted by the workflow to the platform registry, with the workflow image name and tag.
+// The copy runs in the background, so that the workflow reconciliation isn't blocked by the transfer of the image:
+// it returns the copy, pinned by digest, once done, and an empty image while the copy is running.
+func PromoteWorkflowImage(ctx context.Context, c client.Client, plat *operatorapi.KogitoServerlessPlatform, workflow *operatorapi.KogitoServerlessWorkflow) (string, error) {
+	promotion := workflow.Spec.Promotion
+	if promotion == nil {
+		return "", fmt.Errorf("the workflow %s doesn't promote an image", workflow.Name)
+	}
+	targetSpec := plat.Spec.BuildPlatform.Registry
+	if len(targetSpec.Address) == 0 {
+		return "", fmt.Errorf("the platform %s has no registry to promote the image to", plat.Name)
+	}
+	target := targetSpec.Address + "/" + workflowdef.GetWorkflowAppImageNameTag(workflow)
+	targetAccess, err := platform.GetRegistryAccess(ctx, c, plat.Namespace, targetSpec)
+	if err != nil {
+		return "", err
+	}
+	sourceSpec := operatorapi.RegistrySpec{Address: promotion.Image, Insecure: promotion.Insecure}
+	if promotion.RegistrySecretRef != nil {
+		sourceSpec.Secret = promotion.RegistrySecretRef.Name
+	}
+	sourceAccess, err := platform.GetRegistryAccess(ctx, c, workflow.Namespace, sourceSpec)
+	if err != nil {
+		return "", err
+	}
+
+	key := client.ObjectKeyFromObject(workflow)
+	promotions.Lock()
+	defer promotions.Unlock()
+	running, found := promotions.copies[key]
+	if !found || running.source != promotion.Image || running.target != target {
+		// the copy of a former promoted image is left to complete, only its result is discarded
+		running = startPromotionCopy(registry.CopyImageOptions{
+			Source:       promotion.Image,
+			SourceAccess: sourceAccess,
+			Target:       target,
+			TargetAccess: targetAccess,
+		})
+		promotions.copies[key] = running
+	}
+	select {
+	case <-running.done:
+		delete(promotions.copies, key)
+		if running.err != nil {
+			return "", running.err
+		}
+		return imageRepository(target) + "@" + running.digest, nil
+	default:
+		return "", nil
+	}
+}
+
+// startPromotionCopy copies the image in the background, detached from the reconciliation context
+func startPromotionCopy(options registry.CopyImageOptions) *promotionCopy {
+	running := &promotionCopy{source: options.Source, target: options.Target, done: make(chan struct{})}
+	go func() {
+		defer close(running.done)
+		ctx, cancel := context.WithTimeout(context.Background(), promotionTimeout)
+		defer cancel()
+		running.digest, running.err = registry.CopyImage(ctx, options)
+	}()
+	return running
+}
+
+// imageRepository strips the tag from an image name
+func imageRepository(image string) string {
+	if colon := strings.LastIndex(image, ":"); colon > strings.LastIndex(image, "/") {
+		return image[:colon]
+	}
+	return image
+}
@@ -23,6 +23,7 @@ import (
 	imgv1 "github.com/openshift/api/image/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
@@ -32,8 +33,11 @@ import (
 
 	"github.com/kiegroup/kogito-serverless-operator/utils"
 
+	"github.com/kiegroup/kogito-serverless-operator/api"
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 	"github.com/kiegroup/kogito-serverless-operator/controllers/builder"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/metrics"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/tracing"
 )
 
 // KogitoServerlessBuildReconciler reconciles a KogitoServerlessBuild object
@@ -74,41 +78,99 @@ func (r *KogitoServerlessBuildReconciler) Reconcile(ctx context.Context, req ctr
 	}
 
 	phase := build.Status.BuildPhase
+	if phase.IsFinished() {
+		return ctrl.Result{}, nil
+	}
 
+	// joins the trace of the workflow reconciliation that created or restarted the build
+	ctx = tracing.Extract(ctx, build)
+	if phase == operatorapi.BuildPhaseNone {
+		ctx, span := tracing.StartSpan(ctx, "BuildManager.Schedule", build)
+		err = r.scheduleBuild(ctx, build)
+		tracing.EndSpan(span, err)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: requeueAfterForNewBuild}, nil
+	}
+	ctx, span := tracing.StartSpan(ctx, "BuildManager.Reconcile", build)
+	err = r.reconcileBuild(ctx, build)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	buildManager, err := builder.NewBuildManager(ctx, r.Client, r.Config, build.Name, build.Namespace)
+	return ctrl.Result{RequeueAfter: requeueAfterForBuildRunning}, nil
+}
+
+func (r *KogitoServerlessBuildReconciler) scheduleBuild(ctx context.Context, build *operatorapi.KogitoServerlessBuild) error {
+	buildManager, err := builder.NewBuildManager(ctx, r.Client, r.Config, build)
 	if err != nil {
-		log.Error(err, "Failed to get create a build manager to handle the workflow build")
-		return ctrl.Result{}, err
+		ctrllog.FromContext(ctx).Error(err, "Failed to get create a build manager to handle the workflow build")
+		return err
+	}
+	if err = buildManager.Schedule(build); err != nil {
+		return err
+	}
+	now := metav1.Now()
+	build.Status.StartTime = &now
+	build.Status.CompletionTime = nil
+	// the build can fail while being scheduled, e.g. when its configuration is invalid
+	if build.Status.BuildPhase.IsFinished() {
+		completeBuild(buildManager.Strategy(), build, now)
 	}
+	r.manageStatusUpdate(ctx, build, operatorapi.BuildPhaseNone)
+	return nil
+}
 
-	if phase == operatorapi.BuildPhaseNone {
-		if err := buildManager.Schedule(build); err != nil {
-			return ctrl.Result{}, err
-		}
-		r.manageStatusUpdate(ctx, build)
-		return ctrl.Result{RequeueAfter: requeueAfterForNewBuild}, nil
-		// TODO: this smells, why not just else? review in the future: https://issues.redhat.com/browse/KOGITO-8785
-	} else if phase != operatorapi.BuildPhaseSucceeded && phase != operatorapi.BuildPhaseError && phase != operatorapi.BuildPhaseFailed {
-		beforeReconcilePhase := build.Status.BuildPhase
-		if err = buildManager.Reconcile(build); err != nil {
-			return ctrl.Result{}, err
-		}
-		if beforeReconcilePhase != build.Status.BuildPhase {
-			r.manageStatusUpdate(ctx, build)
+func (r *KogitoServerlessBuildReconciler) reconcileBuild(ctx context.Context, build *operatorapi.KogitoServerlessBuild) error {
+	buildManager, err := builder.NewBuildManager(ctx, r.Client, r.Config, build)
+	if err != nil {
+		ctrllog.FromContext(ctx).Error(err, "Failed to get create a build manager to handle the workflow build")
+		return err
+	}
+	beforeReconcilePhase := build.Status.BuildPhase
+	if err = buildManager.Reconcile(build); err != nil {
+		return err
+	}
+	if beforeReconcilePhase != build.Status.BuildPhase {
+		if build.Status.BuildPhase.IsFinished() {
+			completeBuild(buildManager.Strategy(), build, metav1.Now())
 		}
-		return ctrl.Result{RequeueAfter: requeueAfterForBuildRunning}, nil
+		r.manageStatusUpdate(ctx, build, beforeReconcilePhase)
 	}
+	return nil
+}
 
-	return ctrl.Result{}, nil
+// completeBuild sets the completion time of the build that reached a final phase and records its duration
+func completeBuild(strategy operatorapi.BuildStrategy, build *operatorapi.KogitoServerlessBuild, now metav1.Time) {
+	build.Status.CompletionTime = &now
+	if build.Status.StartTime != nil {
+		metrics.ObserveBuild(strategy, build.Status.BuildPhase, now.Sub(build.Status.StartTime.Time))
+	}
 }
 
-func (r *KogitoServerlessBuildReconciler) manageStatusUpdate(ctx context.Context, instance *operatorapi.KogitoServerlessBuild) {
+func (r *KogitoServerlessBuildReconciler) manageStatusUpdate(ctx context.Context, instance *operatorapi.KogitoServerlessBuild, previousPhase operatorapi.BuildPhase) {
 	err := r.Status().Update(ctx, instance)
 	if err == nil {
-		r.Recorder.Event(instance, corev1.EventTypeNormal, "Updated", fmt.Sprintf("Updated buildphase to  %s", instance.Status.BuildPhase))
+		r.recordPhaseEvent(instance, previousPhase)
+	}
+}
+
+// recordPhaseEvent emits the Event matching the build phase transition, if any
+func (r *KogitoServerlessBuildReconciler) recordPhaseEvent(instance *operatorapi.KogitoServerlessBuild, previousPhase operatorapi.BuildPhase) {
+	switch instance.Status.BuildPhase {
+	case operatorapi.BuildPhaseSucceeded:
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, api.BuildSucceededEventReason, "Image %s built", instance.Status.ImageTag)
+	case operatorapi.BuildPhaseFailed, operatorapi.BuildPhaseError:
+		message := instance.Status.Error
+		if len(message) == 0 {
+			message = fmt.Sprintf("Build finished in phase %s", instance.Status.BuildPhase)
+		}
+		r.Recorder.Event(instance, corev1.EventTypeWarning, api.BuildFailedEventReason, message)
+	default:
+		if previousPhase == operatorapi.BuildPhaseNone {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, api.BuildStartedEventReason, "Build of image %s started", instance.Status.ImageTag)
+		}
 	}
 }
 
@@ -18,10 +18,12 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
@@ -39,7 +41,8 @@ func TestKogitoServerlessBuildController(t *testing.T) {
 		WithRuntimeObjects(test.GetKogitoServerlessOperatorBuilderConfig("../", namespace)).
 		Build()
 
-	r := &KogitoServerlessBuildReconciler{cl, cl.Scheme(), &record.FakeRecorder{}, &rest.Config{}}
+	recorder := record.NewFakeRecorder(10)
+	r := &KogitoServerlessBuildReconciler{cl, cl.Scheme(), recorder, &rest.Config{}}
 	req := reconcile.Request{
 		NamespacedName: types.NamespacedName{
 			Name:      ksb.Name,
@@ -59,4 +62,40 @@ func TestKogitoServerlessBuildController(t *testing.T) {
 	containerBuild := &api.ContainerBuild{}
 	assert.NoError(t, ksb.Status.GetInnerBuild(containerBuild))
 	assert.Equal(t, string(ksb.Status.BuildPhase), string(containerBuild.Status.Phase))
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Normal BuildStarted")
+
+	// the build fails
+	ksb.Status.BuildPhase = operatorapi.BuildPhaseFailed
+	ksb.Status.Error = "no space left on device"
+	r.manageStatusUpdate(context.TODO(), ksb, operatorapi.BuildPhaseRunning)
+	assert.Equal(t, "Warning BuildFailed no space left on device", <-recorder.Events)
+}
+
+func TestKogitoServerlessBuildControllerFailedScheduling(t *testing.T) {
+	namespace := t.Name()
+	ksw := test.GetKogitoServerlessWorkflow("../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, namespace)
+	ksw.Spec.Build = &operatorapi.WorkflowBuildSpec{Platforms: []string{"linux"}}
+	ksb := test.GetNewEmptyKogitoServerlessBuild(ksw.Name, namespace)
+	cl := test.NewKogitoClientBuilder().
+		WithRuntimeObjects(ksb, ksw).
+		WithRuntimeObjects(test.GetKogitoServerlessPlatformInReadyPhase("../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, namespace)).
+		WithRuntimeObjects(test.GetKogitoServerlessOperatorBuilderConfig("../", namespace)).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &KogitoServerlessBuildReconciler{cl, cl.Scheme(), recorder, &rest.Config{}}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksb.Name, Namespace: ksb.Namespace}}
+
+	// the build fails while being scheduled, it's completed and observed like any other failed build
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksb))
+	assert.Equal(t, operatorapi.BuildPhaseFailed, ksb.Status.BuildPhase)
+	assert.NotNil(t, ksb.Status.StartTime)
+	assert.NotNil(t, ksb.Status.CompletionTime)
+	assert.Contains(t, <-recorder.Events, "Warning BuildFailed")
+	count, err := testutil.GatherAndCount(ctrlmetrics.Registry, "kogito_serverless_build_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
 }
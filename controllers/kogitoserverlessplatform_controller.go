@@ -19,13 +19,18 @@ import (
 	"fmt"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	clientr "github.com/kiegroup/kogito-serverless-operator/container-builder/client"
 	klog "github.com/kiegroup/kogito-serverless-operator/container-builder/util/log"
@@ -36,6 +41,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/kiegroup/kogito-serverless-operator/api"
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 )
 
@@ -54,6 +60,7 @@ type KogitoServerlessPlatformReconciler struct {
 //+kubebuilder:rbac:groups=sw.kogito.kie.org,resources=kogitoserverlessplatforms,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=sw.kogito.kie.org,resources=kogitoserverlessplatforms/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=sw.kogito.kie.org,resources=kogitoserverlessplatforms/finalizers,verbs=update
+//+kubebuilder:rbac:groups=sw.kogito.kie.org,resources=kogitoserverlessclusterplatforms,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -95,10 +102,26 @@ func (r *KogitoServerlessPlatformReconciler) Reconcile(ctx context.Context, req
 		logger.Info("Ignoring request because resource is not assigned to current operator")
 		return reconcile.Result{}, nil
 	}
+	cli, _ := clientr.FromCtrlClientSchemeAndConfig(r.Client, r.Scheme, r.Config)
+	target := instance.DeepCopy()
+	if err := platform.InheritClusterPlatform(ctx, cli, target); err != nil {
+		if !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		return r.manageClusterPlatformNotFound(ctx, &instance)
+	}
+	if succeed := target.Status.GetTopLevelCondition(); succeed.IsFalse() && succeed.Reason == api.ClusterPlatformNotFoundReason {
+		// the cluster platform is back, resume from the phase matching the other conditions
+		target.Status.Manager().MarkUnknown(api.SucceedConditionType, "", "")
+		target.Status.UpdatePhase()
+	}
+
 	actions := []platform.Action{
 		platform.NewInitializeAction(),
 		platform.NewWarmAction(r.Reader),
+		platform.NewRegistryCheckAction(),
 		platform.NewCreateAction(),
+		platform.NewDeployServicesAction(),
 		platform.NewMonitorAction(),
 	}
 
@@ -106,11 +129,9 @@ func (r *KogitoServerlessPlatformReconciler) Reconcile(ctx context.Context, req
 
 	var err error
 
-	target := instance.DeepCopy()
 	targetLog := klog.Log
 
 	for _, a := range actions {
-		cli, _ := clientr.FromCtrlClientSchemeAndConfig(r.Client, r.Scheme, r.Config)
 		a.InjectClient(cli)
 		a.InjectLogger(targetLog)
 
@@ -121,20 +142,27 @@ func (r *KogitoServerlessPlatformReconciler) Reconcile(ctx context.Context, req
 
 			target, err = a.Handle(ctx, target)
 			if err != nil {
-				r.Recorder.Event(&instance, corev1.EventTypeNormal, "Updated", fmt.Sprintf("Updated platform phase to  %s", instance.Status.Phase))
+				r.Recorder.Eventf(&instance, corev1.EventTypeWarning, api.PlatformErrorEventReason, "Failed to invoke action %s: %v", a.Name(), err)
 				return reconcile.Result{}, err
 			}
 
 			if target != nil {
 				target.Status.ObservedGeneration = instance.Generation
+				target.Status.UpdatePhase()
+				target.Status.EffectiveSpec = nil
+				if instance.Spec.ClusterPlatformRef != nil {
+					// the inherited fields are only reported in the status, so that the cluster platform changes are picked up
+					target.Status.EffectiveSpec = target.Spec.DeepCopy()
+					target.Spec = *instance.Spec.DeepCopy()
+				}
 
 				if err := r.Client.Status().Patch(ctx, target, ctrl.MergeFrom(&instance)); err != nil {
-					r.Recorder.Event(&instance, corev1.EventTypeNormal, "Status Updated", fmt.Sprintf("Updated platform phase to  %s", instance.Status.Phase))
+					r.Recorder.Eventf(&instance, corev1.EventTypeWarning, api.PlatformErrorEventReason, "Failed to update the platform status: %v", err)
 					return reconcile.Result{}, err
 				}
 
 				if err := r.Client.Update(ctx, target); err != nil {
-					r.Recorder.Event(&instance, corev1.EventTypeNormal, "Spec Updated", fmt.Sprintf("Updated platform phase to  %s", instance.Status.Phase))
+					r.Recorder.Eventf(&instance, corev1.EventTypeWarning, api.PlatformErrorEventReason, "Failed to update the platform: %v", err)
 					return reconcile.Result{}, err
 				}
 
@@ -146,12 +174,12 @@ func (r *KogitoServerlessPlatformReconciler) Reconcile(ctx context.Context, req
 						"phase-from", phaseFrom,
 						"phase-to", target.Status.Phase,
 					)
+					r.recordPhaseEvent(target)
 				}
 			}
 
 			// handle one action at time so the resource
 			// is always at its latest state
-			r.Recorder.Event(&instance, corev1.EventTypeNormal, "Updated", fmt.Sprintf("Updated platform phase to  %s", instance.Status.Phase))
 			break
 		}
 	}
@@ -167,9 +195,55 @@ func (r *KogitoServerlessPlatformReconciler) Reconcile(ctx context.Context, req
 
 }
 
+// manageClusterPlatformNotFound fails the platform while its cluster platform is missing.
+// The platforms not initialized yet, or duplicated, just wait for it.
+func (r *KogitoServerlessPlatformReconciler) manageClusterPlatformNotFound(ctx context.Context, instance *operatorapi.KogitoServerlessPlatform) (reconcile.Result, error) {
+	name := instance.Spec.ClusterPlatformRef.Name
+	r.Recorder.Eventf(instance, corev1.EventTypeWarning, api.PlatformErrorEventReason, "Cluster platform %s not found", name)
+	if instance.Status.Phase != operatorapi.PlatformPhaseNone && instance.Status.Phase != operatorapi.PlatformPhaseDuplicate {
+		target := instance.DeepCopy()
+		target.Status.Manager().MarkFalse(api.SucceedConditionType, api.ClusterPlatformNotFoundReason, "Cluster platform %s not found", name)
+		target.Status.UpdatePhase()
+		if err := r.Client.Status().Patch(ctx, target, ctrl.MergeFrom(instance)); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+}
+
+// platformsReferencing returns the requests of the platforms inheriting from the given cluster platform
+func (r *KogitoServerlessPlatformReconciler) platformsReferencing(clusterPlatform ctrl.Object) []reconcile.Request {
+	platforms := operatorapi.NewKogitoServerlessPlatformList()
+	if err := r.Client.List(context.Background(), &platforms); err != nil {
+		klog.Error(err, "Failed to list the platforms referencing the cluster platform", "name", clusterPlatform.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, p := range platforms.Items {
+		if p.Spec.ClusterPlatformRef != nil && p.Spec.ClusterPlatformRef.Name == clusterPlatform.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: p.Namespace, Name: p.Name}})
+		}
+	}
+	return requests
+}
+
+// recordPhaseEvent emits the Event matching the phase the platform has just transitioned to, if any
+func (r *KogitoServerlessPlatformReconciler) recordPhaseEvent(target *operatorapi.KogitoServerlessPlatform) {
+	switch target.Status.Phase {
+	case operatorapi.PlatformPhaseReady:
+		r.Recorder.Event(target, corev1.EventTypeNormal, api.PlatformReadyEventReason, "Platform ready")
+	case operatorapi.PlatformPhaseError:
+		r.Recorder.Event(target, corev1.EventTypeWarning, api.PlatformErrorEventReason, target.Status.GetTopLevelCondition().Message)
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *KogitoServerlessPlatformReconciler) SetupWithManager(mgr ctrlrun.Manager) error {
 	return ctrlrun.NewControllerManagedBy(mgr).
 		For(&operatorapi.KogitoServerlessPlatform{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&batchv1.CronJob{}).
+		Watches(&source.Kind{Type: &operatorapi.KogitoServerlessClusterPlatform{}}, handler.EnqueueRequestsFromMapFunc(r.platformsReferencing)).
 		Complete(r)
 }
@@ -16,14 +16,25 @@ package controllers
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/kiegroup/kogito-serverless-operator/api"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/registry"
+	buildertest "github.com/kiegroup/kogito-serverless-operator/container-builder/util/test"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
 	"github.com/kiegroup/kogito-serverless-operator/test"
 
 	"github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
@@ -61,5 +72,307 @@ func TestKogitoServerlessPlatformController(t *testing.T) {
 		assert.Equal(t, v1alpha08.PlatformClusterKubernetes, ksp.Status.Cluster)
 
 		assert.Equal(t, v1alpha08.PlatformPhaseCreating, ksp.Status.Phase)
+		assert.True(t, ksp.Status.GetCondition(api.BuilderConfigValidConditionType).IsTrue())
+		assert.True(t, ksp.Status.GetCondition(api.CacheWarmedConditionType).IsTrue())
+		assert.True(t, ksp.Status.GetCondition(api.SucceedConditionType).IsUnknown())
+	})
+	t.Run("verify that an invalid builder configuration fails the platform", func(t *testing.T) {
+		ksp := test.GetKogitoServerlessPlatform("../config/samples/sw.kogito_v1alpha08_kogitoserverlessplatform.yaml")
+		ksp.Spec.BuildPlatform.Timeout = &metav1.Duration{Duration: -time.Minute}
+
+		cl := test.NewKogitoClientBuilder().WithRuntimeObjects(ksp).Build()
+		r := &KogitoServerlessPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, &record.FakeRecorder{}}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksp.Name, Namespace: ksp.Namespace}}
+
+		_, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+
+		assert.Equal(t, v1alpha08.PlatformPhaseError, ksp.Status.Phase)
+		assert.True(t, ksp.Status.GetCondition(api.BuilderConfigValidConditionType).IsFalse())
+		assert.Equal(t, api.BuilderConfigInvalidReason, ksp.Status.GetCondition(api.BuilderConfigValidConditionType).Reason)
+		assert.True(t, ksp.Status.GetCondition(api.SucceedConditionType).IsFalse())
+	})
+	t.Run("verify that the registry is checked before the platform is ready", func(t *testing.T) {
+		standIn := &buildertest.RegistryStandIn{Username: "user", Password: "secret"}
+		server := standIn.Start(true)
+		defer server.Close()
+		address := strings.TrimPrefix(server.URL, "http://")
+
+		ksp := test.GetKogitoServerlessPlatform("../config/samples/sw.kogito_v1alpha08_kogitoserverlessplatform.yaml")
+		ksp.Spec.BuildPlatform.Registry.Address = address + "/kiegroup"
+		ksp.Spec.BuildPlatform.Registry.Insecure = true
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: ksp.Spec.BuildPlatform.Registry.Secret, Namespace: ksp.Namespace},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"` + address + `":{"username":"user","password":"wrong"}}}`)},
+		}
+
+		cl := test.NewKogitoClientBuilder().WithRuntimeObjects(ksp, secret).Build()
+		r := &KogitoServerlessPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, &record.FakeRecorder{}}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksp.Name, Namespace: ksp.Namespace}}
+
+		// initialize, then check the registry
+		for i := 0; i < 2; i++ {
+			_, err := r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.Equal(t, v1alpha08.PlatformPhaseError, ksp.Status.Phase)
+		assert.Equal(t, api.RegistryUnauthorizedReason, ksp.Status.GetCondition(api.RegistryReachableConditionType).Reason)
+
+		// fix the credentials, the registry is checked again and the platform recovers
+		secret.Data[corev1.DockerConfigJsonKey] = []byte(`{"auths":{"` + address + `":{"username":"user","password":"secret"}}}`)
+		assert.NoError(t, cl.Update(context.TODO(), secret))
+		for i := 0; i < 2; i++ {
+			_, err := r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.True(t, ksp.Status.GetCondition(api.RegistryReachableConditionType).IsTrue())
+		assert.Equal(t, v1alpha08.PlatformPhaseReady, ksp.Status.Phase)
+		assert.Equal(t, []string{"kiegroup/" + registry.PreflightImage}, standIn.Uploads())
+	})
+	t.Run("verify that the platform inherits from the cluster platform", func(t *testing.T) {
+		kscp := test.GetKogitoServerlessClusterPlatform("../config/samples/sw.kogito_v1alpha08_kogitoserverlessclusterplatform.yaml")
+		ksp := test.GetKogitoServerlessPlatform("../config/samples/sw.kogito_v1alpha08_kogitoserverlessplatform_withClusterPlatform.yaml")
+		ksp.Namespace = t.Name()
+		ksp.Spec.Configurations = []v1alpha08.ConfigurationSpec{{Type: v1alpha08.PropertyConfigurationSpec, Property: "quarkus.log.level=DEBUG"}}
+
+		cl := test.NewKogitoClientBuilder().WithRuntimeObjects(kscp, ksp).Build()
+		r := &KogitoServerlessPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, &record.FakeRecorder{}}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksp.Name, Namespace: ksp.Namespace}}
+
+		_, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+
+		// the spec is left untouched, the inherited fields are in the status
+		assert.Empty(t, ksp.Spec.BuildPlatform.Registry.Address)
+		assert.Nil(t, ksp.Spec.BuildPlatform.Timeout)
+		effective := ksp.Status.EffectiveSpec
+		assert.NotNil(t, effective)
+		assert.Equal(t, "quay.io/kiegroup", effective.BuildPlatform.Registry.Address)
+		assert.Equal(t, "regcred", effective.BuildPlatform.Registry.Secret)
+		assert.Equal(t, "my-team", effective.BuildPlatform.Registry.Organization)
+		assert.Equal(t, 10*time.Minute, effective.BuildPlatform.GetTimeout().Duration)
+		assert.Equal(t, []string{"quarkus.log.level=INFO", "quarkus.log.level=DEBUG"},
+			[]string{effective.Configurations[0].Property, effective.Configurations[1].Property})
+		assert.Equal(t, v1alpha08.PlatformPhaseCreating, ksp.Status.Phase)
+
+		active, err := platform.GetActivePlatform(context.TODO(), cl, ksp.Namespace)
+		assert.NoError(t, err)
+		assert.Equal(t, "quay.io/kiegroup", active.Spec.BuildPlatform.Registry.Address)
+
+		// the platform fails while the cluster platform is missing, and recovers once it's back
+		assert.NoError(t, cl.Delete(context.TODO(), kscp))
+		_, err = r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.Equal(t, v1alpha08.PlatformPhaseError, ksp.Status.Phase)
+		assert.Equal(t, api.ClusterPlatformNotFoundReason, ksp.Status.GetTopLevelCondition().Reason)
+
+		server := (&buildertest.RegistryStandIn{}).Start(true)
+		defer server.Close()
+		kscp.ResourceVersion = ""
+		kscp.Spec.BuildPlatform.Registry = v1alpha08.RegistrySpec{Address: strings.TrimPrefix(server.URL, "http://"), Insecure: true}
+		assert.NoError(t, cl.Create(context.TODO(), kscp))
+		_, err = r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.Equal(t, v1alpha08.PlatformPhaseCreating, ksp.Status.Phase)
+		assert.True(t, ksp.Status.GetCondition(api.RegistryReachableConditionType).IsTrue())
+		assert.Equal(t, kscp.Spec.BuildPlatform.Registry.Address, ksp.Status.EffectiveSpec.BuildPlatform.Registry.Address)
+	})
+	t.Run("verify that the platform is initialized again when its build platform changes", func(t *testing.T) {
+		standIn := &buildertest.RegistryStandIn{}
+		server := standIn.Start(true)
+		defer server.Close()
+
+		namespace := t.Name()
+		ksp := test.GetKogitoServerlessPlatform("../config/samples/sw.kogito_v1alpha08_kogitoserverlessplatform_withCache.yaml")
+		ksp.Namespace = namespace
+		ksp.Spec.BuildPlatform.Registry = v1alpha08.RegistrySpec{Address: strings.TrimPrefix(server.URL, "http://"), Insecure: true}
+		ksp.Spec.BuildPlatform.RebuildOnChange = true
+		workflow := test.GetKogitoServerlessWorkflow("../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, namespace)
+		workflow.Status.Manager().MarkTrue(api.BuiltConditionType)
+		build := test.GetNewEmptyKogitoServerlessBuild(workflow.Name, namespace)
+		build.Status.BuildPhase = v1alpha08.BuildPhaseSucceeded
+
+		cl := test.NewKogitoClientBuilder().WithRuntimeObjects(ksp, workflow, build).Build()
+		r := &KogitoServerlessPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, &record.FakeRecorder{}}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksp.Name, Namespace: ksp.Namespace}}
+		warmer := &corev1.Pod{}
+		warmerKey := types.NamespacedName{Name: ksp.Name + "-cache", Namespace: namespace}
+
+		// initialize, warm the cache, check the registry and create
+		_, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), warmerKey, warmer))
+		warmer.Status.Phase = corev1.PodSucceeded
+		assert.NoError(t, cl.Status().Update(context.TODO(), warmer))
+		for i := 0; i < 3; i++ {
+			_, err = r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.Equal(t, v1alpha08.PlatformPhaseReady, ksp.Status.Phase)
+		assert.Equal(t, ksp.Spec.BuildPlatform.BaseImage, ksp.Status.AppliedBuildPlatform.BaseImage)
+
+		// a new base image warms the cache again and rebuilds the workflows
+		ksp.Spec.BuildPlatform.BaseImage = "quay.io/kiegroup/kogito-swf-builder:custom"
+		assert.NoError(t, cl.Update(context.TODO(), ksp))
+		_, err = r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.Equal(t, v1alpha08.PlatformPhaseWarming, ksp.Status.Phase)
+		assert.Equal(t, "quay.io/kiegroup/kogito-swf-builder:custom", ksp.Status.AppliedBuildPlatform.BaseImage)
+		assert.True(t, ksp.Status.GetCondition(api.RegistryReachableConditionType).IsUnknown())
+		assert.NoError(t, cl.Get(context.TODO(), warmerKey, warmer))
+		assert.Contains(t, warmer.Spec.InitContainers[1].Args, "--image=quay.io/kiegroup/kogito-swf-builder:custom")
+
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: build.Name, Namespace: namespace}, build))
+		assert.Equal(t, v1alpha08.BuildPhaseNone, build.Status.BuildPhase)
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: workflow.Name, Namespace: namespace}, workflow))
+		assert.True(t, workflow.Status.IsBuildRunningOrUnknown())
+	})
+	t.Run("verify that a platform initialized by a former operator version isn't initialized again", func(t *testing.T) {
+		namespace := t.Name()
+		ksp := test.GetKogitoServerlessPlatformInReadyPhase("../config/samples/sw.kogito_v1alpha08_kogitoserverlessplatform.yaml", namespace)
+		ksp.Status.AppliedBuildPlatform = nil
+
+		cl := test.NewKogitoClientBuilder().WithRuntimeObjects(ksp).Build()
+		r := &KogitoServerlessPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, &record.FakeRecorder{}}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksp.Name, Namespace: ksp.Namespace}}
+
+		for i := 0; i < 2; i++ {
+			_, err := r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+			assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+			assert.Equal(t, v1alpha08.PlatformPhaseReady, ksp.Status.Phase)
+		}
+		assert.Equal(t, ksp.Spec.BuildPlatform, *ksp.Status.AppliedBuildPlatform)
+	})
+	t.Run("verify that the Kaniko cache is refreshed, reported and cleaned up", func(t *testing.T) {
+		standIn := &buildertest.RegistryStandIn{}
+		server := standIn.Start(true)
+		defer server.Close()
+
+		namespace := t.Name()
+		ksp := test.GetKogitoServerlessPlatform("../config/samples/sw.kogito_v1alpha08_kogitoserverlessplatform_withCache.yaml")
+		ksp.Namespace = namespace
+		ksp.Spec.BuildPlatform.Registry = v1alpha08.RegistrySpec{Address: strings.TrimPrefix(server.URL, "http://"), Insecure: true}
+		ksp.Spec.BuildPlatform.BuildStrategyOptions["KanikoCacheRefreshSchedule"] = "0 3 * * *"
+
+		cl := test.NewKogitoClientBuilder().WithRuntimeObjects(ksp).Build()
+		r := &KogitoServerlessPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, &record.FakeRecorder{}}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksp.Name, Namespace: ksp.Namespace}}
+		warmerKey := types.NamespacedName{Name: ksp.Name + "-cache", Namespace: namespace}
+		refresherKey := types.NamespacedName{Name: ksp.Name + "-cache-refresh", Namespace: namespace}
+		pvcKey := types.NamespacedName{Name: ksp.Name, Namespace: namespace}
+
+		_, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		warmer := &corev1.Pod{}
+		assert.NoError(t, cl.Get(context.TODO(), warmerKey, warmer))
+		assert.True(t, metav1.IsControlledBy(warmer, ksp))
+		pvc := &corev1.PersistentVolumeClaim{}
+		assert.NoError(t, cl.Get(context.TODO(), pvcKey, pvc))
+		assert.True(t, metav1.IsControlledBy(pvc, ksp))
+		refresher := &batchv1.CronJob{}
+		assert.NoError(t, cl.Get(context.TODO(), refresherKey, refresher))
+		assert.True(t, metav1.IsControlledBy(refresher, ksp))
+		assert.Equal(t, "0 3 * * *", refresher.Spec.Schedule)
+		assert.Equal(t, ksp.Name, refresher.Spec.JobTemplate.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+
+		// the warmer reports the size of the cache once done
+		warmer.Status.Phase = corev1.PodSucceeded
+		warmer.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "report-kaniko-cache-size",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "2048\n", FinishedAt: metav1.Now()}},
+		}}
+		assert.NoError(t, cl.Status().Update(context.TODO(), warmer))
+		_, err = r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.True(t, ksp.Status.GetCondition(api.CacheWarmedConditionType).IsTrue())
+		assert.Equal(t, ksp.Name, ksp.Status.KanikoCache.PersistentVolumeClaim)
+		assert.Equal(t, "2Mi", ksp.Status.KanikoCache.Size.String())
+		assert.NotNil(t, ksp.Status.KanikoCache.LastWarmTime)
+
+		// disabling the cache of the ready platform removes its artefacts
+		for i := 0; i < 2; i++ {
+			_, err = r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.Equal(t, v1alpha08.PlatformPhaseReady, ksp.Status.Phase)
+		ksp.Spec.BuildPlatform.BuildStrategyOptions["KanikoBuildCacheEnabled"] = "false"
+		assert.NoError(t, cl.Update(context.TODO(), ksp))
+		for i := 0; i < 3; i++ {
+			_, err = r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.Equal(t, v1alpha08.PlatformPhaseReady, ksp.Status.Phase)
+		assert.Nil(t, ksp.Status.KanikoCache)
+		assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), warmerKey, &corev1.Pod{})))
+		assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), refresherKey, &batchv1.CronJob{})))
+		assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), pvcKey, &corev1.PersistentVolumeClaim{})))
+	})
+	t.Run("verify that the platform services are deployed and monitored", func(t *testing.T) {
+		namespace := t.Name()
+		ksp := test.GetKogitoServerlessPlatformInReadyPhase("../config/samples/sw.kogito_v1alpha08_kogitoserverlessplatform.yaml", namespace)
+		ksp.Spec.Services = &v1alpha08.PlatformServicesSpec{
+			DataIndex: &v1alpha08.PlatformServiceSpec{
+				Persistence: &v1alpha08.PersistenceSpec{
+					PostgreSQL: &v1alpha08.PostgreSQLPersistenceSpec{
+						SecretRef:  v1alpha08.PostgreSQLSecretReference{Name: "postgresql"},
+						ServiceRef: &v1alpha08.PostgreSQLServiceReference{Name: "postgresql"},
+					},
+				},
+			},
+			JobService: &v1alpha08.PlatformServiceSpec{Enabled: &[]bool{false}[0]},
+		}
+
+		cl := test.NewKogitoClientBuilder().WithRuntimeObjects(ksp).Build()
+		r := &KogitoServerlessPlatformReconciler{cl, cl, cl.Scheme(), &rest.Config{}, &record.FakeRecorder{}}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: ksp.Name, Namespace: ksp.Namespace}}
+
+		_, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: ksp.Name + "-data-index-service", Namespace: namespace}, deployment))
+		container := deployment.Spec.Template.Spec.Containers[0]
+		assert.Contains(t, container.Image, "kogito-data-index-postgresql")
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "QUARKUS_DATASOURCE_JDBC_URL", Value: "jdbc:postgresql://postgresql." + namespace + ":5432/kogito?currentSchema=data-index-service"})
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: ksp.Name + "-data-index-service", Namespace: namespace}, &corev1.Service{}))
+		assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), types.NamespacedName{Name: ksp.Name + "-jobs-service", Namespace: namespace}, &appsv1.Deployment{})))
+
+		assert.NotNil(t, ksp.Status.Services)
+		assert.Nil(t, ksp.Status.Services.JobService)
+		assert.Equal(t, "http://"+ksp.Name+"-data-index-service."+namespace, ksp.Status.Services.DataIndex.URL)
+		assert.False(t, ksp.Status.Services.DataIndex.Ready)
+
+		deployment.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue}}
+		assert.NoError(t, cl.Status().Update(context.TODO(), deployment))
+		_, err = r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), req.NamespacedName, ksp))
+		assert.True(t, ksp.Status.Services.DataIndex.Ready)
+		assert.Empty(t, ksp.Status.Services.DataIndex.Message)
+
+		// the services deleted or edited by hand are restored
+		deployment.Spec.Template.Spec.Containers[0].Image = "quay.io/example/data-index:edited"
+		assert.NoError(t, cl.Update(context.TODO(), deployment))
+		assert.NoError(t, cl.Delete(context.TODO(), &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: ksp.Name + "-data-index-service", Namespace: namespace}}))
+		_, err = r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: ksp.Name + "-data-index-service", Namespace: namespace}, deployment))
+		assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Image, "kogito-data-index-postgresql")
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: ksp.Name + "-data-index-service", Namespace: namespace}, &corev1.Service{}))
 	})
 }
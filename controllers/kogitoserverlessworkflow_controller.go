@@ -21,10 +21,13 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/client-go/rest"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -33,6 +36,7 @@ import (
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kiegroup/kogito-serverless-operator/api"
 
@@ -92,7 +96,7 @@ func (r *KogitoServerlessWorkflowReconciler) Reconcile(ctx context.Context, req
 		return reconcile.Result{}, nil
 	}
 
-	return profiles.NewReconciler(r.Client, r.Config, &logger, workflow).Reconcile(ctx, workflow)
+	return profiles.NewReconciler(r.Client, r.Config, r.Recorder, &logger, workflow).Reconcile(ctx, workflow)
 }
 
 func platformEnqueueRequestsFromMapFunc(c client.Client, p *operatorapi.KogitoServerlessPlatform) []reconcile.Request {
@@ -116,25 +120,91 @@ func platformEnqueueRequestsFromMapFunc(c client.Client, p *operatorapi.KogitoSe
 			cond := workflow.Status.GetTopLevelCondition()
 			if cond.IsFalse() && api.WaitingForPlatformReason == cond.Reason {
 				log.Infof("Platform %s ready, wake-up workflow: %s", p.Name, workflow.Name)
-				requests = append(requests, reconcile.Request{
-					NamespacedName: types.NamespacedName{
-						Namespace: workflow.Namespace,
-						Name:      workflow.Name,
-					},
-				})
+			} else if workflow.Namespace != p.Namespace {
+				continue
 			}
+			// workflows in the platform namespace must be reconciled to pick up the platform configuration changes
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: workflow.Namespace,
+					Name:      workflow.Name,
+				},
+			})
 		}
 	}
 	return requests
 }
 
+// secretEnqueueRequestsFromMapFunc enqueues the workflows referencing the given Secret, so they're rolled out or their Route certificates refreshed when the Secret changes
+func secretEnqueueRequestsFromMapFunc(c client.Client, secret *corev1.Secret) []reconcile.Request {
+	var requests []reconcile.Request
+
+	list := &operatorapi.KogitoServerlessWorkflowList{}
+	if err := c.List(context.Background(), list, client.InNamespace(secret.Namespace)); err != nil {
+		log.Error(err, "Failed to list workflows")
+		return requests
+	}
+	referencedByPlatform := isConfigurationReferencedByPlatforms(c, secret.Namespace, operatorapi.SecretConfigurationSpec, secret.Name)
+	for i := range list.Items {
+		if referencedByPlatform || profiles.IsSecretReferencedByWorkflow(&list.Items[i], secret.Name) ||
+			profiles.IsSecretReferencedByRouteTLS(context.Background(), c, &list.Items[i], secret.Name) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: list.Items[i].Namespace,
+					Name:      list.Items[i].Name,
+				},
+			})
+		}
+	}
+	return requests
+}
+
+// configMapEnqueueRequestsFromMapFunc enqueues the workflows of the namespace when the given ConfigMap is part of the platform configuration
+func configMapEnqueueRequestsFromMapFunc(c client.Client, cm *corev1.ConfigMap) []reconcile.Request {
+	var requests []reconcile.Request
+
+	if !isConfigurationReferencedByPlatforms(c, cm.Namespace, operatorapi.ConfigMapConfigurationSpec, cm.Name) {
+		return requests
+	}
+	list := &operatorapi.KogitoServerlessWorkflowList{}
+	if err := c.List(context.Background(), list, client.InNamespace(cm.Namespace)); err != nil {
+		log.Error(err, "Failed to list workflows")
+		return requests
+	}
+	for _, workflow := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: workflow.Namespace,
+				Name:      workflow.Name,
+			},
+		})
+	}
+	return requests
+}
+
+// isConfigurationReferencedByPlatforms verifies whether a platform of the namespace references the given ConfigMap or Secret in its configuration
+func isConfigurationReferencedByPlatforms(c client.Client, namespace string, specType operatorapi.ConfigurationSpecType, name string) bool {
+	platforms, err := platform.ListAllPlatforms(context.Background(), c, namespace)
+	if err != nil {
+		log.Error(err, "Failed to list platforms")
+		return false
+	}
+	for i := range platforms.Items {
+		if platform.IsConfigurationReferencedByPlatform(&platforms.Items[i], specType, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *KogitoServerlessWorkflowReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&operatorapi.KogitoServerlessWorkflow{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&networkingv1.Ingress{}).
 		Owns(&operatorapi.KogitoServerlessBuild{}).
 		Watches(&source.Kind{Type: &operatorapi.KogitoServerlessPlatform{}}, handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
 			platform, ok := a.(*operatorapi.KogitoServerlessPlatform)
@@ -144,7 +214,36 @@ func (r *KogitoServerlessWorkflowReconciler) SetupWithManager(mgr ctrl.Manager)
 			}
 			return platformEnqueueRequestsFromMapFunc(mgr.GetClient(), platform)
 		})).
-		Complete(r)
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+			secret, ok := a.(*corev1.Secret)
+			if !ok {
+				log.Error(fmt.Errorf("type assertion failed: %v", a), "Failed to retrieve workflow list")
+				return []reconcile.Request{}
+			}
+			return secretEnqueueRequestsFromMapFunc(mgr.GetClient(), secret)
+		})).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+			cm, ok := a.(*corev1.ConfigMap)
+			if !ok {
+				log.Error(fmt.Errorf("type assertion failed: %v", a), "Failed to retrieve workflow list")
+				return []reconcile.Request{}
+			}
+			return configMapEnqueueRequestsFromMapFunc(mgr.GetClient(), cm)
+		}))
+	// the Gateway API is optional, the HTTPRoutes are only watched when it's installed in the cluster
+	if isHTTPRouteAvailable(mgr.GetRESTMapper()) {
+		builder = builder.Owns(&gwapi.HTTPRoute{})
+	}
+	return builder.Complete(r)
+}
+
+// isHTTPRouteAvailable checks if the Gateway API HTTPRoute is served by the cluster
+func isHTTPRouteAvailable(mapper meta.RESTMapper) bool {
+	_, err := mapper.RESTMapping(schema.GroupKind{Group: gwapi.GroupName, Kind: "HTTPRoute"}, gwapi.GroupVersion.Version)
+	if err != nil && !meta.IsNoMatchError(err) {
+		log.Error(err, "Failed to check if the Gateway API is installed, HTTPRoutes aren't watched")
+	}
+	return err == nil
 }
 
 // sameOrMatch return true if the build it is related to the workflow, false otherwise
@@ -19,9 +19,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kiegroup/kogito-serverless-operator/api"
 
@@ -44,7 +47,7 @@ func TestKogitoServerlessWorkflowController(t *testing.T) {
 		// Create a fake client to mock API calls.
 		cl := test.NewKogitoClientBuilder().WithRuntimeObjects(objs...).Build()
 		// Create a KogitoServerlessWorkflowReconciler object with the scheme and fake client.
-		r := &KogitoServerlessWorkflowReconciler{Client: cl, Scheme: cl.Scheme()}
+		r := &KogitoServerlessWorkflowReconciler{Client: cl, Scheme: cl.Scheme(), Recorder: &record.FakeRecorder{}}
 
 		// Mock request to simulate Reconcile() being called on an event for a
 		// watched resource .
@@ -75,3 +78,11 @@ func TestKogitoServerlessWorkflowController(t *testing.T) {
 		assert.True(t, len(ksw.Spec.Flow.States) == 4)
 	})
 }
+
+func TestIsHTTPRouteAvailable(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	assert.False(t, isHTTPRouteAvailable(mapper))
+
+	mapper.Add(gwapi.SchemeGroupVersion.WithKind("HTTPRoute"), meta.RESTScopeNamespace)
+	assert.True(t, isHTTPRouteAvailable(mapper))
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides the Prometheus metrics of the operator, exposed with the controller-runtime ones.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+const (
+	metricsNamespace = "kogito_serverless"
+	// collectTimeout is the maximum time spent reading the operator objects on every scrape
+	collectTimeout = 10 * time.Second
+
+	namespaceLabel = "namespace"
+	strategyLabel  = "strategy"
+	phaseLabel     = "phase"
+	platformLabel  = "platform"
+	typeLabel      = "type"
+	statusLabel    = "status"
+	reasonLabel    = "reason"
+)
+
+var (
+	buildDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "build",
+		Name:      "duration_seconds",
+		Help:      "Duration of the workflow builds by build strategy and final phase.",
+		Buckets:   []float64{30, 60, 120, 180, 300, 600, 900, 1200, 1800, 3600},
+	}, []string{strategyLabel, phaseLabel})
+
+	recoveryAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "workflow",
+		Name:      "recovery_attempts_total",
+		Help:      "Attempts to recover dev mode workflow deployments from failures.",
+	}, []string{namespaceLabel})
+
+	buildQueueDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "build", "queue_depth"),
+		"Workflow builds waiting to run.",
+		[]string{namespaceLabel}, nil)
+
+	workflowConditionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "workflow", "conditions"),
+		"Workflows by condition type, status and reason.",
+		[]string{namespaceLabel, typeLabel, statusLabel, reasonLabel}, nil)
+
+	platformPhaseDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "platform", "phase"),
+		"Current phase of the platforms, the value is always 1.",
+		[]string{namespaceLabel, platformLabel, phaseLabel}, nil)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(buildDuration, recoveryAttempts)
+}
+
+// RegisterCollector registers the metrics describing the current state of the operator objects, read on every scrape
+// with the given client. Use the manager client so the objects are read from its cache.
+func RegisterCollector(reader client.Reader) error {
+	return ctrlmetrics.Registry.Register(&stateCollector{reader: reader})
+}
+
+// ObserveBuild records the duration of a build that reached the given final phase
+func ObserveBuild(strategy operatorapi.BuildStrategy, phase operatorapi.BuildPhase, duration time.Duration) {
+	buildDuration.WithLabelValues(string(strategy), string(phase)).Observe(duration.Seconds())
+}
+
+// IncRecoveryAttempts records an attempt to recover a workflow in the given namespace from a failure.
+// Only the dev profile recovers the workflow deployments, the prod profile reports their failures instead.
+func IncRecoveryAttempts(namespace string) {
+	recoveryAttempts.WithLabelValues(namespace).Inc()
+}
+
+// IsBuildQueued checks if the build is waiting to run
+func IsBuildQueued(phase operatorapi.BuildPhase) bool {
+	switch phase {
+	case operatorapi.BuildPhaseNone, operatorapi.BuildPhaseInitialization, operatorapi.BuildPhaseScheduling, operatorapi.BuildPhasePending:
+		return true
+	}
+	return false
+}
+
+// stateCollector is a prometheus.Collector reading the builds, workflows and platforms on every scrape,
+// so deleted objects don't leave stale series behind.
+type stateCollector struct {
+	reader client.Reader
+}
+
+func (c *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- buildQueueDepthDesc
+	ch <- workflowConditionsDesc
+	ch <- platformPhaseDesc
+}
+
+func (c *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+	c.collectBuilds(ctx, ch)
+	c.collectWorkflows(ctx, ch)
+	c.collectPlatforms(ctx, ch)
+}
+
+func (c *stateCollector) collectBuilds(ctx context.Context, ch chan<- prometheus.Metric) {
+	builds := &operatorapi.KogitoServerlessBuildList{}
+	if err := c.reader.List(ctx, builds); err != nil {
+		ch <- prometheus.NewInvalidMetric(buildQueueDepthDesc, err)
+		return
+	}
+	queued := map[string]int{}
+	for _, build := range builds.Items {
+		if IsBuildQueued(build.Status.BuildPhase) {
+			queued[build.Namespace]++
+		}
+	}
+	for namespace, count := range queued {
+		ch <- prometheus.MustNewConstMetric(buildQueueDepthDesc, prometheus.GaugeValue, float64(count), namespace)
+	}
+}
+
+func (c *stateCollector) collectWorkflows(ctx context.Context, ch chan<- prometheus.Metric) {
+	workflows := &operatorapi.KogitoServerlessWorkflowList{}
+	if err := c.reader.List(ctx, workflows); err != nil {
+		ch <- prometheus.NewInvalidMetric(workflowConditionsDesc, err)
+		return
+	}
+	type conditionKey struct {
+		namespace, conditionType, status, reason string
+	}
+	counts := map[conditionKey]int{}
+	for _, workflow := range workflows.Items {
+		for _, condition := range workflow.Status.Conditions {
+			counts[conditionKey{workflow.Namespace, string(condition.Type), string(condition.Status), condition.Reason}]++
+		}
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(workflowConditionsDesc, prometheus.GaugeValue, float64(count), key.namespace, key.conditionType, key.status, key.reason)
+	}
+}
+
+func (c *stateCollector) collectPlatforms(ctx context.Context, ch chan<- prometheus.Metric) {
+	platforms := &operatorapi.KogitoServerlessPlatformList{}
+	if err := c.reader.List(ctx, platforms); err != nil {
+		ch <- prometheus.NewInvalidMetric(platformPhaseDesc, err)
+		return
+	}
+	for _, platform := range platforms.Items {
+		ch <- prometheus.MustNewConstMetric(platformPhaseDesc, prometheus.GaugeValue, 1, platform.Namespace, platform.Name, string(platform.Status.Phase))
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiegroup/kogito-serverless-operator/api"
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/test"
+)
+
+func Test_stateCollector(t *testing.T) {
+	newBuild := func(name string, phase operatorapi.BuildPhase) *operatorapi.KogitoServerlessBuild {
+		build := test.GetNewEmptyKogitoServerlessBuild(name, t.Name())
+		build.Status.BuildPhase = phase
+		return build
+	}
+	workflow := &operatorapi.KogitoServerlessWorkflow{ObjectMeta: metav1.ObjectMeta{Name: "greeting", Namespace: t.Name()}}
+	workflow.Status.Conditions = api.Conditions{
+		{Type: api.RunningConditionType, Status: corev1.ConditionFalse, Reason: api.WaitingForDeploymentReason},
+		{Type: api.BuiltConditionType, Status: corev1.ConditionTrue},
+	}
+	platform := operatorapi.NewKogitoServerlessPlatform(t.Name(), "kogito-platform")
+	platform.Status.Phase = operatorapi.PlatformPhaseReady
+	cli := test.NewKogitoClientBuilder().WithRuntimeObjects(
+		newBuild("pending", operatorapi.BuildPhasePending),
+		newBuild("scheduling", operatorapi.BuildPhaseScheduling),
+		newBuild("running", operatorapi.BuildPhaseRunning),
+		newBuild("succeeded", operatorapi.BuildPhaseSucceeded),
+		workflow, &platform).Build()
+
+	expected := `
+# HELP kogito_serverless_build_queue_depth Workflow builds waiting to run.
+# TYPE kogito_serverless_build_queue_depth gauge
+kogito_serverless_build_queue_depth{namespace="Test_stateCollector"} 2
+# HELP kogito_serverless_platform_phase Current phase of the platforms, the value is always 1.
+# TYPE kogito_serverless_platform_phase gauge
+kogito_serverless_platform_phase{namespace="Test_stateCollector",phase="Ready",platform="kogito-platform"} 1
+# HELP kogito_serverless_workflow_conditions Workflows by condition type, status and reason.
+# TYPE kogito_serverless_workflow_conditions gauge
+kogito_serverless_workflow_conditions{namespace="Test_stateCollector",reason="",status="True",type="Built"} 1
+kogito_serverless_workflow_conditions{namespace="Test_stateCollector",reason="WaitingForDeployment",status="False",type="Running"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(&stateCollector{reader: cli}, strings.NewReader(expected)))
+}
+
+func Test_ObserveBuild(t *testing.T) {
+	ObserveBuild(operatorapi.OperatorBuildStrategy, operatorapi.BuildPhaseSucceeded, 2*time.Minute)
+	ObserveBuild(operatorapi.OperatorBuildStrategy, operatorapi.BuildPhaseFailed, time.Minute)
+	assert.Equal(t, 2, testutil.CollectAndCount(buildDuration, "kogito_serverless_build_duration_seconds"))
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/imdario/mergo"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/client"
+)
+
+// GetClusterPlatform returns the KogitoServerlessClusterPlatform with the given name.
+func GetClusterPlatform(ctx context.Context, c ctrl.Reader, name string) (*operatorapi.KogitoServerlessClusterPlatform, error) {
+	clusterPlatform := &operatorapi.KogitoServerlessClusterPlatform{}
+	if err := c.Get(ctx, ctrl.ObjectKey{Name: name}, clusterPlatform); err != nil {
+		return nil, err
+	}
+	return clusterPlatform, nil
+}
+
+// InheritClusterPlatform merges the spec of the cluster platform referenced by the given platform, if any, into its spec.
+// Since the merged spec isn't persisted, the defaults are applied again once the platform is initialized.
+func InheritClusterPlatform(ctx context.Context, c client.Client, p *operatorapi.KogitoServerlessPlatform) error {
+	if p.Spec.ClusterPlatformRef == nil {
+		return nil
+	}
+	clusterPlatform, err := GetClusterPlatform(ctx, c, p.Spec.ClusterPlatformRef.Name)
+	if err != nil {
+		return err
+	}
+	spec, err := MergePlatformSpec(&clusterPlatform.Spec, &p.Spec)
+	if err != nil {
+		return err
+	}
+	p.Spec = *spec
+	if p.Status.Cluster != "" {
+		return ConfigureDefaults(ctx, c, p, false)
+	}
+	return nil
+}
+
+// MergePlatformSpec returns the spec of a platform inheriting from a cluster platform.
+// The fields set in the platform take precedence, the configuration entries of the cluster platform come first so that the platform ones override them.
+// Since empty fields are inherited, a platform can't unset a field or set a boolean to false when the cluster platform sets it.
+func MergePlatformSpec(cluster, namespaced *operatorapi.KogitoServerlessPlatformSpec) (*operatorapi.KogitoServerlessPlatformSpec, error) {
+	merged := namespaced.DeepCopy()
+	inherited := cluster.DeepCopy()
+	configurations := append(inherited.GetConfigurations(), merged.GetConfigurations()...)
+	inherited.ClusterPlatformRef = nil
+	inherited.Configuration = nil
+	inherited.Configurations = nil
+	merged.Configuration = nil
+	if err := mergo.Merge(merged, inherited, mergo.WithTransformers(setPointersTransformer{})); err != nil {
+		return nil, err
+	}
+	merged.Configurations = configurations
+	return merged, nil
+}
+
+// setPointersTransformer keeps the pointers to scalars set in the platform, like `enabled: false`, instead of inheriting when they point to a zero value
+type setPointersTransformer struct{}
+
+func (setPointersTransformer) Transformer(t reflect.Type) func(dst, src reflect.Value) error {
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() == reflect.Struct {
+		return nil
+	}
+	// only called when the platform pointer is set, the nil ones are inherited by mergo
+	return func(dst, src reflect.Value) error {
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+func TestMergePlatformSpec(t *testing.T) {
+	enabled, disabled := true, false
+	cluster := &operatorapi.KogitoServerlessPlatformSpec{
+		ClusterPlatformRef: &operatorapi.ClusterPlatformReference{Name: "ignored"},
+		BuildPlatform: operatorapi.BuildPlatformTemplate{
+			BaseImage:            "quay.io/kiegroup/builder",
+			Timeout:              &metav1.Duration{Duration: time.Minute},
+			BuildStrategyOptions: map[string]string{"KanikoBuildCacheEnabled": "true", "KanikoPersistentVolumeClaim": "cache"},
+			Registry:             operatorapi.RegistrySpec{Address: "quay.io/kiegroup", Secret: "regcred"},
+		},
+		Configuration:  &operatorapi.ConfigurationSpec{Type: operatorapi.PropertyConfigurationSpec, Property: "a=deprecated"},
+		Configurations: []operatorapi.ConfigurationSpec{{Type: operatorapi.PropertyConfigurationSpec, Property: "a=cluster"}},
+		Services:       &operatorapi.PlatformServicesSpec{DataIndex: &operatorapi.PlatformServiceSpec{Enabled: &enabled, Image: "data-index"}},
+	}
+	namespaced := &operatorapi.KogitoServerlessPlatformSpec{
+		ClusterPlatformRef: &operatorapi.ClusterPlatformReference{Name: "cluster"},
+		BuildPlatform: operatorapi.BuildPlatformTemplate{
+			Timeout:              &metav1.Duration{Duration: 2 * time.Minute},
+			BuildStrategyOptions: map[string]string{"KanikoBuildCacheEnabled": "false"},
+			Registry:             operatorapi.RegistrySpec{Organization: "team"},
+		},
+		Configurations: []operatorapi.ConfigurationSpec{{Type: operatorapi.PropertyConfigurationSpec, Property: "a=namespace"}},
+		Services:       &operatorapi.PlatformServicesSpec{DataIndex: &operatorapi.PlatformServiceSpec{Enabled: &disabled}},
+	}
+
+	merged, err := MergePlatformSpec(cluster, namespaced)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "cluster", merged.ClusterPlatformRef.Name)
+	assert.Equal(t, "quay.io/kiegroup/builder", merged.BuildPlatform.BaseImage)
+	assert.Equal(t, 2*time.Minute, merged.BuildPlatform.Timeout.Duration)
+	assert.Equal(t, map[string]string{"KanikoBuildCacheEnabled": "false", "KanikoPersistentVolumeClaim": "cache"}, merged.BuildPlatform.BuildStrategyOptions)
+	assert.Equal(t, operatorapi.RegistrySpec{Address: "quay.io/kiegroup", Secret: "regcred", Organization: "team"}, merged.BuildPlatform.Registry)
+	// the deprecated configuration entry is applied first
+	assert.Equal(t, []operatorapi.ConfigurationSpec{*cluster.Configuration, cluster.Configurations[0], namespaced.Configurations[0]}, merged.Configurations)
+	assert.Nil(t, merged.Configuration)
+	assert.False(t, *merged.Services.DataIndex.Enabled)
+	assert.Equal(t, "data-index", merged.Services.DataIndex.Image)
+
+	// the inputs are left untouched
+	assert.Equal(t, 2*time.Minute, namespaced.BuildPlatform.Timeout.Duration)
+	assert.Empty(t, namespaced.BuildPlatform.Registry.Address)
+	assert.Len(t, cluster.Configurations, 1)
+}
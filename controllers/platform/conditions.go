@@ -0,0 +1,58 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"github.com/kiegroup/kogito-serverless-operator/api"
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+// validateBuilderConfig checks that the platform build configuration, with the defaults applied, can be used to build workflows.
+// The result is tracked by the BuilderConfigValid condition.
+func validateBuilderConfig(platform *operatorapi.KogitoServerlessPlatform) bool {
+	buildPlatform := platform.Spec.BuildPlatform
+	switch {
+	case buildPlatform.BuildStrategy != operatorapi.OperatorBuildStrategy && buildPlatform.BuildStrategy != operatorapi.PlatformBuildStrategy:
+		platform.Status.Manager().MarkFalse(api.BuilderConfigValidConditionType, api.BuilderConfigInvalidReason,
+			"Build strategy %q not supported, use either %q or %q", buildPlatform.BuildStrategy, operatorapi.OperatorBuildStrategy, operatorapi.PlatformBuildStrategy)
+	case buildPlatform.BuildStrategy == operatorapi.PlatformBuildStrategy && platform.Status.Cluster != operatorapi.PlatformClusterOpenShift:
+		platform.Status.Manager().MarkFalse(api.BuilderConfigValidConditionType, api.BuilderConfigInvalidReason,
+			"Build strategy %q is only supported on OpenShift", buildPlatform.BuildStrategy)
+	case buildPlatform.GetTimeout().Duration <= 0:
+		platform.Status.Manager().MarkFalse(api.BuilderConfigValidConditionType, api.BuilderConfigInvalidReason,
+			"Build timeout must be positive, got %s", buildPlatform.GetTimeout().Duration)
+	default:
+		platform.Status.Manager().MarkTrue(api.BuilderConfigValidConditionType)
+		return true
+	}
+	return false
+}
+
+// dependentConditions the conditions that must be true for the platform to succeed
+var dependentConditions = []api.ConditionType{
+	api.BuilderConfigValidConditionType,
+	api.CacheWarmedConditionType,
+	api.RegistryReachableConditionType,
+}
+
+// markSucceedIfReady sets the Succeed condition once every dependent condition is true
+func markSucceedIfReady(platform *operatorapi.KogitoServerlessPlatform) {
+	for _, t := range dependentConditions {
+		if !platform.Status.GetCondition(t).IsTrue() {
+			return
+		}
+	}
+	platform.Status.Manager().MarkTrue(api.SucceedConditionType)
+}
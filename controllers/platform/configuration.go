@@ -0,0 +1,164 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/magiconair/properties"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/utils"
+)
+
+// applicationPropertiesKey is the ConfigMap key holding the properties in the application.properties format
+const applicationPropertiesKey = "application.properties"
+
+// Configuration is the resolved KogitoServerlessPlatformSpec configuration entries to be applied to the workflows
+type Configuration struct {
+	// Properties to be merged into the workflow application properties
+	Properties *properties.Properties
+	// EnvFrom sources to be injected into the workflow application container
+	EnvFrom []corev1.EnvFromSource
+	// secretsChecksum maps the Secrets referenced by EnvFrom to the checksum of their data, the pods only read them at startup
+	secretsChecksum map[string][]byte
+}
+
+// Checksum of the configuration, changes to it must roll out the workflow deployments
+func (c *Configuration) Checksum() string {
+	if c.Properties.Len() == 0 && len(c.EnvFrom) == 0 {
+		return ""
+	}
+	hash := sha256.New()
+	hash.Write([]byte(c.Properties.String()))
+	for _, env := range c.EnvFrom {
+		hash.Write([]byte(env.SecretRef.Name))
+		hash.Write(c.secretsChecksum[env.SecretRef.Name])
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+// GetConfiguration resolves the configuration of the given platform.
+// The platform can be nil, in this case an empty configuration is returned.
+// The properties wiring the workflows to the platform services come first, then the entries are applied in order, so the latter overrides the former.
+func GetConfiguration(ctx context.Context, c ctrl.Reader, platform *operatorapi.KogitoServerlessPlatform) (*Configuration, error) {
+	config := &Configuration{Properties: utils.NewProperties(), secretsChecksum: map[string][]byte{}}
+	if platform == nil {
+		return config, nil
+	}
+	config.Properties.Merge(getServicesProperties(platform))
+	for _, spec := range platform.Spec.GetConfigurations() {
+		switch spec.Type {
+		case operatorapi.PropertyConfigurationSpec:
+			key, value, found := strings.Cut(spec.Property, "=")
+			if !found || len(strings.TrimSpace(key)) == 0 {
+				return nil, fmt.Errorf("platform %s has an invalid configuration property %q, expected key=value", platform.Name, spec.Property)
+			}
+			if _, _, err := config.Properties.Set(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+				return nil, err
+			}
+		case operatorapi.ConfigMapConfigurationSpec:
+			props, err := getConfigMapProperties(ctx, c, platform.Namespace, spec.Value.Name)
+			if err != nil {
+				return nil, err
+			}
+			config.Properties.Merge(props)
+		case operatorapi.SecretConfigurationSpec:
+			data, err := getSecretData(ctx, c, platform.Namespace, spec.Value.Name)
+			if err != nil {
+				return nil, err
+			}
+			config.secretsChecksum[spec.Value.Name] = secretDataChecksum(data)
+			config.EnvFrom = append(config.EnvFrom, corev1.EnvFromSource{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: spec.Value.Name}},
+			})
+		default:
+			return nil, fmt.Errorf("platform %s has an unsupported configuration type %q", platform.Name, spec.Type)
+		}
+	}
+	return config, nil
+}
+
+// IsConfigurationReferencedByPlatform verifies whether the given platform configuration entries reference the ConfigMap or Secret with the given name
+func IsConfigurationReferencedByPlatform(platform *operatorapi.KogitoServerlessPlatform, specType operatorapi.ConfigurationSpecType, name string) bool {
+	spec := &platform.Spec
+	if platform.Status.EffectiveSpec != nil {
+		spec = platform.Status.EffectiveSpec
+	}
+	for _, entry := range spec.GetConfigurations() {
+		if entry.Type == specType && entry.Value.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getSecretData reads the data of a Secret, a missing Secret has no data until it's created
+func getSecretData(ctx context.Context, c ctrl.Reader, namespace, name string) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+// secretDataChecksum hashes the Secret data in the keys order
+func secretDataChecksum(data map[string][]byte) []byte {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	for _, k := range keys {
+		hash.Write([]byte(k))
+		hash.Write(data[k])
+	}
+	return hash.Sum(nil)
+}
+
+// getConfigMapProperties reads the properties from a ConfigMap, either from its application.properties key or one property per key.
+func getConfigMapProperties(ctx context.Context, c ctrl.Reader, namespace, name string) (*properties.Properties, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, cm); err != nil {
+		return nil, err
+	}
+	if content, ok := cm.Data[applicationPropertiesKey]; ok {
+		return utils.LoadProperties(content)
+	}
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	props := utils.NewProperties()
+	for _, k := range keys {
+		if _, _, err := props.Set(k, cm.Data[k]); err != nil {
+			return nil, err
+		}
+	}
+	return props, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+func TestGetConfiguration(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform-secret", Namespace: "default"},
+		Data:       map[string][]byte{"PASSWORD": []byte("secret")},
+	}
+	platform := &operatorapi.KogitoServerlessPlatform{ObjectMeta: metav1.ObjectMeta{Name: "kogito-workflow-platform", Namespace: "default"}}
+	platform.Spec.Configuration = &operatorapi.ConfigurationSpec{Type: operatorapi.PropertyConfigurationSpec, Property: "a=deprecated"}
+	platform.Spec.Configurations = []operatorapi.ConfigurationSpec{
+		{Type: operatorapi.PropertyConfigurationSpec, Property: "b=platform"},
+		{Type: operatorapi.SecretConfigurationSpec, Value: corev1.ObjectReference{Name: secret.Name}},
+	}
+	c := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	config, err := GetConfiguration(context.TODO(), c, platform)
+	assert.NoError(t, err)
+	assert.Equal(t, "deprecated", config.Properties.GetString("a", ""))
+	assert.Equal(t, "platform", config.Properties.GetString("b", ""))
+	assert.Len(t, config.EnvFrom, 1)
+	checksum := config.Checksum()
+
+	// the pods read the Secret at startup only, changing its data must roll them out
+	secret.Data["PASSWORD"] = []byte("changed")
+	assert.NoError(t, c.Update(context.TODO(), secret))
+	config, err = GetConfiguration(context.TODO(), c, platform)
+	assert.NoError(t, err)
+	assert.NotEqual(t, checksum, config.Checksum())
+
+	assert.True(t, IsConfigurationReferencedByPlatform(platform, operatorapi.SecretConfigurationSpec, secret.Name))
+	assert.False(t, IsConfigurationReferencedByPlatform(platform, operatorapi.ConfigMapConfigurationSpec, secret.Name))
+}
@@ -39,7 +39,7 @@ func (action *createAction) CanHandle(platform *v08.KogitoServerlessPlatform) bo
 
 func (action *createAction) Handle(ctx context.Context, platform *v08.KogitoServerlessPlatform) (*v08.KogitoServerlessPlatform, error) {
 	//TODO: Perform the actions needed for the Platform creation
-	platform.Status.Phase = v08.PlatformPhaseReady
+	markSucceedIfReady(platform)
 
 	return platform, nil
 }
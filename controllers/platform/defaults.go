@@ -34,6 +34,13 @@ func ConfigureDefaults(ctx context.Context, c client.Client, p *operatorapi.Kogi
 			p.Status.Cluster = operatorapi.PlatformClusterKubernetes
 			p.Spec.BuildPlatform.BuildStrategy = operatorapi.OperatorBuildStrategy
 		}
+	} else if p.Spec.BuildPlatform.BuildStrategy == "" {
+		// the platforms inheriting from a cluster platform don't persist the strategy elected above
+		if p.Status.Cluster == operatorapi.PlatformClusterOpenShift {
+			p.Spec.BuildPlatform.BuildStrategy = operatorapi.PlatformBuildStrategy
+		} else {
+			p.Spec.BuildPlatform.BuildStrategy = operatorapi.OperatorBuildStrategy
+		}
 	}
 
 	err := SetPlatformDefaults(p, verbose)
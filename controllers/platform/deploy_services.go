@@ -0,0 +1,59 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+// NewDeployServicesAction returns an action that deploys the platform services, like the Data Index, once the platform is ready.
+func NewDeployServicesAction() Action {
+	return &deployServicesAction{}
+}
+
+type deployServicesAction struct {
+	baseAction
+}
+
+func (action *deployServicesAction) Name() string {
+	return "deploy-services"
+}
+
+func (action *deployServicesAction) CanHandle(platform *operatorapi.KogitoServerlessPlatform) bool {
+	if platform.Status.Phase != operatorapi.PlatformPhaseReady {
+		return false
+	}
+	if platform.Status.Services == nil {
+		return HasServices(platform)
+	}
+	return platform.Status.Services.ObservedGeneration != platform.Generation
+}
+
+func (action *deployServicesAction) Handle(ctx context.Context, platform *operatorapi.KogitoServerlessPlatform) (*operatorapi.KogitoServerlessPlatform, error) {
+	action.Logger.Info("Deploy platform services")
+	if err := ensureServices(ctx, action.client, platform); err != nil {
+		return nil, err
+	}
+	if err := refreshServicesStatus(ctx, action.client, platform); err != nil {
+		return nil, err
+	}
+	if platform.Status.Services != nil {
+		platform.Status.Services.ObservedGeneration = platform.Generation
+	}
+
+	return platform, nil
+}
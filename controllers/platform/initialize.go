@@ -21,7 +21,9 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/kiegroup/kogito-serverless-operator/api"
 	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
 
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/client"
@@ -48,7 +50,8 @@ func (action *initializeAction) Name() string {
 }
 
 func (action *initializeAction) CanHandle(platform *operatorapi.KogitoServerlessPlatform) bool {
-	return platform.Status.Phase == "" || platform.Status.Phase == operatorapi.PlatformPhaseDuplicate
+	return platform.Status.Phase == operatorapi.PlatformPhaseNone || platform.Status.Phase == operatorapi.PlatformPhaseDuplicate ||
+		needsReinitialization(platform)
 }
 
 func (action *initializeAction) Handle(ctx context.Context, platform *operatorapi.KogitoServerlessPlatform) (*operatorapi.KogitoServerlessPlatform, error) {
@@ -58,9 +61,9 @@ func (action *initializeAction) Handle(ctx context.Context, platform *operatorap
 	}
 	if duplicate {
 		// another platform already present in the namespace
-		if platform.Status.Phase != operatorapi.PlatformPhaseDuplicate {
+		if !platform.Status.IsDuplicate() {
 			plat := platform.DeepCopy()
-			plat.Status.Phase = operatorapi.PlatformPhaseDuplicate
+			plat.Status.Manager().MarkFalse(api.SucceedConditionType, api.DuplicatedPlatformReason, "Another platform is already active in the namespace %s", platform.Namespace)
 
 			return plat, nil
 		}
@@ -68,9 +71,29 @@ func (action *initializeAction) Handle(ctx context.Context, platform *operatorap
 		return nil, nil
 	}
 
+	platform.Status.Manager().InitializeConditions()
+	platform.Status.Manager().MarkUnknown(api.SucceedConditionType, "", "")
 	if err = ConfigureDefaults(ctx, action.client, platform, true); err != nil {
 		return nil, err
 	}
+	applied := platform.Status.AppliedBuildPlatform
+	if applied != nil {
+		action.Logger.Info("Build platform changed, initializing the platform again")
+		if platform.Spec.BuildPlatform.RebuildOnChange && buildsImpacted(applied, &platform.Spec.BuildPlatform) {
+			if err = invalidateBuilds(ctx, action.client, platform); err != nil {
+				return nil, err
+			}
+		}
+	}
+	platform.Status.AppliedBuildPlatform = platform.Spec.BuildPlatform.DeepCopy()
+	if !validateBuilderConfig(platform) {
+		return platform, nil
+	}
+	if applied != nil {
+		// the cache and the registry are set up and checked again from scratch
+		platform.Status.Manager().MarkUnknown(api.CacheWarmedConditionType, "", "")
+		platform.Status.Manager().MarkUnknown(api.RegistryReachableConditionType, "", "")
+	}
 	// nolint: staticcheck
 	if platform.Spec.BuildPlatform.BuildStrategy == operatorapi.OperatorBuildStrategy {
 		//If KanikoCache is enabled
@@ -87,13 +110,23 @@ func (action *initializeAction) Handle(ctx context.Context, platform *operatorap
 			if err != nil {
 				return nil, err
 			}
-			platform.Status.Phase = operatorapi.PlatformPhaseWarming
+			// Refresh the cache periodically, if scheduled
+			if err = ensureKanikoCacheRefresher(ctx, action.client, platform); err != nil {
+				return nil, err
+			}
+			platform.Status.Manager().MarkUnknown(api.CacheWarmedConditionType, api.WarmingCacheReason, "Waiting for the Kaniko cache warmer pod to complete")
 		} else {
-			// Skip the warmer pod creation
-			platform.Status.Phase = operatorapi.PlatformPhaseCreating
+			// Skip the warmer pod creation, and remove the one of a former configuration
+			if err = cleanupKanikoCache(ctx, action.client, platform); err != nil {
+				return nil, err
+			}
+			platform.Status.Manager().MarkTrueWithReason(api.CacheWarmedConditionType, api.CacheDisabledReason, "Kaniko cache is disabled")
 		}
 	} else {
-		platform.Status.Phase = operatorapi.PlatformPhaseCreating
+		if err = cleanupKanikoCache(ctx, action.client, platform); err != nil {
+			return nil, err
+		}
+		platform.Status.Manager().MarkTrueWithReason(api.CacheWarmedConditionType, api.CacheDisabledReason, "The %s build strategy doesn't use a cache", platform.Spec.BuildPlatform.BuildStrategy)
 	}
 	platform.Status.Version = metadata.SpecVersion
 
@@ -107,12 +140,6 @@ func createPersistentVolumeClaim(ctx context.Context, client client.Client, plat
 	if err != nil {
 		return err
 	}
-	// nolint: staticcheck
-	pvcName := defaultKanikoCachePVCName
-	if persistentVolumeClaim, found := platform.Spec.BuildPlatform.BuildStrategyOptions[kanikoPVCName]; found {
-		pvcName = persistentVolumeClaim
-	}
-
 	pvc := &corev1.PersistentVolumeClaim{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: corev1.SchemeGroupVersion.String(),
@@ -120,7 +147,7 @@ func createPersistentVolumeClaim(ctx context.Context, client client.Client, plat
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: platform.Namespace,
-			Name:      pvcName,
+			Name:      kanikoCachePVCName(platform),
 			Labels: map[string]string{
 				"app": "kogito-serverless-operator",
 			},
@@ -137,6 +164,11 @@ func createPersistentVolumeClaim(ctx context.Context, client client.Client, plat
 		},
 	}
 
+	// The cache is removed along with the platform
+	if err = controllerutil.SetControllerReference(platform, pvc, client.GetScheme()); err != nil {
+		return err
+	}
+
 	err = client.Create(ctx, pvc)
 	// Skip the error in case the PVC already exists
 	if err != nil && !k8serrors.IsAlreadyExists(err) {
@@ -17,112 +17,175 @@ package platform
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/client"
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/defaults"
 
+	"github.com/kiegroup/kogito-serverless-operator/utils"
+
 	v08 "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 )
 
-// kanikoCacheDir is the cache directory for Kaniko builds (mounted into the Kaniko pod).
 const kanikoCacheDir = "/kaniko/cache"
 const kanikoPVCName = "KanikoPersistentVolumeClaim"
 const kanikoWarmerImage = "KanikoWarmerImage"
 const kanikoBuildCacheEnabled = "KanikoBuildCacheEnabled"
 const kanikoDefaultWarmerImageName = "gcr.io/kaniko-project/warmer"
 
+// kanikoCacheRefreshSchedule the build strategy option holding the cron schedule of the Kaniko cache refresher, none by default
+const kanikoCacheRefreshSchedule = "KanikoCacheRefreshSchedule"
+
+// kanikoCacheRepository the build strategy option holding the repository where Kaniko pushes and pulls the cached layers of the builds
+const kanikoCacheRepository = "KanikoCacheRepository"
+
+const (
+	kanikoWarmerComponent = "kaniko-warmer"
+	// kanikoCacheSizeContainerName the warmer container reporting the size of the cache, in KiB, as termination message
+	kanikoCacheSizeContainerName = "report-kaniko-cache-size"
+)
+
 func IsKanikoCacheEnabled(platform *v08.KogitoServerlessPlatform) bool {
 	return platform.Spec.BuildPlatform.IsOptionEnabled(kanikoBuildCacheEnabled)
 }
 
-func createKanikoCacheWarmerPod(ctx context.Context, client client.Client, platform *v08.KogitoServerlessPlatform) error {
-	// The pod will be scheduled to nodes that are selected by the persistent volume
-	// node affinity spec, if any, as provisioned by the persistent volume claim storage
-	// class provisioner.
-	// See:
-	// - https://kubernetes.io/docs/concepts/storage/persistent-volumes/#node-affinity
-	// - https://kubernetes.io/docs/concepts/storage/volumes/#local
+// GetKanikoTaskCache gets the Kaniko cache of the builds scheduled with the platform: the volume warmed by the platform, if enabled,
+// and the repository of the cached layers, if any
+func GetKanikoTaskCache(platform *v08.KogitoServerlessPlatform) api.KanikoTaskCache {
+	cache := api.KanikoTaskCache{Repository: platform.Spec.BuildPlatform.BuildStrategyOptions[kanikoCacheRepository]}
+	if IsKanikoCacheEnabled(platform) {
+		cache.PersistentVolumeClaim = kanikoCachePVCName(platform)
+	}
+	if len(cache.PersistentVolumeClaim) > 0 || len(cache.Repository) > 0 {
+		cache.Enabled = utils.Pbool(true)
+	}
+	return cache
+}
+
+// kanikoCachePVCName the name of the persistent volume claim holding the Kaniko cache of the platform
+func kanikoCachePVCName(platform *v08.KogitoServerlessPlatform) string {
 	// nolint: staticcheck
-	pvcName := defaultKanikoCachePVCName
 	if persistentVolumeClaim, found := platform.Spec.BuildPlatform.BuildStrategyOptions[kanikoPVCName]; found {
-		pvcName = persistentVolumeClaim
+		return persistentVolumeClaim
+	}
+	return defaultKanikoCachePVCName
+}
+
+func kanikoCacheWarmerPodName(platform *v08.KogitoServerlessPlatform) string {
+	return platform.Name + "-cache"
+}
+
+func kanikoCacheRefresherName(platform *v08.KogitoServerlessPlatform) string {
+	return platform.Name + "-cache-refresh"
+}
+
+func kanikoWarmerLabels(platform *v08.KogitoServerlessPlatform) map[string]string {
+	return map[string]string{
+		serviceComponentLabel: kanikoWarmerComponent,
+		servicePlatformLabel:  platform.Name,
 	}
+}
 
+// newKanikoCacheWarmerPodSpec creates the spec of the pods warming the Kaniko cache, either run once by the platform or by the refresher.
+// The base image is cached by an init container, then the size of the cache is reported.
+func newKanikoCacheWarmerPodSpec(platform *v08.KogitoServerlessPlatform) corev1.PodSpec {
 	var warmerImage string
 	if image, found := platform.Spec.BuildPlatform.BuildStrategyOptions[kanikoWarmerImage]; found {
 		warmerImage = image
 	} else {
 		warmerImage = fmt.Sprintf("%s:v%s", kanikoDefaultWarmerImageName, defaults.KanikoVersion)
 	}
-
-	pod := corev1.Pod{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: corev1.SchemeGroupVersion.String(),
-			Kind:       "Pod",
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "kaniko-cache",
+			MountPath: kanikoCacheDir,
 		},
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: platform.Namespace,
-			Name:      platform.Name + "-cache",
-			Labels: map[string]string{
-				"sw.kogito.kie.org/component": "kaniko-warmer",
+	}
+
+	return corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			// Create the cache directory otherwise Kaniko warmer skips caching silently
+			{
+				Name:            "create-kaniko-cache",
+				Image:           "busybox",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command:         []string{"/bin/sh", "-c"},
+				Args:            []string{"mkdir -p " + kanikoCacheDir + "&& chmod -R a+rwx " + kanikoCacheDir},
+				VolumeMounts:    volumeMounts,
+				/* TODO: enable this test once we apply security enforcement: https://issues.redhat.com/browse/KOGITO-8799
+				SecurityContext: kubeutil.SecurityDefaults(),*/
 			},
-		},
-		Spec: corev1.PodSpec{
-			Containers: []corev1.Container{
-				{
-					Name:  "warm-kaniko-cache",
-					Image: warmerImage,
-					Args: []string{
-						"--force",
-						"--cache-dir=" + kanikoCacheDir,
-						"--image=" + platform.Spec.BuildPlatform.BaseImage,
-					},
-					VolumeMounts: []corev1.VolumeMount{
-						{
-							Name:      "kaniko-cache",
-							MountPath: kanikoCacheDir,
-						},
-					},
-					/* TODO: enable this test once we apply security enforcement: https://issues.redhat.com/browse/KOGITO-8799
-					SecurityContext: kubeutil.SecurityDefaults(),*/
+			{
+				Name:  "warm-kaniko-cache",
+				Image: warmerImage,
+				Args: []string{
+					"--force",
+					"--cache-dir=" + kanikoCacheDir,
+					"--image=" + platform.Spec.BuildPlatform.BaseImage,
 				},
+				VolumeMounts: volumeMounts,
+				/* TODO: enable this test once we apply security enforcement: https://issues.redhat.com/browse/KOGITO-8799
+				SecurityContext: kubeutil.SecurityDefaults(),*/
 			},
-			// Create the cache directory otherwise Kaniko warmer skips caching silently
-			InitContainers: []corev1.Container{
-				{
-					Name:            "create-kaniko-cache",
-					Image:           "busybox",
-					ImagePullPolicy: corev1.PullIfNotPresent,
-					Command:         []string{"/bin/sh", "-c"},
-					Args:            []string{"mkdir -p " + kanikoCacheDir + "&& chmod -R a+rwx " + kanikoCacheDir},
-					VolumeMounts: []corev1.VolumeMount{
-						{
-							Name:      "kaniko-cache",
-							MountPath: kanikoCacheDir,
-						},
-					},
-					/* TODO: enable this test once we apply security enforcement: https://issues.redhat.com/browse/KOGITO-8799
-					SecurityContext: kubeutil.SecurityDefaults(),*/
-				},
+		},
+		Containers: []corev1.Container{
+			{
+				Name:                     kanikoCacheSizeContainerName,
+				Image:                    "busybox",
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				Command:                  []string{"/bin/sh", "-c"},
+				Args:                     []string{"du -sk " + kanikoCacheDir + " | cut -f1 > " + corev1.TerminationMessagePathDefault},
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				VolumeMounts:             volumeMounts,
 			},
-			RestartPolicy: corev1.RestartPolicyOnFailure,
-			Volumes: []corev1.Volume{
-				{
-					Name: "kaniko-cache",
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: pvcName,
-						},
+		},
+		RestartPolicy: corev1.RestartPolicyOnFailure,
+		Volumes: []corev1.Volume{
+			{
+				Name: "kaniko-cache",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: kanikoCachePVCName(platform),
 					},
 				},
 			},
 		},
 	}
+}
+
+func createKanikoCacheWarmerPod(ctx context.Context, client client.Client, platform *v08.KogitoServerlessPlatform) error {
+	// The pod will be scheduled to nodes that are selected by the persistent volume
+	// node affinity spec, if any, as provisioned by the persistent volume claim storage
+	// class provisioner.
+	// See:
+	// - https://kubernetes.io/docs/concepts/storage/persistent-volumes/#node-affinity
+	// - https://kubernetes.io/docs/concepts/storage/volumes/#local
+	pod := corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: platform.Namespace,
+			Name:      kanikoCacheWarmerPodName(platform),
+			Labels:    kanikoWarmerLabels(platform),
+		},
+		Spec: newKanikoCacheWarmerPodSpec(platform),
+	}
+	if err := controllerutil.SetControllerReference(platform, &pod, client.GetScheme()); err != nil {
+		return err
+	}
 
 	err := client.Delete(ctx, &pod)
 	if err != nil && !k8serrors.IsNotFound(err) {
@@ -136,3 +199,109 @@ func createKanikoCacheWarmerPod(ctx context.Context, client client.Client, platf
 
 	return nil
 }
+
+// ensureKanikoCacheRefresher creates or updates the CronJob warming the Kaniko cache again on the schedule set in the build strategy options.
+// Without schedule, the refresher is removed.
+func ensureKanikoCacheRefresher(ctx context.Context, c client.Client, platform *v08.KogitoServerlessPlatform) error {
+	schedule := platform.Spec.BuildPlatform.BuildStrategyOptions[kanikoCacheRefreshSchedule]
+	if len(schedule) == 0 {
+		return deleteIgnoringNotFound(ctx, c, &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: kanikoCacheRefresherName(platform), Namespace: platform.Namespace}})
+	}
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: kanikoCacheRefresherName(platform), Namespace: platform.Namespace}}
+	_, err := controllerutil.CreateOrPatch(ctx, c, cronJob, func() error {
+		lbl := kanikoWarmerLabels(platform)
+		historyLimit := int32(1)
+		cronJob.Labels = lbl
+		cronJob.Spec.Schedule = schedule
+		// a refresh still running means the former one isn't over, the cache volume can't be shared anyway
+		cronJob.Spec.ConcurrencyPolicy = batchv1.ForbidConcurrent
+		cronJob.Spec.SuccessfulJobsHistoryLimit = &historyLimit
+		cronJob.Spec.FailedJobsHistoryLimit = &historyLimit
+		cronJob.Spec.JobTemplate.Labels = lbl
+		cronJob.Spec.JobTemplate.Spec.Template.Labels = lbl
+		cronJob.Spec.JobTemplate.Spec.Template.Spec = newKanikoCacheWarmerPodSpec(platform)
+		return controllerutil.SetControllerReference(platform, cronJob, c.GetScheme())
+	})
+	return err
+}
+
+// cleanupKanikoCache removes the Kaniko cache artefacts of a platform not using the cache anymore.
+// The persistent volume claim is removed only if it was created by the platform.
+func cleanupKanikoCache(ctx context.Context, c client.Client, platform *v08.KogitoServerlessPlatform) error {
+	objectMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: platform.Namespace}
+	}
+	for _, object := range []ctrl.Object{
+		&corev1.Pod{ObjectMeta: objectMeta(kanikoCacheWarmerPodName(platform))},
+		&batchv1.CronJob{ObjectMeta: objectMeta(kanikoCacheRefresherName(platform))},
+	} {
+		if err := deleteIgnoringNotFound(ctx, c, object); err != nil {
+			return err
+		}
+	}
+	platform.Status.KanikoCache = nil
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := c.Get(ctx, ctrl.ObjectKey{Namespace: platform.Namespace, Name: kanikoCachePVCName(platform)}, pvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(pvc, platform) {
+		return nil
+	}
+	return deleteIgnoringNotFound(ctx, c, pvc)
+}
+
+func deleteIgnoringNotFound(ctx context.Context, c client.Client, object ctrl.Object) error {
+	if err := c.Delete(ctx, object); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// refreshKanikoCacheStatus reports the size of the Kaniko cache warmed by the latest successful warmer pod, either the platform or the refresher one.
+func refreshKanikoCacheStatus(ctx context.Context, c ctrl.Reader, platform *v08.KogitoServerlessPlatform) error {
+	if !IsKanikoCacheEnabled(platform) {
+		platform.Status.KanikoCache = nil
+		return nil
+	}
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, ctrl.InNamespace(platform.Namespace), ctrl.MatchingLabels(kanikoWarmerLabels(platform))); err != nil {
+		return err
+	}
+	cache := &v08.KanikoCacheStatus{PersistentVolumeClaim: kanikoCachePVCName(platform)}
+	if platform.Status.KanikoCache != nil {
+		cache.Size = platform.Status.KanikoCache.Size
+		cache.LastWarmTime = platform.Status.KanikoCache.LastWarmTime
+	}
+	for _, pod := range pods.Items {
+		terminated := kanikoCacheSizeReport(&pod)
+		if terminated == nil || (cache.LastWarmTime != nil && !cache.LastWarmTime.Before(&terminated.FinishedAt)) {
+			continue
+		}
+		kib, err := strconv.ParseInt(strings.TrimSpace(terminated.Message), 10, 64)
+		if err != nil {
+			continue
+		}
+		finishedAt := terminated.FinishedAt
+		cache.Size = resource.NewQuantity(kib*1024, resource.BinarySI)
+		cache.LastWarmTime = &finishedAt
+	}
+	platform.Status.KanikoCache = cache
+	return nil
+}
+
+// kanikoCacheSizeReport the terminated state of the container reporting the cache size of a successful warmer pod, nil if not available
+func kanikoCacheSizeReport(pod *corev1.Pod) *corev1.ContainerStateTerminated {
+	if pod.Status.Phase != corev1.PodSucceeded {
+		return nil
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == kanikoCacheSizeContainerName && status.State.Terminated != nil && status.State.Terminated.ExitCode == 0 {
+			return status.State.Terminated
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/api"
+	"github.com/kiegroup/kogito-serverless-operator/utils"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+func TestGetKanikoTaskCache(t *testing.T) {
+	platform := &operatorapi.KogitoServerlessPlatform{}
+	platform.Name = "kogito-workflow-platform"
+	assert.Equal(t, api.KanikoTaskCache{}, GetKanikoTaskCache(platform))
+
+	platform.Spec.BuildPlatform.BuildStrategyOptions = map[string]string{"KanikoCacheRepository": "quay.io/kiegroup/cache"}
+	assert.Equal(t, api.KanikoTaskCache{Enabled: utils.Pbool(true), Repository: "quay.io/kiegroup/cache"}, GetKanikoTaskCache(platform))
+
+	platform.Spec.BuildPlatform.BuildStrategyOptions["KanikoBuildCacheEnabled"] = "true"
+	platform.Spec.BuildPlatform.BuildStrategyOptions["KanikoPersistentVolumeClaim"] = platform.Name
+	assert.Equal(t, api.KanikoTaskCache{Enabled: utils.Pbool(true), PersistentVolumeClaim: platform.Name, Repository: "quay.io/kiegroup/cache"}, GetKanikoTaskCache(platform))
+}
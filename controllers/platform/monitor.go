@@ -17,6 +17,7 @@ package platform
 import (
 	"context"
 
+	"github.com/kiegroup/kogito-serverless-operator/api"
 	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 )
@@ -35,20 +36,50 @@ func (action *monitorAction) Name() string {
 }
 
 func (action *monitorAction) CanHandle(platform *operatorapi.KogitoServerlessPlatform) bool {
-	return platform.Status.Phase == operatorapi.PlatformPhaseReady
+	return platform.Status.Phase == operatorapi.PlatformPhaseReady || platform.Status.Phase == operatorapi.PlatformPhaseError
 }
 
 func (action *monitorAction) Handle(ctx context.Context, platform *operatorapi.KogitoServerlessPlatform) (*operatorapi.KogitoServerlessPlatform, error) {
+	// Platforms created by former operator versions are ready without conditions
+	if len(platform.Status.Conditions) == 0 {
+		platform.Status.Manager().MarkTrue(api.SucceedConditionType)
+		platform.Status.Manager().InitializeConditions()
+	}
+
 	// Just track the version of the operator in the platform resource
 	if platform.Status.Version != metadata.SpecVersion {
 		platform.Status.Version = metadata.SpecVersion
 		action.Logger.Info("Platform version updated", "version", platform.Status.Version)
 	}
 
+	// Restore the platform services deleted or edited since they were deployed
+	if platform.Status.Phase == operatorapi.PlatformPhaseReady && platform.Status.Services != nil {
+		if err := ensureServices(ctx, action.client, platform); err != nil {
+			return nil, err
+		}
+	}
+
+	// Track the platform services, if any becomes unavailable they're monitored until ready again
+	if err := refreshServicesStatus(ctx, action.client, platform); err != nil {
+		return nil, err
+	}
+
+	// Track the cache warmed by the refresher
+	if err := refreshKanikoCacheStatus(ctx, action.client, platform); err != nil {
+		return nil, err
+	}
+
 	// Refresh applied configuration
 	if err := ConfigureDefaults(ctx, action.client, platform, false); err != nil {
 		return nil, err
 	}
+	// Platforms initialized by former operator versions didn't track the applied build platform, defaulted like in the initialization
+	if platform.Status.AppliedBuildPlatform == nil {
+		platform.Status.AppliedBuildPlatform = platform.Spec.BuildPlatform.DeepCopy()
+	}
+	if validateBuilderConfig(platform) {
+		markSucceedIfReady(platform)
+	}
 
 	return platform, nil
 }
@@ -16,6 +16,7 @@ package platform
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -111,12 +112,49 @@ func GetOperatorLockName(operatorID string) string {
 	return fmt.Sprintf("%s-lock", operatorID)
 }
 
+// ErrPlatformNotReady is returned when the platform selected by a workflow isn't ready yet
+var ErrPlatformNotReady = errors.New("platform not ready")
+
+// IsPlatformUnavailable tells whether the error returned by GetWorkflowPlatform means the platform is missing or not ready yet.
+func IsPlatformUnavailable(err error) bool {
+	return k8serrors.IsNotFound(err) || errors.Is(err, ErrPlatformNotReady)
+}
+
+// GetWorkflowPlatform returns the platform selected by the workflow, or the active platform of its namespace when none is selected.
+func GetWorkflowPlatform(ctx context.Context, c ctrl.Reader, workflow *operatorapi.KogitoServerlessWorkflow) (*operatorapi.KogitoServerlessPlatform, error) {
+	return GetSelectedPlatform(ctx, c, workflow.Namespace, SelectedPlatformName(workflow))
+}
+
+// SelectedPlatformName returns the name of the platform selected by the workflow, either with its spec or with the metadata.PlatformAnnotation.
+func SelectedPlatformName(workflow *operatorapi.KogitoServerlessWorkflow) string {
+	if workflow.Spec.PlatformRef != nil && len(workflow.Spec.PlatformRef.Name) > 0 {
+		return workflow.Spec.PlatformRef.Name
+	}
+	return workflow.Annotations[metadata.PlatformAnnotation]
+}
+
+// GetSelectedPlatform returns the given platform of the namespace, that must be ready, or the active platform of the namespace when no name is given.
+func GetSelectedPlatform(ctx context.Context, c ctrl.Reader, namespace, name string) (*operatorapi.KogitoServerlessPlatform, error) {
+	if len(name) == 0 {
+		return GetActivePlatform(ctx, c, namespace)
+	}
+	pl := &operatorapi.KogitoServerlessPlatform{}
+	if err := c.Get(ctx, ctrl.ObjectKey{Namespace: namespace, Name: name}, pl); err != nil {
+		return nil, err
+	}
+	if !pl.Status.IsReady() {
+		return nil, fmt.Errorf("%w: %s/%s", ErrPlatformNotReady, namespace, name)
+	}
+	return withEffectiveSpec(pl), nil
+}
+
 // GetActivePlatform returns the currently installed active platform in the local namespace.
 func GetActivePlatform(ctx context.Context, c ctrl.Reader, namespace string) (*operatorapi.KogitoServerlessPlatform, error) {
 	return GetLocalPlatform(ctx, c, namespace, true)
 }
 
 // GetLocalPlatform returns the currently installed platform or any platform existing in local namespace.
+// The spec of the returned platform includes the fields inherited from its cluster platform, if any.
 func GetLocalPlatform(ctx context.Context, c ctrl.Reader, namespace string, active bool) (*operatorapi.KogitoServerlessPlatform, error) {
 	log.Debug("Finding available platforms")
 
@@ -129,7 +167,7 @@ func GetLocalPlatform(ctx context.Context, c ctrl.Reader, namespace string, acti
 		platform := platform // pin
 		if IsActive(&platform) {
 			log.Debugf("Found active local build platform %s", platform.Name)
-			return &platform, nil
+			return withEffectiveSpec(&platform), nil
 		}
 	}
 
@@ -137,13 +175,21 @@ func GetLocalPlatform(ctx context.Context, c ctrl.Reader, namespace string, acti
 		// does not require the platform to be active, just return one if present
 		res := lst.Items[0]
 		log.Debugf("Found local build platform %s", res.Name)
-		return &res, nil
+		return withEffectiveSpec(&res), nil
 	}
 
 	log.Debugf("Not found a local build platform")
 	return nil, k8serrors.NewNotFound(operatorapi.Resource("KogitoServerlessPlatform"), DefaultPlatformName)
 }
 
+// withEffectiveSpec replaces the spec of a platform inheriting from a cluster platform with the merged one reported in its status.
+func withEffectiveSpec(p *operatorapi.KogitoServerlessPlatform) *operatorapi.KogitoServerlessPlatform {
+	if p.Status.EffectiveSpec != nil {
+		p.Spec = *p.Status.EffectiveSpec
+	}
+	return p
+}
+
 // ListPrimaryPlatforms returns all non-secondary platforms installed in a given namespace (only one will be active).
 func ListPrimaryPlatforms(ctx context.Context, c ctrl.Reader, namespace string) (*operatorapi.KogitoServerlessPlatformList, error) {
 	lst, err := ListAllPlatforms(ctx, c, namespace)
@@ -0,0 +1,93 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/registry"
+)
+
+// gcrRegistrySecretKey the key of the Google service account secrets mounted by the Kaniko builder, which can't be used by the operator
+const gcrRegistrySecretKey = "kaniko-secret.json"
+
+var (
+	// ErrRegistryConfigInvalid is returned when the registry Secret or CA ConfigMap can't be used
+	ErrRegistryConfigInvalid = errors.New("invalid registry configuration")
+	// ErrRegistryCredentialsNotVerifiable is returned when the registry Secret holds credentials only the Kaniko builder can use
+	ErrRegistryCredentialsNotVerifiable = errors.New("registry credentials can't be verified")
+)
+
+// GetRegistryAccess reads the credentials and the CA of the registry, if any, from the Secret and the ConfigMap in the namespace.
+// The returned error wraps ErrRegistryConfigInvalid or ErrRegistryCredentialsNotVerifiable when they can't be used.
+func GetRegistryAccess(ctx context.Context, c ctrl.Reader, namespace string, registrySpec operatorapi.RegistrySpec) (registry.RegistryAccess, error) {
+	access := registry.RegistryAccess{Insecure: registrySpec.Insecure}
+	if len(registrySpec.Secret) > 0 {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: registrySpec.Secret}, secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return access, fmt.Errorf("%w: registry secret %s not found", ErrRegistryConfigInvalid, registrySpec.Secret)
+			}
+			return access, err
+		}
+		if _, ok := secret.Data[gcrRegistrySecretKey]; ok {
+			return access, fmt.Errorf("%w: the Google service account in the registry secret %s is only used by the builder", ErrRegistryCredentialsNotVerifiable, registrySpec.Secret)
+		}
+		config, err := registry.ParseDockerConfig(secret.Data)
+		if err != nil {
+			return access, fmt.Errorf("%w: registry secret %s: %v", ErrRegistryConfigInvalid, registrySpec.Secret, err)
+		}
+		host, _, _ := strings.Cut(registrySpec.Address, "/")
+		auth, found := config.AuthFor(host)
+		if !found {
+			return access, fmt.Errorf("%w: registry secret %s has no credentials for %s", ErrRegistryConfigInvalid, registrySpec.Secret, host)
+		}
+		if access.Username, access.Password, err = auth.Credentials(); err != nil {
+			return access, fmt.Errorf("%w: registry secret %s: %v", ErrRegistryConfigInvalid, registrySpec.Secret, err)
+		}
+	}
+	if len(registrySpec.CA) > 0 {
+		configMap := &corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: registrySpec.CA}, configMap); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return access, fmt.Errorf("%w: registry CA ConfigMap %s not found", ErrRegistryConfigInvalid, registrySpec.CA)
+			}
+			return access, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		found := false
+		for _, data := range configMap.Data {
+			found = pool.AppendCertsFromPEM([]byte(data)) || found
+		}
+		if !found {
+			return access, fmt.Errorf("%w: registry CA ConfigMap %s has no PEM certificate", ErrRegistryConfigInvalid, registrySpec.CA)
+		}
+		access.RootCAs = pool
+	}
+	return access, nil
+}
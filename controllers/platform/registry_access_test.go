@@ -0,0 +1,57 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+func TestGetRegistryAccess(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("user:secret"))
+	regcred := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "regcred", Namespace: "default"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"quay.io":{"auth":"` + auth + `"}}}`)},
+	}
+	gcr := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcr", Namespace: "default"},
+		Data:       map[string][]byte{gcrRegistrySecretKey: []byte("{}")},
+	}
+	c := fake.NewClientBuilder().WithObjects(regcred, gcr).Build()
+
+	access, err := GetRegistryAccess(context.TODO(), c, "default", operatorapi.RegistrySpec{Address: "quay.io/kiegroup", Secret: "regcred", Insecure: true})
+	assert.NoError(t, err)
+	assert.True(t, access.Insecure)
+	assert.Equal(t, "user", access.Username)
+	assert.Equal(t, "secret", access.Password)
+
+	_, err = GetRegistryAccess(context.TODO(), c, "default", operatorapi.RegistrySpec{Address: "docker.io/kiegroup", Secret: "regcred"})
+	assert.ErrorIs(t, err, ErrRegistryConfigInvalid)
+	_, err = GetRegistryAccess(context.TODO(), c, "default", operatorapi.RegistrySpec{Address: "quay.io/kiegroup", Secret: "missing"})
+	assert.ErrorIs(t, err, ErrRegistryConfigInvalid)
+	_, err = GetRegistryAccess(context.TODO(), c, "default", operatorapi.RegistrySpec{Address: "gcr.io/kiegroup", Secret: "gcr"})
+	assert.ErrorIs(t, err, ErrRegistryCredentialsNotVerifiable)
+	_, err = GetRegistryAccess(context.TODO(), c, "default", operatorapi.RegistrySpec{Address: "quay.io/kiegroup", CA: "missing"})
+	assert.ErrorIs(t, err, ErrRegistryConfigInvalid)
+}
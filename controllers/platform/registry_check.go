@@ -0,0 +1,89 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kiegroup/kogito-serverless-operator/api"
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/registry"
+)
+
+// NewRegistryCheckAction returns an action that verifies the connectivity and the credentials of the platform registry before its creation.
+func NewRegistryCheckAction() Action {
+	return &registryCheckAction{}
+}
+
+type registryCheckAction struct {
+	baseAction
+}
+
+func (action *registryCheckAction) Name() string {
+	return "registry-check"
+}
+
+func (action *registryCheckAction) CanHandle(platform *operatorapi.KogitoServerlessPlatform) bool {
+	switch platform.Status.Phase {
+	case operatorapi.PlatformPhaseCreating:
+		return !platform.Status.GetCondition(api.RegistryReachableConditionType).IsTrue()
+	case operatorapi.PlatformPhaseError:
+		// keep checking a failing registry until it's fixed
+		return platform.Status.GetCondition(api.RegistryReachableConditionType).IsFalse()
+	}
+	return false
+}
+
+func (action *registryCheckAction) Handle(ctx context.Context, platform *operatorapi.KogitoServerlessPlatform) (*operatorapi.KogitoServerlessPlatform, error) {
+	registrySpec := platform.Spec.BuildPlatform.Registry
+	if platform.Spec.BuildPlatform.BuildStrategy != operatorapi.OperatorBuildStrategy {
+		platform.Status.Manager().MarkTrueWithReason(api.RegistryReachableConditionType, api.RegistryNotVerifiedReason,
+			"The registry is managed by the %s build strategy", platform.Spec.BuildPlatform.BuildStrategy)
+		return platform, nil
+	}
+	if registrySpec.Address == "" {
+		platform.Status.Manager().MarkTrueWithReason(api.RegistryReachableConditionType, api.RegistryNotVerifiedReason, "No registry address configured")
+		return platform, nil
+	}
+
+	access, err := GetRegistryAccess(ctx, action.client, platform.Namespace, registrySpec)
+	switch {
+	case errors.Is(err, ErrRegistryCredentialsNotVerifiable):
+		platform.Status.Manager().MarkTrueWithReason(api.RegistryReachableConditionType, api.RegistryNotVerifiedReason, "%v", err)
+		return platform, nil
+	case errors.Is(err, ErrRegistryConfigInvalid):
+		platform.Status.Manager().MarkFalse(api.RegistryReachableConditionType, api.RegistryConfigInvalidReason, "%v", err)
+		return platform, nil
+	case err != nil:
+		return nil, err
+	}
+	options := registry.PreflightOptions{Address: registrySpec.Address, Organization: registrySpec.Organization, RegistryAccess: access}
+
+	action.Logger.Info("Checking registry", "address", registrySpec.Address)
+	err = registry.Preflight(ctx, options)
+	switch {
+	case err == nil:
+		platform.Status.Manager().MarkTrue(api.RegistryReachableConditionType)
+	case errors.Is(err, registry.ErrUnauthorized):
+		platform.Status.Manager().MarkFalse(api.RegistryReachableConditionType, api.RegistryUnauthorizedReason, "%v", err)
+	case errors.Is(err, registry.ErrPushDenied):
+		platform.Status.Manager().MarkFalse(api.RegistryReachableConditionType, api.RegistryPushDeniedReason, "%v", err)
+	default:
+		platform.Status.Manager().MarkFalse(api.RegistryReachableConditionType, api.RegistryUnreachableReason, "%v", err)
+	}
+
+	return platform, nil
+}
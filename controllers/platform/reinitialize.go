@@ -0,0 +1,98 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"reflect"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiegroup/kogito-serverless-operator/api"
+	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/client"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+// needsReinitialization tells whether a Ready, or failed, platform must be initialized again.
+// It's the case when the build platform the Kaniko cache and the registry were set up with changed,
+// or when the spec of a platform with an invalid builder configuration was edited.
+func needsReinitialization(platform *operatorapi.KogitoServerlessPlatform) bool {
+	if platform.Status.Phase != operatorapi.PlatformPhaseReady && platform.Status.Phase != operatorapi.PlatformPhaseError {
+		return false
+	}
+	applied := platform.Status.AppliedBuildPlatform
+	if applied == nil {
+		return false
+	}
+	if platform.Status.GetCondition(api.BuilderConfigValidConditionType).IsFalse() && platform.Generation != platform.Status.ObservedGeneration {
+		return true
+	}
+	current := &platform.Spec.BuildPlatform
+	return applied.BuildStrategy != current.BuildStrategy ||
+		applied.BaseImage != current.BaseImage ||
+		!reflect.DeepEqual(applied.Registry, current.Registry) ||
+		!reflect.DeepEqual(applied.BuildStrategyOptions, current.BuildStrategyOptions)
+}
+
+// buildsImpacted tells whether the images built with the applied build platform are outdated with the current one
+func buildsImpacted(applied, current *operatorapi.BuildPlatformTemplate) bool {
+	return applied.BaseImage != current.BaseImage ||
+		!reflect.DeepEqual(applied.Registry, current.Registry) ||
+		applied.IsOptionEnabled(kanikoBuildCacheEnabled) != current.IsOptionEnabled(kanikoBuildCacheEnabled)
+}
+
+// invalidateBuilds restarts the finished builds of the workflows using the given platform, and marks their workflows as building again.
+// The running builds complete with the former configuration.
+func invalidateBuilds(ctx context.Context, c client.Client, platform *operatorapi.KogitoServerlessPlatform) error {
+	builds := &operatorapi.KogitoServerlessBuildList{}
+	if err := c.List(ctx, builds, ctrl.InNamespace(platform.Namespace)); err != nil {
+		return err
+	}
+	for i := range builds.Items {
+		build := &builds.Items[i]
+		if !usesPlatform(build, platform) || !build.Status.BuildPhase.IsFinished() {
+			continue
+		}
+		build.Status.BuildPhase = operatorapi.BuildPhaseNone
+		if err := c.Status().Update(ctx, build); err != nil {
+			return err
+		}
+
+		workflow := &operatorapi.KogitoServerlessWorkflow{}
+		if err := c.Get(ctx, ctrl.ObjectKeyFromObject(build), workflow); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.BuildIsRunningReason, "Platform %s changed, rebuilding", platform.Name)
+		workflow.Status.Manager().MarkUnknown(api.RunningConditionType, "", "")
+		if err := c.Status().Update(ctx, workflow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// usesPlatform tells whether the build was created with the given platform, either selected by its workflow or the active one of the namespace
+func usesPlatform(build *operatorapi.KogitoServerlessBuild, platform *operatorapi.KogitoServerlessPlatform) bool {
+	if name := build.Annotations[metadata.PlatformAnnotation]; len(name) > 0 {
+		return name == platform.Name
+	}
+	return !IsSecondary(platform)
+}
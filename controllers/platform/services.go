@@ -0,0 +1,365 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/magiconair/properties"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/client"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/utils"
+	kubeutil "github.com/kiegroup/kogito-serverless-operator/utils/kubernetes"
+)
+
+const (
+	dataIndexServiceName = "data-index-service"
+	jobServiceName       = "jobs-service"
+
+	serviceComponentLabel = "sw.kogito.kie.org/component"
+	servicePlatformLabel  = "sw.kogito.kie.org/platform"
+
+	serviceContainerName = "service"
+	serviceHTTPPort      = 8080
+	serviceHTTPPortName  = "http"
+	defaultServicePort   = 80
+
+	serviceHealthPathReady = "/q/health/ready"
+	serviceHealthPathLive  = "/q/health/live"
+
+	quarkusDatasourceJdbcURLEnv     = "QUARKUS_DATASOURCE_JDBC_URL"
+	quarkusDatasourceReactiveURLEnv = "QUARKUS_DATASOURCE_REACTIVE_URL"
+	quarkusDatasourceUsernameEnv    = "QUARKUS_DATASOURCE_USERNAME"
+	quarkusDatasourcePasswordEnv    = "QUARKUS_DATASOURCE_PASSWORD"
+	quarkusFlywayMigrateAtStartEnv  = "QUARKUS_FLYWAY_MIGRATE_AT_START"
+	// dataIndexQuarkusProfileEnv enables the Data Index to receive the events over HTTP instead of a message broker
+	dataIndexQuarkusProfileEnv      = "KOGITO_DATA_INDEX_QUARKUS_PROFILE"
+	dataIndexHTTPEventsProfile      = "http-events-support"
+	jobServiceStatusChangeEventsEnv = "KOGITO_JOBS_SERVICE_HTTP_JOB_STATUS_CHANGE_EVENTS"
+	jobServiceStatusChangeURLEnv    = "MP_MESSAGING_OUTGOING_KOGITO_JOB_SERVICE_JOB_STATUS_EVENTS_HTTP_URL"
+
+	// Data Index endpoints receiving the events over HTTP.
+	// See: https://kiegroup.github.io/kogito-docs/serverlessworkflow/latest/data-index/data-index-service.html
+	dataIndexProcessesPath = "/processes"
+	dataIndexJobsPath      = "/jobs"
+	// Jobs Service endpoint receiving the job requests over HTTP.
+	// See: https://kiegroup.github.io/kogito-docs/serverlessworkflow/latest/job-services/core-concepts.html
+	jobServiceEventsPath = "/v2/jobs/events"
+)
+
+// supportingService describes a service deployed by the platform to support the workflows
+type supportingService struct {
+	// name of the service, used as the suffix of its objects
+	name string
+	// spec of the service in the platform, nil if not declared
+	spec func(services *operatorapi.PlatformServicesSpec) *operatorapi.PlatformServiceSpec
+	// status of the service in the platform status
+	status func(services *operatorapi.PlatformServicesStatus) **operatorapi.PlatformServiceStatus
+	// defaultImage of the service, depending on its persistence
+	defaultImage func(persistent bool) string
+	// configure the service container for the given platform
+	configure func(platform *operatorapi.KogitoServerlessPlatform, container *corev1.Container, jdbcURL string)
+}
+
+var dataIndexService = supportingService{
+	name: dataIndexServiceName,
+	spec: func(services *operatorapi.PlatformServicesSpec) *operatorapi.PlatformServiceSpec {
+		return services.DataIndex
+	},
+	status: func(services *operatorapi.PlatformServicesStatus) **operatorapi.PlatformServiceStatus {
+		return &services.DataIndex
+	},
+	defaultImage: workflowdef.GetDefaultDataIndexImageTag,
+	configure: func(platform *operatorapi.KogitoServerlessPlatform, container *corev1.Container, jdbcURL string) {
+		kubeutil.CreateOrReplaceEnv(container, dataIndexQuarkusProfileEnv, dataIndexHTTPEventsProfile)
+		if len(jdbcURL) > 0 {
+			kubeutil.CreateOrReplaceEnv(container, quarkusDatasourceJdbcURLEnv, jdbcURL)
+		}
+	},
+}
+
+var jobService = supportingService{
+	name: jobServiceName,
+	spec: func(services *operatorapi.PlatformServicesSpec) *operatorapi.PlatformServiceSpec {
+		return services.JobService
+	},
+	status: func(services *operatorapi.PlatformServicesStatus) **operatorapi.PlatformServiceStatus {
+		return &services.JobService
+	},
+	defaultImage: workflowdef.GetDefaultJobServiceImageTag,
+	configure: func(platform *operatorapi.KogitoServerlessPlatform, container *corev1.Container, jdbcURL string) {
+		if len(jdbcURL) > 0 {
+			kubeutil.CreateOrReplaceEnv(container, quarkusDatasourceJdbcURLEnv, jdbcURL)
+			// the Jobs Service uses the reactive client at runtime, it shares the JDBC URL host, database and schema
+			reactiveURL := strings.Replace(strings.TrimPrefix(jdbcURL, "jdbc:"), "currentSchema=", "search_path=", 1)
+			kubeutil.CreateOrReplaceEnv(container, quarkusDatasourceReactiveURLEnv, reactiveURL)
+		}
+		// let the Data Index know about the jobs' status changes
+		if dataIndexURL := GetDataIndexURL(platform); len(dataIndexURL) > 0 {
+			kubeutil.CreateOrReplaceEnv(container, jobServiceStatusChangeEventsEnv, "true")
+			kubeutil.CreateOrReplaceEnv(container, jobServiceStatusChangeURLEnv, dataIndexURL+dataIndexJobsPath)
+		}
+	},
+}
+
+var supportingServices = []supportingService{dataIndexService, jobService}
+
+// GetDataIndexURL gets the URL of the Data Index deployed by the given platform, empty if it isn't enabled
+func GetDataIndexURL(platform *operatorapi.KogitoServerlessPlatform) string {
+	return getServiceURL(platform, dataIndexService)
+}
+
+// GetJobServiceURL gets the URL of the Jobs Service deployed by the given platform, empty if it isn't enabled
+func GetJobServiceURL(platform *operatorapi.KogitoServerlessPlatform) string {
+	return getServiceURL(platform, jobService)
+}
+
+// HasServices checks if the given platform deploys any supporting service
+func HasServices(platform *operatorapi.KogitoServerlessPlatform) bool {
+	for _, service := range supportingServices {
+		if service.isEnabled(platform) {
+			return true
+		}
+	}
+	return false
+}
+
+// getServicesProperties gets the application properties that wire the workflows to the platform services
+func getServicesProperties(platform *operatorapi.KogitoServerlessPlatform) *properties.Properties {
+	props := utils.NewProperties()
+	if dataIndexURL := GetDataIndexURL(platform); len(dataIndexURL) > 0 {
+		_, _, _ = props.Set("kogito.data-index.url", dataIndexURL)
+		_, _, _ = props.Set("kogito.events.processinstances.enabled", "true")
+		_, _, _ = props.Set("mp.messaging.outgoing.kogito-processinstances-events.connector", "quarkus-http")
+		_, _, _ = props.Set("mp.messaging.outgoing.kogito-processinstances-events.url", dataIndexURL+dataIndexProcessesPath)
+	}
+	if jobServiceURL := GetJobServiceURL(platform); len(jobServiceURL) > 0 {
+		_, _, _ = props.Set("kogito.jobs-service.url", jobServiceURL)
+		_, _, _ = props.Set("mp.messaging.outgoing.kogito-job-service-job-request-events.connector", "quarkus-http")
+		_, _, _ = props.Set("mp.messaging.outgoing.kogito-job-service-job-request-events.url", jobServiceURL+jobServiceEventsPath)
+	}
+	return props
+}
+
+func (s supportingService) isEnabled(platform *operatorapi.KogitoServerlessPlatform) bool {
+	return platform.Spec.Services != nil && s.spec(platform.Spec.Services).IsEnabled()
+}
+
+func (s supportingService) objectName(platform *operatorapi.KogitoServerlessPlatform) string {
+	return platform.Name + "-" + s.name
+}
+
+func (s supportingService) labels(platform *operatorapi.KogitoServerlessPlatform) map[string]string {
+	return map[string]string{
+		workflowdef.LabelApp:  s.objectName(platform),
+		servicePlatformLabel:  platform.Name,
+		serviceComponentLabel: s.name,
+	}
+}
+
+func getServiceURL(platform *operatorapi.KogitoServerlessPlatform, service supportingService) string {
+	if platform == nil || !service.isEnabled(platform) {
+		return ""
+	}
+	return fmt.Sprintf("http://%s.%s", service.objectName(platform), platform.Namespace)
+}
+
+// getServicePersistence gets the persistence of the service, defaulting to the platform one. Nil if the service is ephemeral.
+func getServicePersistence(platform *operatorapi.KogitoServerlessPlatform, spec *operatorapi.PlatformServiceSpec) *operatorapi.PersistenceSpec {
+	persistence := spec.Persistence
+	if persistence == nil {
+		persistence = platform.Spec.Persistence
+	}
+	if persistence == nil || persistence.PostgreSQL == nil {
+		return nil
+	}
+	return persistence
+}
+
+// ensureServices deploys the enabled platform services and removes the disabled ones
+func ensureServices(ctx context.Context, c client.Client, platform *operatorapi.KogitoServerlessPlatform) error {
+	for _, service := range supportingServices {
+		if !service.isEnabled(platform) {
+			if err := deleteService(ctx, c, platform, service); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := ensureServiceDeployment(ctx, c, platform, service); err != nil {
+			return err
+		}
+		if err := ensureServiceService(ctx, c, platform, service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ensureServiceDeployment(ctx context.Context, c client.Client, platform *operatorapi.KogitoServerlessPlatform, service supportingService) error {
+	spec := service.spec(platform.Spec.Services)
+	persistence := getServicePersistence(platform, spec)
+	jdbcURL := ""
+	if persistence != nil {
+		var err error
+		if jdbcURL, err = workflowdef.GetPostgreSQLJdbcURL(persistence.PostgreSQL, platform.Namespace, service.name); err != nil {
+			return fmt.Errorf("platform %s service %s: %w", platform.Name, service.name, err)
+		}
+	}
+	image := spec.Image
+	if len(image) == 0 {
+		image = service.defaultImage(persistence != nil)
+	}
+	replicas := int32(1)
+	if spec.Replicas != nil {
+		replicas = *spec.Replicas
+	}
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: service.objectName(platform), Namespace: platform.Namespace}}
+	_, err := controllerutil.CreateOrPatch(ctx, c, deployment, func() error {
+		lbl := service.labels(platform)
+		deployment.Labels = lbl
+		deployment.Spec.Replicas = &replicas
+		deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: lbl}
+		deployment.Spec.Template.Labels = lbl
+		container := corev1.Container{
+			Name:      serviceContainerName,
+			Image:     image,
+			Resources: spec.Resources,
+			Ports: []corev1.ContainerPort{{
+				Name:          serviceHTTPPortName,
+				ContainerPort: serviceHTTPPort,
+				Protocol:      corev1.ProtocolTCP,
+			}},
+			ReadinessProbe: getServiceProbe(serviceHealthPathReady),
+			LivenessProbe:  getServiceProbe(serviceHealthPathLive),
+		}
+		if persistence != nil {
+			userKey, passwordKey := workflowdef.GetPostgreSQLSecretKeys(persistence.PostgreSQL.SecretRef)
+			kubeutil.CreateOrReplaceEnvFromSecret(&container, quarkusDatasourceUsernameEnv, persistence.PostgreSQL.SecretRef.Name, userKey)
+			kubeutil.CreateOrReplaceEnvFromSecret(&container, quarkusDatasourcePasswordEnv, persistence.PostgreSQL.SecretRef.Name, passwordKey)
+			// the services don't support the migration in an init container, the schema is either migrated at startup or not at all
+			migrate := workflowdef.GetPersistenceMigration(persistence) != operatorapi.PersistenceMigrationNone
+			kubeutil.CreateOrReplaceEnv(&container, quarkusFlywayMigrateAtStartEnv, fmt.Sprintf("%t", migrate))
+		}
+		service.configure(platform, &container, jdbcURL)
+		deployment.Spec.Template.Spec.Containers = []corev1.Container{container}
+		return controllerutil.SetControllerReference(platform, deployment, c.GetScheme())
+	})
+	return err
+}
+
+func ensureServiceService(ctx context.Context, c client.Client, platform *operatorapi.KogitoServerlessPlatform, service supportingService) error {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: service.objectName(platform), Namespace: platform.Namespace}}
+	_, err := controllerutil.CreateOrPatch(ctx, c, svc, func() error {
+		lbl := service.labels(platform)
+		svc.Labels = lbl
+		svc.Spec.Selector = lbl
+		svc.Spec.Ports = []corev1.ServicePort{{
+			Name:       serviceHTTPPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       defaultServicePort,
+			TargetPort: intstr.FromInt(serviceHTTPPort),
+		}}
+		return controllerutil.SetControllerReference(platform, svc, c.GetScheme())
+	})
+	return err
+}
+
+func deleteService(ctx context.Context, c client.Client, platform *operatorapi.KogitoServerlessPlatform, service supportingService) error {
+	objectMeta := metav1.ObjectMeta{Name: service.objectName(platform), Namespace: platform.Namespace}
+	for _, object := range []ctrl.Object{&appsv1.Deployment{ObjectMeta: objectMeta}, &corev1.Service{ObjectMeta: objectMeta}} {
+		if err := c.Delete(ctx, object); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func getServiceProbe(path string) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: path,
+				Port: intstr.FromInt(serviceHTTPPort),
+			},
+		},
+		InitialDelaySeconds: 10,
+		PeriodSeconds:       10,
+		TimeoutSeconds:      3,
+	}
+}
+
+// refreshServicesStatus reads the Deployments of the enabled platform services and updates their status
+func refreshServicesStatus(ctx context.Context, c ctrl.Reader, platform *operatorapi.KogitoServerlessPlatform) error {
+	if !HasServices(platform) {
+		platform.Status.Services = nil
+		return nil
+	}
+	if platform.Status.Services == nil {
+		platform.Status.Services = &operatorapi.PlatformServicesStatus{}
+	}
+	for _, service := range supportingServices {
+		status := service.status(platform.Status.Services)
+		if !service.isEnabled(platform) {
+			*status = nil
+			continue
+		}
+		serviceStatus := &operatorapi.PlatformServiceStatus{URL: getServiceURL(platform, service)}
+		deployment := &appsv1.Deployment{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: platform.Namespace, Name: service.objectName(platform)}, deployment); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+			serviceStatus.Message = fmt.Sprintf("deployment %s not found", service.objectName(platform))
+		} else if kubeutil.IsDeploymentAvailable(deployment) {
+			serviceStatus.Ready = true
+		} else if message := kubeutil.GetDeploymentUnavailabilityMessage(deployment); len(message) > 0 {
+			serviceStatus.Message = message
+		} else {
+			serviceStatus.Message = fmt.Sprintf("deployment %s is not available yet", deployment.Name)
+		}
+		*status = serviceStatus
+	}
+	return nil
+}
+
+// areServicesReady checks if every enabled platform service is ready
+func areServicesReady(platform *operatorapi.KogitoServerlessPlatform) bool {
+	for _, service := range supportingServices {
+		if !service.isEnabled(platform) {
+			continue
+		}
+		if platform.Status.Services == nil {
+			return false
+		}
+		if status := *service.status(platform.Status.Services); status == nil || !status.Ready {
+			return false
+		}
+	}
+	return true
+}
@@ -16,13 +16,13 @@ package platform
 
 import (
 	"context"
-	"errors"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kiegroup/kogito-serverless-operator/api"
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 )
 
@@ -54,7 +54,7 @@ func (action *warmAction) Handle(ctx context.Context, platform *operatorapi.Kogi
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: platform.Namespace,
-			Name:      platform.Name + "-cache",
+			Name:      kanikoCacheWarmerPodName(platform),
 		},
 	}
 
@@ -66,10 +66,14 @@ func (action *warmAction) Handle(ctx context.Context, platform *operatorapi.Kogi
 	switch pod.Status.Phase {
 	case corev1.PodSucceeded:
 		action.Logger.Info("Kaniko cache successfully warmed up")
-		platform.Status.Phase = operatorapi.PlatformPhaseCreating
+		platform.Status.Manager().MarkTrue(api.CacheWarmedConditionType)
+		if err = refreshKanikoCacheStatus(ctx, action.reader, platform); err != nil {
+			return nil, err
+		}
 		return platform, nil
 	case corev1.PodFailed:
-		return nil, errors.New("failed to warm up Kaniko cache")
+		platform.Status.Manager().MarkFalse(api.CacheWarmedConditionType, api.CacheWarmingFailedReason, "Failed to warm up Kaniko cache, see the %s pod logs", pod.Name)
+		return platform, nil
 	default:
 		action.Logger.Info("Waiting for Kaniko cache to warm up...")
 		// Requeue
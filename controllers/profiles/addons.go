@@ -0,0 +1,48 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"sort"
+
+	"github.com/magiconair/properties"
+
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
+	"github.com/kiegroup/kogito-serverless-operator/utils"
+)
+
+// getAddonsProperties gets the application properties configuring the given add-ons in the prod profile.
+// The properties of the later add-ons win over the former ones. It returns nil if there are no properties to set.
+func getAddonsProperties(addons []string) *properties.Properties {
+	var props *properties.Properties
+	for _, name := range addons {
+		addon, ok := workflowdef.GetAddon(name)
+		if !ok || len(addon.Properties) == 0 {
+			continue
+		}
+		if props == nil {
+			props = utils.NewProperties()
+		}
+		keys := make([]string, 0, len(addon.Properties))
+		for k := range addon.Properties {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			_, _, _ = props.Set(k, addon.Properties[k])
+		}
+	}
+	return props
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/magiconair/properties"
+
+	"github.com/kiegroup/kogito-serverless-operator/utils"
+)
+
+// propertyStrategy is the ownership of an application property in the workflow properties ConfigMap
+type propertyStrategy string
+
+const (
+	// propertyStrategyEnforced properties are required to run the workflow application, values set by the user are replaced
+	propertyStrategyEnforced propertyStrategy = "enforced"
+	// propertyStrategyOverridable properties are set by the operator unless the user sets the property
+	propertyStrategyOverridable propertyStrategy = "overridable"
+	// propertyStrategyUserOnly properties are never set by the operator.
+	// The user declares them in the metadata.UserPropertiesAnnotation of the workflow properties ConfigMap.
+	propertyStrategyUserOnly propertyStrategy = "user"
+)
+
+// enforcedApplicationProperties are the default application properties the workflow application can't run without.
+// Every other default application property can be overridden by the user.
+var enforcedApplicationProperties = []string{"quarkus.http.port", "quarkus.http.host"}
+
+// managedProperty is an application property set by the operator, as tracked in the metadata.ManagedPropertiesAnnotation
+type managedProperty struct {
+	Strategy propertyStrategy `json:"strategy"`
+	// Value set by the operator, the property is owned by the user once the value changes
+	Value string `json:"value"`
+}
+
+// managedProperties maps the application property keys set by the operator
+type managedProperties map[string]managedProperty
+
+// parseManagedProperties parses the metadata.ManagedPropertiesAnnotation, it returns nil if there's no annotation
+func parseManagedProperties(annotation string) managedProperties {
+	if len(annotation) == 0 {
+		return nil
+	}
+	managed := managedProperties{}
+	if err := json.Unmarshal([]byte(annotation), &managed); err != nil {
+		return managedProperties{}
+	}
+	return managed
+}
+
+func (m managedProperties) String() string {
+	// can't fail, it's a map of strings
+	managed, _ := json.Marshal(m)
+	return string(managed)
+}
+
+// parseUserOnlyProperties parses the comma separated list of property keys owned by the user
+func parseUserOnlyProperties(annotation string) map[string]bool {
+	userOnly := map[string]bool{}
+	for _, key := range strings.Split(annotation, ",") {
+		if key = strings.TrimSpace(key); len(key) > 0 {
+			userOnly[key] = true
+		}
+	}
+	return userOnly
+}
+
+// getPropertyProfileKeys gets the profile-specific variants of the given key, like `%dev.<key>`.
+// See: https://quarkus.io/guides/config-reference#profiles
+func getPropertyProfileKeys(props *properties.Properties, key string) []string {
+	var keys []string
+	for _, k := range props.Keys() {
+		if !strings.HasPrefix(k, "%") {
+			continue
+		}
+		if dot := strings.Index(k, "."); dot > 0 && k[dot+1:] == key {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// applicationPropertiesMerger merges the user application properties with the ones managed by the operator
+type applicationPropertiesMerger struct {
+	// defaults are the operator default application properties, either enforced or overridable
+	defaults *properties.Properties
+	// addons are the application properties configuring the workflow add-ons, always overridable
+	addons *properties.Properties
+	// platform are the application properties from the platform configuration, always overridable
+	platform *properties.Properties
+	// spec are the application properties derived from the workflow spec, like the persistence ones, always enforced
+	spec *properties.Properties
+}
+
+// merge merges the application properties owned by the user with the operator ones, following each property strategy:
+//   - enforced properties replace the user values, and their profile-specific variants are removed;
+//   - overridable properties are only set when the user hasn't set them. The platform properties win over the add-ons
+//     ones, which win over the defaults;
+//   - user only properties are never set.
+//
+// The properties previously set by the operator, as tracked in previous, whose values haven't been changed since are
+// owned by the operator, hence are updated or removed. When there's no previous tracking, like in the properties created
+// with the operator defaults, the default values are considered set by the operator.
+// It returns the merged properties along with the managed ones.
+func (m *applicationPropertiesMerger) merge(user *properties.Properties, previous managedProperties, userOnly map[string]bool) (*properties.Properties, managedProperties) {
+	if previous == nil {
+		previous = managedProperties{}
+		for _, k := range m.defaults.Keys() {
+			v, _ := m.defaults.Get(k)
+			previous[k] = managedProperty{Value: v}
+		}
+	}
+	for _, k := range user.Keys() {
+		v, _ := user.Get(k)
+		if prev, ok := previous[k]; ok && prev.Value == v {
+			user.Delete(k)
+		}
+	}
+
+	enforcedProps := utils.NewProperties()
+	for _, k := range enforcedApplicationProperties {
+		if v, ok := m.defaults.Get(k); ok {
+			_, _, _ = enforcedProps.Set(k, v)
+		}
+	}
+	if m.spec != nil {
+		enforcedProps.Merge(m.spec)
+	}
+	enforced := map[string]bool{}
+	for _, k := range enforcedProps.Keys() {
+		enforced[k] = true
+	}
+
+	overridable := utils.NewProperties()
+	for _, source := range []*properties.Properties{m.defaults, m.addons, m.platform} {
+		if source == nil {
+			continue
+		}
+		for _, k := range source.Keys() {
+			if !enforced[k] {
+				v, _ := source.Get(k)
+				_, _, _ = overridable.Set(k, v)
+			}
+		}
+	}
+
+	result := utils.NewProperties()
+	managed := managedProperties{}
+	for _, k := range overridable.Keys() {
+		if _, set := user.Get(k); set || userOnly[k] {
+			continue
+		}
+		v, _ := overridable.Get(k)
+		_, _, _ = result.Set(k, v)
+		managed[k] = managedProperty{Strategy: propertyStrategyOverridable, Value: v}
+	}
+	result.Merge(user)
+	for _, k := range enforcedProps.Keys() {
+		for _, profileKey := range getPropertyProfileKeys(result, k) {
+			result.Delete(profileKey)
+		}
+		v, _ := enforcedProps.Get(k)
+		_, _, _ = result.Set(k, v)
+		managed[k] = managedProperty{Strategy: propertyStrategyEnforced, Value: v}
+	}
+	return result, managed
+}
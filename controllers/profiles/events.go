@@ -0,0 +1,82 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kiegroup/kogito-serverless-operator/api"
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+// deploymentFailureReasons Running condition reasons meaning that the workflow deployment has failed
+var deploymentFailureReasons = map[string]bool{
+	api.DeploymentFailureReason:     true,
+	api.DeploymentUnavailableReason: true,
+	api.RedeploymentExhaustedReason: true,
+	api.DevModeBuildErrorReason:     true,
+	api.NetworkConfigInvalidReason:  true,
+}
+
+// imageRejectedReasons Built condition reasons meaning that the promoted or prebuilt image can't be deployed
+var imageRejectedReasons = map[string]bool{
+	api.ImagePromotionFailedReason: true,
+	api.PrebuiltImageInvalidReason: true,
+	api.ImageSourceConflictReason:  true,
+}
+
+// recordTransitionEvents emits the Events matching the transitions of the workflow conditions since the given previous status.
+func recordTransitionEvents(recorder record.EventRecorder, previous *operatorapi.KogitoServerlessWorkflowStatus, workflow *operatorapi.KogitoServerlessWorkflow) {
+	if built := workflow.Status.GetCondition(api.BuiltConditionType); hasTransitioned(previous.GetCondition(api.BuiltConditionType), built) {
+		switch {
+		case built.IsTrue() && built.Reason == api.ImagePromotedReason:
+			recorder.Event(workflow, corev1.EventTypeNormal, api.ImagePromotedEventReason, messageOrDefault(built, "Workflow image promoted"))
+		case built.IsTrue() && built.Reason == api.PrebuiltImageValidatedReason:
+			// nothing was built, the Deployed event follows
+		case built.IsTrue():
+			recorder.Event(workflow, corev1.EventTypeNormal, api.BuildSucceededEventReason, "Workflow image built")
+		case built.IsFalse() && imageRejectedReasons[built.Reason]:
+			recorder.Event(workflow, corev1.EventTypeWarning, api.ImageRejectedEventReason, messageOrDefault(built, "Workflow image rejected"))
+		case built.IsFalse() && built.Reason == api.BuildIsRunningReason:
+			recorder.Event(workflow, corev1.EventTypeNormal, api.BuildStartedEventReason, "Workflow image build started")
+		case built.IsFalse() && (built.Reason == api.BuildFailedReason || built.Reason == api.BuildConfigInvalidReason):
+			recorder.Event(workflow, corev1.EventTypeWarning, api.BuildFailedEventReason, messageOrDefault(built, "Workflow image build failed"))
+		}
+	}
+	if running := workflow.Status.GetCondition(api.RunningConditionType); hasTransitioned(previous.GetCondition(api.RunningConditionType), running) {
+		switch {
+		case running.IsTrue():
+			recorder.Event(workflow, corev1.EventTypeNormal, api.DeployedEventReason, "Workflow deployed")
+		case running.IsFalse() && deploymentFailureReasons[running.Reason]:
+			recorder.Event(workflow, corev1.EventTypeWarning, api.DeploymentFailedEventReason, messageOrDefault(running, "Workflow deployment failed"))
+		}
+	}
+}
+
+// hasTransitioned checks if the condition status or reason has changed
+func hasTransitioned(previous, current *api.Condition) bool {
+	if current == nil {
+		return false
+	}
+	return previous == nil || previous.Status != current.Status || previous.Reason != current.Reason
+}
+
+func messageOrDefault(condition *api.Condition, defaultMessage string) string {
+	if len(condition.Message) > 0 {
+		return condition.Message
+	}
+	return defaultMessage
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kiegroup/kogito-serverless-operator/api"
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/test"
+)
+
+func Test_recordTransitionEvents(t *testing.T) {
+	tests := []struct {
+		name       string
+		transition func(manager api.ConditionsManager)
+		events     []string
+	}{
+		{"build started", func(m api.ConditionsManager) {
+			m.MarkFalse(api.BuiltConditionType, api.BuildIsRunningReason, "")
+			m.MarkFalse(api.RunningConditionType, api.WaitingForBuildReason, "")
+		}, []string{"Normal BuildStarted Workflow image build started"}},
+		{"build failed", func(m api.ConditionsManager) {
+			m.MarkFalse(api.BuiltConditionType, api.BuildFailedReason, "missing base image")
+		}, []string{"Warning BuildFailed missing base image"}},
+		{"build configuration invalid", func(m api.ConditionsManager) {
+			m.MarkFalse(api.BuiltConditionType, api.BuildConfigInvalidReason, "image attestation isn't supported by multi-platform builds")
+			m.MarkFalse(api.RunningConditionType, api.WaitingForBuildReason, "")
+		}, []string{"Warning BuildFailed image attestation isn't supported by multi-platform builds"}},
+		{"built and deployed", func(m api.ConditionsManager) {
+			m.MarkTrue(api.BuiltConditionType)
+			m.MarkTrue(api.RunningConditionType)
+		}, []string{"Normal BuildSucceeded Workflow image built", "Normal Deployed Workflow deployed"}},
+		{"image promoted and deployed", func(m api.ConditionsManager) {
+			m.MarkTrueWithReason(api.BuiltConditionType, api.ImagePromotedReason, "Image promoted from quay.io/dev/greeting@sha256:1234")
+			m.MarkTrue(api.RunningConditionType)
+		}, []string{"Normal ImagePromoted Image promoted from quay.io/dev/greeting@sha256:1234", "Normal Deployed Workflow deployed"}},
+		{"prebuilt image deployed", func(m api.ConditionsManager) {
+			m.MarkTrueWithReason(api.BuiltConditionType, api.PrebuiltImageValidatedReason, "Prebuilt image quay.io/ci/greeting:1.0 found")
+			m.MarkTrue(api.RunningConditionType)
+		}, []string{"Normal Deployed Workflow deployed"}},
+		{"prebuilt image rejected", func(m api.ConditionsManager) {
+			m.MarkFalse(api.BuiltConditionType, api.PrebuiltImageInvalidReason, "image not found")
+		}, []string{"Warning ImageRejected image not found"}},
+		{"deployment failed", func(m api.ConditionsManager) {
+			m.MarkFalse(api.RunningConditionType, api.DeploymentUnavailableReason, "")
+		}, []string{"Warning DeploymentFailed Workflow deployment failed"}},
+		{"network configuration invalid", func(m api.ConditionsManager) {
+			m.MarkFalse(api.RunningConditionType, api.NetworkConfigInvalidReason, "the route network mode requires OpenShift")
+		}, []string{"Warning DeploymentFailed the route network mode requires OpenShift"}},
+		{"waiting for deployment", func(m api.ConditionsManager) {
+			m.MarkFalse(api.RunningConditionType, api.WaitingForDeploymentReason, "")
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+			workflow.Status.Manager().InitializeConditions()
+			previous := workflow.Status.DeepCopy()
+			tt.transition(workflow.Status.Manager())
+
+			recorder := record.NewFakeRecorder(10)
+			recordTransitionEvents(recorder, previous, workflow)
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			assert.Equal(t, tt.events, events)
+
+			// no transition, no events
+			recorder = record.NewFakeRecorder(10)
+			recordTransitionEvents(recorder, workflow.Status.DeepCopy(), workflow)
+			assert.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func Test_recordTransitionEvents_unchangedFailure(t *testing.T) {
+	workflow := &operatorapi.KogitoServerlessWorkflow{}
+	workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentFailureReason, "crash loop")
+	previous := workflow.Status.DeepCopy()
+	workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentFailureReason, "crash loop back-off")
+
+	recorder := record.NewFakeRecorder(10)
+	recordTransitionEvents(recorder, previous, workflow)
+	assert.Empty(t, recorder.Events)
+}
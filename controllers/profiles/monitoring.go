@@ -0,0 +1,198 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+// quarkusMetricsPath is where the workflow application exposes its metrics in the Prometheus format.
+// See: https://quarkus.io/guides/micrometer
+const quarkusMetricsPath = "/q/metrics"
+
+// newMonitoringObjectEnsurers see monitoringObjectEnsurers.
+func newMonitoringObjectEnsurers(support *stateSupport) *monitoringObjectEnsurers {
+	return &monitoringObjectEnsurers{
+		client:         support.client,
+		logger:         support.logger,
+		serviceMonitor: newDefaultObjectEnsurer(support.client, support.logger, serviceMonitorCreator),
+		podMonitor:     newDefaultObjectEnsurer(support.client, support.logger, podMonitorCreator),
+	}
+}
+
+// monitoringObjectEnsurers ensures the Prometheus Operator object scraping the workflow application metrics based on the
+// operatorapi.MonitoringType. Objects not required anymore are removed.
+type monitoringObjectEnsurers struct {
+	client         client.Client
+	logger         *logr.Logger
+	serviceMonitor ObjectEnsurer
+	podMonitor     ObjectEnsurer
+}
+
+// ensure creates the object scraping the workflow application metrics, or none if the monitoring is nil.
+// If the Prometheus Operator isn't installed in the cluster, no object is created.
+func (m *monitoringObjectEnsurers) ensure(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow, monitoring *operatorapi.MonitoringSpec) (client.Object, error) {
+	var object client.Object
+	var err error
+	if monitoring != nil {
+		switch workflowdef.GetMonitoringType(monitoring) {
+		case operatorapi.MonitoringTypePodMonitor:
+			object, _, err = m.podMonitor.ensure(ctx, workflow, podMonitorMutateVisitor(workflow, monitoring))
+		default:
+			object, _, err = m.serviceMonitor.ensure(ctx, workflow, serviceMonitorMutateVisitor(workflow, monitoring))
+		}
+	}
+	if err != nil {
+		if !isNotInstalledError(err) {
+			return nil, err
+		}
+		m.logger.Info("Prometheus Operator not installed in the cluster, the workflow application metrics won't be scraped", "workflow", workflow.Name)
+		object = nil
+	}
+	if err = m.removeStaleObjects(ctx, workflow, object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// removeStaleObjects deletes the monitoring objects owned by the workflow that are not the current one.
+func (m *monitoringObjectEnsurers) removeStaleObjects(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow, current client.Object) error {
+	for _, candidate := range []client.Object{&monitoringv1.ServiceMonitor{}, &monitoringv1.PodMonitor{}} {
+		if current != nil && fmt.Sprintf("%T", current) == fmt.Sprintf("%T", candidate) {
+			continue
+		}
+		if err := m.client.Get(ctx, client.ObjectKeyFromObject(workflow), candidate); err != nil {
+			if errors.IsNotFound(err) || isNotInstalledError(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(candidate, workflow) {
+			continue
+		}
+		m.logger.Info("Removing monitoring object not required anymore", "name", candidate.GetName(), "namespace", candidate.GetNamespace())
+		if err := m.client.Delete(ctx, candidate); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// isNotInstalledError checks if the error is due to the object kind not being known by the cluster or the operator
+func isNotInstalledError(err error) bool {
+	return meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err)
+}
+
+// serviceMonitorCreator is an objectCreator for a ServiceMonitor scraping the workflow application metrics through its Service.
+// See: https://prometheus-operator.dev/docs/operator/api/#monitoring.coreos.com/v1.ServiceMonitor
+func serviceMonitorCreator(workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
+	lbl := workflowdef.GetDefaultLabels(workflow)
+	serviceMonitor := &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflow.Name,
+			Namespace: workflow.Namespace,
+			Labels:    lbl,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{MatchLabels: lbl},
+			Endpoints: []monitoringv1.Endpoint{{
+				TargetPort: &defaultHTTPWorkflowPortIntStr,
+				Path:       quarkusMetricsPath,
+			}},
+		},
+	}
+	return serviceMonitor, nil
+}
+
+// serviceMonitorMutateVisitor guarantees the ServiceMonitor labels and scrape interval based on the given monitoring configuration.
+func serviceMonitorMutateVisitor(workflow *operatorapi.KogitoServerlessWorkflow, monitoring *operatorapi.MonitoringSpec) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			original, err := serviceMonitorCreator(workflow)
+			if err != nil {
+				return err
+			}
+			serviceMonitor := object.(*monitoringv1.ServiceMonitor)
+			serviceMonitor.Labels = getMonitoringLabels(workflow, monitoring)
+			serviceMonitor.Spec = original.(*monitoringv1.ServiceMonitor).Spec
+			serviceMonitor.Spec.Endpoints[0].Interval = monitoring.Interval
+			return nil
+		}
+	}
+}
+
+// podMonitorCreator is an objectCreator for a PodMonitor scraping the workflow application metrics directly from its Pods.
+// See: https://prometheus-operator.dev/docs/operator/api/#monitoring.coreos.com/v1.PodMonitor
+func podMonitorCreator(workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
+	lbl := workflowdef.GetDefaultLabels(workflow)
+	podMonitor := &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflow.Name,
+			Namespace: workflow.Namespace,
+			Labels:    lbl,
+		},
+		Spec: monitoringv1.PodMonitorSpec{
+			Selector: metav1.LabelSelector{MatchLabels: lbl},
+			PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{{
+				TargetPort: &defaultHTTPWorkflowPortIntStr,
+				Path:       quarkusMetricsPath,
+			}},
+		},
+	}
+	return podMonitor, nil
+}
+
+// podMonitorMutateVisitor guarantees the PodMonitor labels and scrape interval based on the given monitoring configuration.
+func podMonitorMutateVisitor(workflow *operatorapi.KogitoServerlessWorkflow, monitoring *operatorapi.MonitoringSpec) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			original, err := podMonitorCreator(workflow)
+			if err != nil {
+				return err
+			}
+			podMonitor := object.(*monitoringv1.PodMonitor)
+			podMonitor.Labels = getMonitoringLabels(workflow, monitoring)
+			podMonitor.Spec = original.(*monitoringv1.PodMonitor).Spec
+			podMonitor.Spec.PodMetricsEndpoints[0].Interval = monitoring.Interval
+			return nil
+		}
+	}
+}
+
+// getMonitoringLabels gets the labels of the monitoring object, the workflow default labels can't be overridden.
+func getMonitoringLabels(workflow *operatorapi.KogitoServerlessWorkflow, monitoring *operatorapi.MonitoringSpec) map[string]string {
+	lbl := make(map[string]string, len(monitoring.Labels)+1)
+	for k, v := range monitoring.Labels {
+		lbl[k] = v
+	}
+	for k, v := range workflowdef.GetDefaultLabels(workflow) {
+		lbl[k] = v
+	}
+	return lbl
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/test"
+)
+
+func Test_monitoringObjectEnsurers(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	cli := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow).Build()
+	ensurers := newMonitoringObjectEnsurers(&stateSupport{client: cli, logger: &logger})
+
+	monitoring := &operatorapi.MonitoringSpec{Enabled: true, Interval: "30s", Labels: map[string]string{"release": "prometheus"}}
+	object, err := ensurers.ensure(context.TODO(), workflow, monitoring)
+	assert.NoError(t, err)
+	serviceMonitor := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, cli.Get(context.TODO(), client.ObjectKeyFromObject(workflow), serviceMonitor))
+	assert.IsType(t, &monitoringv1.ServiceMonitor{}, object)
+	assert.Equal(t, "prometheus", serviceMonitor.Labels["release"])
+	assert.Equal(t, workflow.Name, serviceMonitor.Spec.Selector.MatchLabels["app"])
+	assert.Equal(t, quarkusMetricsPath, serviceMonitor.Spec.Endpoints[0].Path)
+	assert.Equal(t, "30s", serviceMonitor.Spec.Endpoints[0].Interval)
+
+	monitoring.Type = operatorapi.MonitoringTypePodMonitor
+	object, err = ensurers.ensure(context.TODO(), workflow, monitoring)
+	assert.NoError(t, err)
+	assert.IsType(t, &monitoringv1.PodMonitor{}, object)
+	podMonitor := &monitoringv1.PodMonitor{}
+	assert.NoError(t, cli.Get(context.TODO(), client.ObjectKeyFromObject(workflow), podMonitor))
+	assert.Equal(t, quarkusMetricsPath, podMonitor.Spec.PodMetricsEndpoints[0].Path)
+	assert.True(t, errors.IsNotFound(cli.Get(context.TODO(), client.ObjectKeyFromObject(workflow), &monitoringv1.ServiceMonitor{})))
+
+	object, err = ensurers.ensure(context.TODO(), workflow, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, object)
+	assert.True(t, errors.IsNotFound(cli.Get(context.TODO(), client.ObjectKeyFromObject(workflow), &monitoringv1.PodMonitor{})))
+}
@@ -0,0 +1,461 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	openshiftv1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/network"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/go-logr/logr"
+
+	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
+	"github.com/kiegroup/kogito-serverless-operator/utils"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+const (
+	defaultIngressPath = "/"
+	// caCertificateKey is the conventional key for the CA certificate in TLS Secrets
+	caCertificateKey = "ca.crt"
+)
+
+// errNetworkConfigInvalid is returned when the workflow application can't be exposed in this cluster with the given network configuration
+var errNetworkConfigInvalid = errors.New("invalid network configuration")
+
+// isNetworkConfigInvalid checks if the given error is caused by an invalid network configuration, see errNetworkConfigInvalid
+func isNetworkConfigInvalid(err error) bool {
+	return errors.Is(err, errNetworkConfigInvalid)
+}
+
+// getWorkflowNetworkSpec gets the network configuration for the given workflow.
+// The workflow configuration takes precedence over the given platform one. Platform can be nil.
+func getWorkflowNetworkSpec(workflow *operatorapi.KogitoServerlessWorkflow, pl *operatorapi.KogitoServerlessPlatform) *operatorapi.NetworkSpec {
+	if workflow.Spec.Network != nil {
+		return workflow.Spec.Network
+	}
+	if pl != nil && pl.Spec.Network != nil {
+		return pl.Spec.Network
+	}
+	return &operatorapi.NetworkSpec{}
+}
+
+// fetchWorkflowNetworkSpec same as getWorkflowNetworkSpec, but fetching the platform of the workflow.
+func fetchWorkflowNetworkSpec(ctx context.Context, c client.Client, workflow *operatorapi.KogitoServerlessWorkflow) *operatorapi.NetworkSpec {
+	if workflow.Spec.Network != nil {
+		return workflow.Spec.Network
+	}
+	// the platform is optional here, a nil platform means the default network configuration
+	pl, _ := platform.GetWorkflowPlatform(ctx, c, workflow)
+	return getWorkflowNetworkSpec(workflow, pl)
+}
+
+// getNetworkMode gets the effective operatorapi.NetworkMode for the given network configuration.
+// Routes are the default on OpenShift, on Kubernetes the default is to rely on the workflow Service only.
+func getNetworkMode(networkSpec *operatorapi.NetworkSpec) operatorapi.NetworkMode {
+	if len(networkSpec.Mode) > 0 {
+		return networkSpec.Mode
+	}
+	if utils.IsOpenShift() {
+		return operatorapi.NetworkModeRoute
+	}
+	return ""
+}
+
+// newNetworkObjectEnsurers see networkObjectEnsurers.
+func newNetworkObjectEnsurers(support *stateSupport) *networkObjectEnsurers {
+	return &networkObjectEnsurers{
+		client:    support.client,
+		logger:    support.logger,
+		route:     newDefaultObjectEnsurer(support.client, support.logger, defaultNetworkCreator),
+		ingress:   newDefaultObjectEnsurer(support.client, support.logger, ingressCreator),
+		httpRoute: newDefaultObjectEnsurer(support.client, support.logger, httpRouteCreator),
+	}
+}
+
+// networkObjectEnsurers ensures the object that exposes the workflow application outside the cluster based on the operatorapi.NetworkMode.
+// Objects created for a previous network mode are removed.
+type networkObjectEnsurers struct {
+	client    client.Client
+	logger    *logr.Logger
+	route     ObjectEnsurer
+	ingress   ObjectEnsurer
+	httpRoute ObjectEnsurer
+}
+
+func (n *networkObjectEnsurers) ensure(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow, networkSpec *operatorapi.NetworkSpec) (client.Object, error) {
+	var object client.Object
+	var err error
+	switch getNetworkMode(networkSpec) {
+	case operatorapi.NetworkModeIngress:
+		object, _, err = n.ingress.ensure(ctx, workflow, ingressMutateVisitor(workflow, networkSpec))
+	case operatorapi.NetworkModeGateway:
+		if networkSpec.Gateway == nil || len(networkSpec.Gateway.Name) == 0 {
+			return nil, fmt.Errorf("%w: the gateway network mode requires a Gateway to attach the HTTPRoute to", errNetworkConfigInvalid)
+		}
+		object, _, err = n.httpRoute.ensure(ctx, workflow, httpRouteMutateVisitor(workflow, networkSpec))
+	case operatorapi.NetworkModeRoute:
+		var tls *openshiftv1.TLSConfig
+		if tls, err = n.getRouteTLSConfig(ctx, workflow, networkSpec); err != nil {
+			return nil, err
+		}
+		object, _, err = n.route.ensure(ctx, workflow, routeMutateVisitor(workflow, networkSpec, tls))
+		// the route.openshift.io API is only served by OpenShift clusters
+		if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return nil, fmt.Errorf("%w: the route network mode requires OpenShift: %v", errNetworkConfigInvalid, err)
+		}
+	default:
+		// the workflow Service is enough
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = n.removeStaleObjects(ctx, workflow, object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// removeStaleObjects deletes the network objects owned by the workflow that are not the current one.
+func (n *networkObjectEnsurers) removeStaleObjects(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow, current client.Object) error {
+	candidates := []client.Object{&networkingv1.Ingress{}, &gwapi.HTTPRoute{}}
+	if utils.IsOpenShift() {
+		candidates = append(candidates, &openshiftv1.Route{})
+	}
+	var currentGVK schema.GroupVersionKind
+	if current != nil {
+		var err error
+		if currentGVK, err = apiutil.GVKForObject(current, n.client.Scheme()); err != nil {
+			return err
+		}
+	}
+	for _, candidate := range candidates {
+		// the candidates unknown by the scheme are skipped below, when fetched
+		if gvk, err := apiutil.GVKForObject(candidate, n.client.Scheme()); err == nil && gvk == currentGVK {
+			continue
+		}
+		if err := n.client.Get(ctx, client.ObjectKeyFromObject(workflow), candidate); err != nil {
+			// the Gateway API might not be installed in the cluster
+			if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(candidate, workflow) {
+			continue
+		}
+		n.logger.Info("Removing network object not required anymore", "name", candidate.GetName(), "namespace", candidate.GetNamespace())
+		if err := n.client.Delete(ctx, candidate); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRouteTLSConfig reads the certificates referenced by the given network configuration to build the Route TLS configuration.
+func (n *networkObjectEnsurers) getRouteTLSConfig(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow, networkSpec *operatorapi.NetworkSpec) (*openshiftv1.TLSConfig, error) {
+	if networkSpec.TLS == nil {
+		return nil, nil
+	}
+	termination := networkSpec.TLS.Termination
+	if len(termination) == 0 {
+		termination = operatorapi.RouteTLSTerminationEdge
+	}
+	tls := &openshiftv1.TLSConfig{Termination: openshiftv1.TLSTerminationType(termination)}
+	if termination != operatorapi.RouteTLSTerminationPassthrough && len(networkSpec.TLS.SecretName) > 0 {
+		secret := &corev1.Secret{}
+		if err := n.client.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: networkSpec.TLS.SecretName}, secret); err != nil {
+			return nil, err
+		}
+		tls.Certificate = string(secret.Data[corev1.TLSCertKey])
+		tls.Key = string(secret.Data[corev1.TLSPrivateKeyKey])
+		tls.CACertificate = string(secret.Data[caCertificateKey])
+	}
+	if termination == operatorapi.RouteTLSTerminationReencrypt && len(networkSpec.TLS.DestinationCASecretName) > 0 {
+		secret := &corev1.Secret{}
+		if err := n.client.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: networkSpec.TLS.DestinationCASecretName}, secret); err != nil {
+			return nil, err
+		}
+		tls.DestinationCACertificate = string(secret.Data[caCertificateKey])
+	}
+	return tls, nil
+}
+
+// IsSecretReferencedByRouteTLS verifies whether the given Secret name holds certificates copied to the workflow Route.
+// Unlike Ingresses, Routes embed the certificates, so the workflow must be reconciled when the Secret changes.
+func IsSecretReferencedByRouteTLS(ctx context.Context, c client.Client, workflow *operatorapi.KogitoServerlessWorkflow, secretName string) bool {
+	networkSpec := fetchWorkflowNetworkSpec(ctx, c, workflow)
+	if getNetworkMode(networkSpec) != operatorapi.NetworkModeRoute || networkSpec.TLS == nil {
+		return false
+	}
+	return networkSpec.TLS.SecretName == secretName || networkSpec.TLS.DestinationCASecretName == secretName
+}
+
+// routeMutateVisitor guarantees the Route host, path, TLS and annotations based on the given network configuration.
+// The host generated by the OpenShift router is kept if none is configured.
+func routeMutateVisitor(workflow *operatorapi.KogitoServerlessWorkflow, networkSpec *operatorapi.NetworkSpec, tls *openshiftv1.TLSConfig) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			original, err := defaultNetworkCreator(workflow)
+			if err != nil {
+				return err
+			}
+			route := object.(*openshiftv1.Route)
+			route.Labels = original.GetLabels()
+			route.Spec.To = original.(*openshiftv1.Route).Spec.To
+			if len(networkSpec.Host) > 0 {
+				route.Spec.Host = networkSpec.Host
+			}
+			route.Spec.Path = networkSpec.Path
+			route.Spec.TLS = tls
+			addNetworkAnnotations(route, networkSpec)
+			return nil
+		}
+	}
+}
+
+// addNetworkAnnotations adds the annotations from the given network configuration, keeping the ones set by others.
+func addNetworkAnnotations(object client.Object, networkSpec *operatorapi.NetworkSpec) {
+	if len(networkSpec.Annotations) == 0 {
+		return
+	}
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, len(networkSpec.Annotations))
+	}
+	for k, v := range networkSpec.Annotations {
+		annotations[k] = v
+	}
+	object.SetAnnotations(annotations)
+}
+
+// ingressCreator is an objectCreator for a basic Ingress routing every request to the workflow Service.
+func ingressCreator(workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflow.Name,
+			Namespace: workflow.Namespace,
+			Labels:    workflowdef.GetDefaultLabels(workflow),
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     defaultIngressPath,
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: workflow.Name,
+									Port: networkingv1.ServiceBackendPort{Number: defaultHTTPServicePort},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+	return ingress, nil
+}
+
+// ingressMutateVisitor guarantees the Ingress host, class and TLS configuration based on the given network configuration.
+func ingressMutateVisitor(workflow *operatorapi.KogitoServerlessWorkflow, networkSpec *operatorapi.NetworkSpec) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			original, err := ingressCreator(workflow)
+			if err != nil {
+				return err
+			}
+			ingress := object.(*networkingv1.Ingress)
+			ingress.Labels = original.GetLabels()
+			ingress.Spec.Rules = original.(*networkingv1.Ingress).Spec.Rules
+			ingress.Spec.Rules[0].Host = networkSpec.Host
+			if len(networkSpec.Path) > 0 {
+				ingress.Spec.Rules[0].HTTP.Paths[0].Path = networkSpec.Path
+			}
+			addNetworkAnnotations(ingress, networkSpec)
+			ingress.Spec.IngressClassName = networkSpec.IngressClassName
+			ingress.Spec.TLS = nil
+			if networkSpec.TLS != nil {
+				ingress.Spec.TLS = []networkingv1.IngressTLS{{SecretName: networkSpec.TLS.SecretName}}
+				if len(networkSpec.Host) > 0 {
+					ingress.Spec.TLS[0].Hosts = []string{networkSpec.Host}
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// httpRouteCreator is an objectCreator for a basic Gateway API HTTPRoute routing every request to the workflow Service.
+// See: https://gateway-api.sigs.k8s.io/api-types/httproute/
+func httpRouteCreator(workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
+	port := gwapi.PortNumber(defaultHTTPServicePort)
+	route := &gwapi.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflow.Name,
+			Namespace: workflow.Namespace,
+			Labels:    workflowdef.GetDefaultLabels(workflow),
+		},
+		Spec: gwapi.HTTPRouteSpec{
+			Rules: []gwapi.HTTPRouteRule{{
+				BackendRefs: []gwapi.HTTPBackendRef{{
+					BackendRef: gwapi.BackendRef{
+						BackendObjectReference: gwapi.BackendObjectReference{
+							Name: gwapi.ObjectName(workflow.Name),
+							Port: &port,
+						},
+					},
+				}},
+			}},
+		},
+	}
+	return route, nil
+}
+
+// httpRouteMutateVisitor guarantees the HTTPRoute parent Gateway and hostname based on the given network configuration.
+func httpRouteMutateVisitor(workflow *operatorapi.KogitoServerlessWorkflow, networkSpec *operatorapi.NetworkSpec) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			original, err := httpRouteCreator(workflow)
+			if err != nil {
+				return err
+			}
+			route := object.(*gwapi.HTTPRoute)
+			route.Labels = original.GetLabels()
+			route.Spec.Rules = original.(*gwapi.HTTPRoute).Spec.Rules
+			if len(networkSpec.Path) > 0 {
+				pathType := gwapi.PathMatchPathPrefix
+				path := networkSpec.Path
+				route.Spec.Rules[0].Matches = []gwapi.HTTPRouteMatch{{Path: &gwapi.HTTPPathMatch{Type: &pathType, Value: &path}}}
+			}
+			addNetworkAnnotations(route, networkSpec)
+			route.Spec.Hostnames = nil
+			if len(networkSpec.Host) > 0 {
+				route.Spec.Hostnames = []gwapi.Hostname{gwapi.Hostname(networkSpec.Host)}
+			}
+			route.Spec.ParentRefs = nil
+			if networkSpec.Gateway != nil {
+				parent := gwapi.ParentReference{Name: gwapi.ObjectName(networkSpec.Gateway.Name)}
+				if len(networkSpec.Gateway.Namespace) > 0 {
+					namespace := gwapi.Namespace(networkSpec.Gateway.Namespace)
+					parent.Namespace = &namespace
+				}
+				if len(networkSpec.Gateway.SectionName) > 0 {
+					sectionName := gwapi.SectionName(networkSpec.Gateway.SectionName)
+					parent.SectionName = &sectionName
+				}
+				route.Spec.ParentRefs = []gwapi.ParentReference{parent}
+			}
+			return nil
+		}
+	}
+}
+
+// networkServiceMutateVisitor sets the workflow Service type according to the network mode: a NodePort in the nodePort mode,
+// or in the dev profile without any mode so developers can reach the workflow, a ClusterIP otherwise.
+func networkServiceMutateVisitor(workflow *operatorapi.KogitoServerlessWorkflow, networkSpec *operatorapi.NetworkSpec) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			service := object.(*corev1.Service)
+			if mode := getNetworkMode(networkSpec); mode == operatorapi.NetworkModeNodePort || (len(mode) == 0 && IsDevProfile(workflow)) {
+				service.Spec.Type = corev1.ServiceTypeNodePort
+				return nil
+			}
+			service.Spec.Type = corev1.ServiceTypeClusterIP
+			// node ports can't be set on ClusterIP Services
+			for i := range service.Spec.Ports {
+				service.Spec.Ports[i].NodePort = 0
+			}
+			return nil
+		}
+	}
+}
+
+// networkStatusEnricher enriches the workflow status with the address and the endpoint where the workflow application is reachable.
+func networkStatusEnricher(ctx context.Context, c client.Client, workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
+	workflow.Status.Address = duckv1.Addressable{
+		URL: apis.HTTP(network.GetServiceHostname(workflow.Name, workflow.Namespace)),
+	}
+	networkSpec := fetchWorkflowNetworkSpec(ctx, c, workflow)
+	switch getNetworkMode(networkSpec) {
+	case operatorapi.NetworkModeIngress:
+		ingress := &networkingv1.Ingress{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(workflow), ingress); err != nil {
+			return nil, err
+		}
+		workflow.Status.Endpoint = getNetworkEndpoint(networkSpec, ingressHost(ingress))
+	case operatorapi.NetworkModeGateway:
+		workflow.Status.Endpoint = getNetworkEndpoint(networkSpec, networkSpec.Host)
+	case operatorapi.NetworkModeRoute:
+		return routeStatusEnricher(ctx, c, workflow)
+	case operatorapi.NetworkModeNone:
+		workflow.Status.Endpoint = nil
+	default:
+		return defaultDevStatusEnricher(ctx, c, workflow)
+	}
+	return workflow, nil
+}
+
+// ingressHost gets the host the Ingress is reachable from, either the configured one or the load balancer one.
+func ingressHost(ingress *networkingv1.Ingress) string {
+	if len(ingress.Spec.Rules) > 0 && len(ingress.Spec.Rules[0].Host) > 0 {
+		return ingress.Spec.Rules[0].Host
+	}
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if len(lb.Hostname) > 0 {
+			return lb.Hostname
+		}
+		if len(lb.IP) > 0 {
+			return lb.IP
+		}
+	}
+	return ""
+}
+
+func getNetworkEndpoint(networkSpec *operatorapi.NetworkSpec, host string) *apis.URL {
+	if len(host) == 0 {
+		return nil
+	}
+	var url *apis.URL
+	if networkSpec.TLS != nil {
+		url = apis.HTTPS(host)
+	} else {
+		url = apis.HTTP(host)
+	}
+	url.Path = networkSpec.Path
+	return url
+}
@@ -0,0 +1,278 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"context"
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/network"
+	clientruntime "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiegroup/kogito-serverless-operator/api"
+	"github.com/kiegroup/kogito-serverless-operator/test"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+func Test_networkObjectEnsurersSwitchingModes(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.Network = &operatorapi.NetworkSpec{Mode: operatorapi.NetworkModeNodePort}
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow).Build()
+	ensurers := newNetworkObjectEnsurers(fakeReconcilerSupport(client))
+	serviceEnsurer := newDefaultObjectEnsurer(client, fakeReconcilerSupport(client).logger, defaultServiceCreator)
+	serviceType := func() corev1.ServiceType {
+		service, _, err := serviceEnsurer.ensure(context.TODO(), workflow, defaultServiceMutateVisitor(workflow), networkServiceMutateVisitor(workflow, workflow.Spec.Network))
+		assert.NoError(t, err)
+		return service.(*corev1.Service).Spec.Type
+	}
+	assert.Equal(t, corev1.ServiceTypeNodePort, serviceType())
+
+	ingressClass := "nginx"
+	workflow.Spec.Network = &operatorapi.NetworkSpec{
+		Mode:             operatorapi.NetworkModeIngress,
+		Host:             "greeting.example.com",
+		TLS:              &operatorapi.NetworkTLSSpec{SecretName: "greeting-tls"},
+		IngressClassName: &ingressClass,
+	}
+	object, err := ensurers.ensure(context.TODO(), workflow, workflow.Spec.Network)
+	assert.NoError(t, err)
+	assert.IsType(t, &networkingv1.Ingress{}, object)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, serviceType())
+
+	ingress := test.MustGetIngress(t, client, workflow)
+	assert.Equal(t, "greeting.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, workflow.Name, ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+	assert.Equal(t, "greeting-tls", ingress.Spec.TLS[0].SecretName)
+	assert.Equal(t, []string{"greeting.example.com"}, ingress.Spec.TLS[0].Hosts)
+
+	workflow.Spec.Network = &operatorapi.NetworkSpec{
+		Mode:    operatorapi.NetworkModeGateway,
+		Host:    "greeting.example.com",
+		Gateway: &operatorapi.GatewayReference{Name: "public", Namespace: "infra", SectionName: "https"},
+	}
+	_, err = ensurers.ensure(context.TODO(), workflow, workflow.Spec.Network)
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, serviceType())
+
+	route := test.MustGetHTTPRoute(t, client, workflow)
+	assert.Equal(t, "greeting.example.com", string(route.Spec.Hostnames[0]))
+	assert.Equal(t, "public", string(route.Spec.ParentRefs[0].Name))
+	assert.Equal(t, "infra", string(*route.Spec.ParentRefs[0].Namespace))
+	assert.Equal(t, "https", string(*route.Spec.ParentRefs[0].SectionName))
+	assert.Equal(t, workflow.Name, string(route.Spec.Rules[0].BackendRefs[0].Name))
+
+	// the Ingress from the previous mode must be gone
+	err = client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), &networkingv1.Ingress{})
+	assert.True(t, errors.IsNotFound(err))
+
+	workflow.Spec.Network = &operatorapi.NetworkSpec{Mode: operatorapi.NetworkModeNone}
+	object, err = ensurers.ensure(context.TODO(), workflow, workflow.Spec.Network)
+	assert.NoError(t, err)
+	assert.Nil(t, object)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, serviceType())
+	err = client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), route)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func Test_networkServiceMutateVisitorInDevMode(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	service, _ := devServiceCreator(workflow)
+
+	// developers reach the workflow through a NodePort unless a network mode is set
+	assert.NoError(t, networkServiceMutateVisitor(workflow, &operatorapi.NetworkSpec{})(service)())
+	assert.Equal(t, corev1.ServiceTypeNodePort, service.(*corev1.Service).Spec.Type)
+	service.(*corev1.Service).Spec.Ports[0].NodePort = 30080
+	assert.NoError(t, networkServiceMutateVisitor(workflow, &operatorapi.NetworkSpec{Mode: operatorapi.NetworkModeNone})(service)())
+	assert.Equal(t, corev1.ServiceTypeClusterIP, service.(*corev1.Service).Spec.Type)
+	assert.Zero(t, service.(*corev1.Service).Spec.Ports[0].NodePort)
+}
+
+func Test_networkStatusEnricherWithIngress(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.Network = &operatorapi.NetworkSpec{Mode: operatorapi.NetworkModeIngress}
+	ingress, _ := ingressCreator(workflow)
+	ingress.(*networkingv1.Ingress).Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{{IP: "192.168.49.2"}}
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, ingress).Build()
+
+	_, err := networkStatusEnricher(context.TODO(), client, workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://192.168.49.2", workflow.Status.Endpoint.String())
+	assert.Equal(t, "http://"+network.GetServiceHostname(workflow.Name, workflow.Namespace), workflow.Status.Address.URL.String())
+}
+
+func Test_networkStatusEnricherWithPlatformGateway(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, t.Name())
+	platform.Spec.Network = &operatorapi.NetworkSpec{
+		Mode: operatorapi.NetworkModeGateway,
+		Host: "greeting.example.com",
+		TLS:  &operatorapi.NetworkTLSSpec{},
+	}
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform).Build()
+
+	_, err := networkStatusEnricher(context.TODO(), client, workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://greeting.example.com", workflow.Status.Endpoint.String())
+}
+
+func Test_prodProfileWithNodePortNetwork(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Status.Applied = workflow.Spec
+	workflow.Spec.Network = &operatorapi.NetworkSpec{Mode: operatorapi.NetworkModeNodePort}
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform).Build()
+	support := fakeReconcilerSupport(client)
+	handler := &deployWorkflowReconciliationState{
+		stateSupport: support,
+		ensurers:     newProdObjectEnsurers(support),
+		enrichers:    newProdObjectEnrichers(support),
+	}
+
+	_, _, err := handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+
+	service := test.MustGetService(t, client, workflow)
+	assert.Equal(t, corev1.ServiceTypeNodePort, service.Spec.Type)
+}
+
+func Test_networkObjectEnsurersWithRouteTLS(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.Network = &operatorapi.NetworkSpec{
+		Mode:        operatorapi.NetworkModeRoute,
+		Host:        "greeting.apps.example.com",
+		Path:        "/greeting",
+		Annotations: map[string]string{"haproxy.router.openshift.io/timeout": "2m"},
+		TLS: &operatorapi.NetworkTLSSpec{
+			SecretName:              "greeting-tls",
+			Termination:             operatorapi.RouteTLSTerminationReencrypt,
+			DestinationCASecretName: "greeting-service-ca",
+		},
+	}
+	certs := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "greeting-tls", Namespace: workflow.Namespace},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+			caCertificateKey:        []byte("ca"),
+		},
+	}
+	destinationCA := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "greeting-service-ca", Namespace: workflow.Namespace},
+		Data:       map[string][]byte{caCertificateKey: []byte("service-ca")},
+	}
+	client := test.NewKogitoClientBuilderWithOpenShift().WithRuntimeObjects(workflow, certs, destinationCA).Build()
+	ensurers := newNetworkObjectEnsurers(fakeReconcilerSupport(client))
+
+	_, err := ensurers.ensure(context.TODO(), workflow, workflow.Spec.Network)
+	assert.NoError(t, err)
+
+	route := &routev1.Route{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), route))
+	assert.Equal(t, "greeting.apps.example.com", route.Spec.Host)
+	assert.Equal(t, "/greeting", route.Spec.Path)
+	assert.Equal(t, workflow.Name, route.Spec.To.Name)
+	assert.Equal(t, "2m", route.Annotations["haproxy.router.openshift.io/timeout"])
+	assert.Equal(t, routev1.TLSTerminationReencrypt, route.Spec.TLS.Termination)
+	assert.Equal(t, "cert", route.Spec.TLS.Certificate)
+	assert.Equal(t, "key", route.Spec.TLS.Key)
+	assert.Equal(t, "ca", route.Spec.TLS.CACertificate)
+	assert.Equal(t, "service-ca", route.Spec.TLS.DestinationCACertificate)
+
+	_, err = networkStatusEnricher(context.TODO(), client, workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://greeting.apps.example.com/greeting", workflow.Status.Endpoint.String())
+
+	// renewed certificates are copied to the Route on the next reconciliation
+	assert.True(t, IsSecretReferencedByRouteTLS(context.TODO(), client, workflow, "greeting-tls"))
+	assert.True(t, IsSecretReferencedByRouteTLS(context.TODO(), client, workflow, "greeting-service-ca"))
+	assert.False(t, IsSecretReferencedByRouteTLS(context.TODO(), client, workflow, "other-tls"))
+	certs.Data[corev1.TLSCertKey] = []byte("renewed-cert")
+	assert.NoError(t, client.Update(context.TODO(), certs))
+	_, err = ensurers.ensure(context.TODO(), workflow, workflow.Spec.Network)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), route))
+	assert.Equal(t, "renewed-cert", route.Spec.TLS.Certificate)
+}
+
+func Test_networkObjectEnsurersInvalidConfig(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	client := test.NewKogitoClientBuilderWithoutOpenShift().WithRuntimeObjects(workflow).Build()
+	ensurers := newNetworkObjectEnsurers(fakeReconcilerSupport(client))
+
+	// the cluster doesn't serve the route.openshift.io API
+	_, err := ensurers.ensure(context.TODO(), workflow, &operatorapi.NetworkSpec{Mode: operatorapi.NetworkModeRoute})
+	assert.True(t, isNetworkConfigInvalid(err))
+
+	_, err = ensurers.ensure(context.TODO(), workflow, &operatorapi.NetworkSpec{Mode: operatorapi.NetworkModeGateway})
+	assert.True(t, isNetworkConfigInvalid(err))
+}
+
+func Test_prodProfileWithRouteNetworkOutOfOpenShift(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Status.Applied = workflow.Spec
+	workflow.Spec.Network = &operatorapi.NetworkSpec{Mode: operatorapi.NetworkModeRoute}
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, t.Name())
+	client := test.NewKogitoClientBuilderWithoutOpenShift().WithRuntimeObjects(workflow, platform).Build()
+	support := fakeReconcilerSupport(client)
+	handler := &deployWorkflowReconciliationState{
+		stateSupport: support,
+		ensurers:     newProdObjectEnsurers(support),
+		enrichers:    newProdObjectEnrichers(support),
+	}
+
+	_, _, err := handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.True(t, workflow.Status.IsNetworkConfigInvalid())
+	assert.Contains(t, workflow.Status.GetCondition(api.RunningConditionType).Message, "requires OpenShift")
+
+	workflow.Spec.Network = &operatorapi.NetworkSpec{Mode: operatorapi.NetworkModeNodePort}
+	_, _, err = handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.False(t, workflow.Status.IsNetworkConfigInvalid())
+}
+
+func Test_networkObjectEnsurersRouteKeepsGeneratedHost(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.Network = &operatorapi.NetworkSpec{
+		Mode: operatorapi.NetworkModeRoute,
+		TLS:  &operatorapi.NetworkTLSSpec{Termination: operatorapi.RouteTLSTerminationPassthrough},
+	}
+	client := test.NewKogitoClientBuilderWithOpenShift().WithRuntimeObjects(workflow).Build()
+	ensurers := newNetworkObjectEnsurers(fakeReconcilerSupport(client))
+
+	_, err := ensurers.ensure(context.TODO(), workflow, workflow.Spec.Network)
+	assert.NoError(t, err)
+
+	// the router generates the host
+	route := &routev1.Route{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), route))
+	route.Spec.Host = "greeting-ns.apps.example.com"
+	assert.NoError(t, client.Update(context.TODO(), route))
+
+	_, err = ensurers.ensure(context.TODO(), workflow, workflow.Spec.Network)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), route))
+	assert.Equal(t, "greeting-ns.apps.example.com", route.Spec.Host)
+	assert.Equal(t, routev1.TLSTerminationPassthrough, route.Spec.TLS.Termination)
+	assert.Empty(t, route.Spec.TLS.Certificate)
+}
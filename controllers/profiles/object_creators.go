@@ -23,9 +23,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/kiegroup/kogito-serverless-operator/api"
+	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
 	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
 
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/utils"
 	kubeutil "github.com/kiegroup/kogito-serverless-operator/utils/kubernetes"
 	"github.com/kiegroup/kogito-serverless-operator/utils/openshift"
 )
@@ -190,9 +194,8 @@ func defaultServiceCreator(workflow *operatorapi.KogitoServerlessWorkflow) (clie
 	return service, nil
 }
 
-// defaultNetworkCreator is an objectCreator for a basic Route for a workflow using dev profile
-// running on OpenShift.
-// It enables the exposition of the dev service using an OpenShift Route.
+// defaultNetworkCreator is an objectCreator for a basic Route for a workflow running on OpenShift.
+// It enables the exposition of the workflow service using an OpenShift Route.
 // See: https://github.com/openshift/api/blob/d170fcdc0fa638b664e4f35f2daf753cb4afe36b/route/v1/route.crd.yaml
 func defaultNetworkCreator(workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
 	route, err := openshift.RouteForWorkflow(workflow)
@@ -216,43 +219,79 @@ func defaultServiceMutateVisitor(workflow *operatorapi.KogitoServerlessWorkflow)
 	}
 }
 
-func ensureWorkflowPropertiesConfigMapMutator(workflow *operatorapi.KogitoServerlessWorkflow, defaultProperties string) mutateVisitor {
+// ensureWorkflowPropertiesConfigMapMutator guarantees the workflow application properties, merging the properties owned by
+// the user with the operator defaults, the workflow add-ons configuration, the platform configuration and the properties
+// derived from the workflow spec.
+// See applicationPropertiesMerger for the strategies.
+// The properties set by the operator are listed in the metadata.ManagedPropertiesAnnotation, so changes and removals in the
+// platform configuration are reflected in the workflow properties unless the user has overridden them.
+// If the user's properties can't be parsed, they're kept as they are and the workflow is marked with the
+// api.PropertiesValidConditionType condition until they're fixed.
+func ensureWorkflowPropertiesConfigMapMutator(workflow *operatorapi.KogitoServerlessWorkflow, defaultProperties string, addonsProps, platformProps, specProps *properties.Properties) mutateVisitor {
 	return func(object client.Object) controllerutil.MutateFn {
 		return func() error {
-			if kubeutil.IsObjectNew(object) {
-				return nil
-			}
-			original, err := workflowPropsConfigMapCreator(workflow)
-			if err != nil {
-				return err
-			}
 			cm := object.(*corev1.ConfigMap)
-			cm.Labels = original.GetLabels()
+			if !kubeutil.IsObjectNew(object) {
+				original, err := workflowPropsConfigMapCreator(workflow)
+				if err != nil {
+					return err
+				}
+				cm.Labels = original.GetLabels()
+			}
 
 			_, hasKey := cm.Data[applicationPropertiesFileName]
 			if !hasKey {
 				cm.Data = make(map[string]string, 1)
 				cm.Data[applicationPropertiesFileName] = defaultProperties
-			} else {
-				props, propErr := properties.LoadString(cm.Data[applicationPropertiesFileName])
-				if propErr != nil {
-					// can't load user's properties, replace with default
-					cm.Data[applicationPropertiesFileName] = defaultProperties
-					return nil
-				}
-				originalProps := properties.MustLoadString(original.(*corev1.ConfigMap).Data[applicationPropertiesFileName])
-				// we overwrite with the defaults
-				props.Merge(originalProps)
-				cm.Data[applicationPropertiesFileName] = props.String()
+			}
+			props, propErr := utils.LoadProperties(cm.Data[applicationPropertiesFileName])
+			if propErr != nil {
+				workflow.Status.Manager().MarkFalse(api.PropertiesValidConditionType, api.PropertiesParseFailedReason,
+					"Failed to parse %s in the ConfigMap %s: %v", applicationPropertiesFileName, cm.Name, propErr)
+				return nil
+			}
+			if err := workflow.Status.Manager().ClearCondition(api.PropertiesValidConditionType); err != nil {
+				return err
 			}
 
+			merger := &applicationPropertiesMerger{defaults: properties.MustLoadString(defaultProperties), addons: addonsProps, platform: platformProps, spec: specProps}
+			result, managed := merger.merge(props,
+				parseManagedProperties(cm.Annotations[metadata.ManagedPropertiesAnnotation]),
+				parseUserOnlyProperties(cm.Annotations[metadata.UserPropertiesAnnotation]))
+			cm.Data[applicationPropertiesFileName] = result.String()
+
+			if cm.Annotations == nil {
+				cm.Annotations = make(map[string]string, 1)
+			}
+			cm.Annotations[metadata.ManagedPropertiesAnnotation] = managed.String()
+			return nil
+		}
+	}
+}
+
+// platformConfigurationMutateVisitor injects the platform configuration sources in the workflow Deployment.
+// The configuration checksum in the pod template rolls out the Deployment whenever the platform configuration changes.
+func platformConfigurationMutateVisitor(config *platform.Configuration) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			deployment := object.(*appsv1.Deployment)
+			deployment.Spec.Template.Spec.Containers[0].EnvFrom = config.EnvFrom
+			checksum := config.Checksum()
+			if len(checksum) == 0 {
+				delete(deployment.Spec.Template.Annotations, metadata.PlatformConfigurationChecksumAnnotation)
+				return nil
+			}
+			if deployment.Spec.Template.Annotations == nil {
+				deployment.Spec.Template.Annotations = make(map[string]string, 1)
+			}
+			deployment.Spec.Template.Annotations[metadata.PlatformConfigurationChecksumAnnotation] = checksum
 			return nil
 		}
 	}
 }
 
-func ensureProdWorkflowPropertiesConfigMapMutator(workflow *operatorapi.KogitoServerlessWorkflow) mutateVisitor {
-	return ensureWorkflowPropertiesConfigMapMutator(workflow, defaultProdApplicationProperties)
+func ensureProdWorkflowPropertiesConfigMapMutator(workflow *operatorapi.KogitoServerlessWorkflow, addonsProps, platformProps, persistenceProps *properties.Properties) mutateVisitor {
+	return ensureWorkflowPropertiesConfigMapMutator(workflow, defaultProdApplicationProperties, addonsProps, platformProps, persistenceProps)
 }
 
 // workflowPropsConfigMapCreator creates a ConfigMap to hold the external application properties
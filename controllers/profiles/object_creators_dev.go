@@ -15,6 +15,7 @@
 package profiles
 
 import (
+	"github.com/magiconair/properties"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -36,7 +37,8 @@ var defaultDevApplicationProperties = "quarkus.http.port=" + defaultHTTPWorkflow
 // devServiceCreator is an objectCreator for a basic Service for a workflow using dev profile
 // aiming a vanilla Kubernetes Deployment.
 // It maps the default HTTP port (80) to the target Java application webserver on port 8080.
-// It configures the Service as a NodePort type service, in this way it will be easier for a developer access the service
+// It configures the Service as a NodePort type service, in this way it will be easier for a developer access the service.
+// The networkServiceMutateVisitor honours the workflow network mode instead when one is set, e.g. a ClusterIP in the none mode.
 func devServiceCreator(workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
 	object, _ := defaultServiceCreator(workflow)
 	service := object.(*corev1.Service)
@@ -74,6 +76,6 @@ func ensureWorkflowDefConfigMapMutator(workflow *operatorapi.KogitoServerlessWor
 	}
 }
 
-func ensureWorkflowDevPropertiesConfigMapMutator(workflow *operatorapi.KogitoServerlessWorkflow) mutateVisitor {
-	return ensureWorkflowPropertiesConfigMapMutator(workflow, defaultDevApplicationProperties)
+func ensureWorkflowDevPropertiesConfigMapMutator(workflow *operatorapi.KogitoServerlessWorkflow, platformProps *properties.Properties) mutateVisitor {
+	return ensureWorkflowPropertiesConfigMapMutator(workflow, defaultDevApplicationProperties, nil, platformProps, nil)
 }
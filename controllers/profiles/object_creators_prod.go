@@ -16,8 +16,10 @@ package profiles
 
 import (
 	"fmt"
+	"reflect"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -43,3 +45,19 @@ func addOpenShiftImageTriggerDeploymentMutateVisitor(image string) mutateVisitor
 		}
 	}
 }
+
+// imagePullSecretsMutateVisitor sets the Secrets pulling the workflow image in the Deployment
+func imagePullSecretsMutateVisitor(pullSecrets []corev1.LocalObjectReference) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			object.(*appsv1.Deployment).Spec.Template.Spec.ImagePullSecrets = pullSecrets
+			return nil
+		}
+	}
+}
+
+// isImagePullSecretsChanged checks if the Deployment pulls the workflow image with other Secrets than the given ones
+func isImagePullSecretsChanged(deployment *appsv1.Deployment, pullSecrets []corev1.LocalObjectReference) bool {
+	current := deployment.Spec.Template.Spec.ImagePullSecrets
+	return (len(current) > 0 || len(pullSecrets) > 0) && !reflect.DeepEqual(current, pullSecrets)
+}
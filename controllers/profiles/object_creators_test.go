@@ -15,13 +15,23 @@
 package profiles
 
 import (
+	"context"
 	"testing"
 
 	"github.com/magiconair/properties"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/kiegroup/kogito-serverless-operator/api"
+	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
 	"github.com/kiegroup/kogito-serverless-operator/test"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/utils"
 )
 
 func Test_ensureWorkflowPropertiesConfigMapMutator(t *testing.T) {
@@ -32,7 +42,7 @@ func Test_ensureWorkflowPropertiesConfigMapMutator(t *testing.T) {
 	cm.SetResourceVersion("1")
 	reflectCm := cm.(*v1.ConfigMap)
 
-	visitor := ensureWorkflowDevPropertiesConfigMapMutator(workflow)
+	visitor := ensureWorkflowDevPropertiesConfigMapMutator(workflow, nil)
 	mutateFn := visitor(cm)
 
 	assert.NoError(t, mutateFn())
@@ -52,3 +62,156 @@ func Test_ensureWorkflowPropertiesConfigMapMutator(t *testing.T) {
 	assert.Equal(t, "0.0.0.0", props.GetString("quarkus.http.host", ""))
 	assert.Equal(t, "1", props.GetString("my.new.prop", ""))
 }
+
+func Test_ensureWorkflowPropertiesConfigMapMutatorWithPlatformConfiguration(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	platformCM := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform-props", Namespace: workflow.Namespace},
+		Data:       map[string]string{"application.properties": "kafka.bootstrap.servers=kafka:9092\nquarkus.http.port=9090"},
+	}
+	pl := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, workflow.Namespace)
+	pl.Spec.Configurations = []operatorapi.ConfigurationSpec{
+		{Type: operatorapi.PropertyConfigurationSpec, Property: "my.platform.prop=platform"},
+		{Type: operatorapi.ConfigMapConfigurationSpec, Value: v1.ObjectReference{Name: platformCM.Name}},
+		{Type: operatorapi.SecretConfigurationSpec, Value: v1.ObjectReference{Name: "platform-secret"}},
+	}
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(platformCM).Build()
+	platformConfig, err := platform.GetConfiguration(context.TODO(), client, pl)
+	assert.NoError(t, err)
+	assert.Len(t, platformConfig.EnvFrom, 1)
+	assert.NotEmpty(t, platformConfig.Checksum())
+
+	cm, _ := workflowPropsConfigMapCreator(workflow)
+	cm.SetUID("1")
+	cm.SetResourceVersion("1")
+	reflectCm := cm.(*v1.ConfigMap)
+	assert.NoError(t, ensureWorkflowDevPropertiesConfigMapMutator(workflow, platformConfig.Properties)(cm)())
+
+	props := properties.MustLoadString(reflectCm.Data[applicationPropertiesFileName])
+	assert.Equal(t, "platform", props.GetString("my.platform.prop", ""))
+	assert.Equal(t, "kafka:9092", props.GetString("kafka.bootstrap.servers", ""))
+	// operator defaults can't be overridden
+	assert.Equal(t, "8080", props.GetString("quarkus.http.port", ""))
+
+	// the user overrides a platform property
+	reflectCm.Data[applicationPropertiesFileName] = reflectCm.Data[applicationPropertiesFileName] + "\nmy.platform.prop=user"
+	assert.NoError(t, ensureWorkflowDevPropertiesConfigMapMutator(workflow, platformConfig.Properties)(cm)())
+	props = properties.MustLoadString(reflectCm.Data[applicationPropertiesFileName])
+	assert.Equal(t, "user", props.GetString("my.platform.prop", ""))
+
+	// the platform changes and removes properties
+	pl.Spec.Configurations = []operatorapi.ConfigurationSpec{
+		{Type: operatorapi.PropertyConfigurationSpec, Property: "my.platform.prop=changed"},
+		{Type: operatorapi.PropertyConfigurationSpec, Property: "kafka.bootstrap.servers=kafka-new:9092"},
+	}
+	platformConfig, err = platform.GetConfiguration(context.TODO(), client, pl)
+	assert.NoError(t, err)
+	assert.NoError(t, ensureWorkflowDevPropertiesConfigMapMutator(workflow, platformConfig.Properties)(cm)())
+	props = properties.MustLoadString(reflectCm.Data[applicationPropertiesFileName])
+	assert.Equal(t, "user", props.GetString("my.platform.prop", ""))
+	assert.Equal(t, "kafka-new:9092", props.GetString("kafka.bootstrap.servers", ""))
+
+	pl.Spec.Configurations = nil
+	platformConfig, err = platform.GetConfiguration(context.TODO(), client, pl)
+	assert.NoError(t, err)
+	assert.Empty(t, platformConfig.Checksum())
+	assert.NoError(t, ensureWorkflowDevPropertiesConfigMapMutator(workflow, platformConfig.Properties)(cm)())
+	props = properties.MustLoadString(reflectCm.Data[applicationPropertiesFileName])
+	assert.Equal(t, "user", props.GetString("my.platform.prop", ""))
+	_, found := props.Get("kafka.bootstrap.servers")
+	assert.False(t, found)
+	assert.NotContains(t, parseManagedProperties(reflectCm.Annotations[metadata.ManagedPropertiesAnnotation]), "kafka.bootstrap.servers")
+}
+
+func Test_ensureProdWorkflowPropertiesConfigMapMutatorWithAddons(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.Build = &operatorapi.WorkflowBuildSpec{Addons: []string{"kafka", "kubernetes"}}
+	platformProps := properties.MustLoadString("mp.messaging.outgoing.kogito_outgoing_stream.connector=platform")
+	addonsProps := getAddonsProperties(workflowdef.GetAddons(workflow, nil))
+
+	cm, _ := workflowPropsConfigMapCreator(workflow)
+	cm.SetUID("1")
+	cm.SetResourceVersion("1")
+	reflectCm := cm.(*v1.ConfigMap)
+	reflectCm.Data[applicationPropertiesFileName] = defaultProdApplicationProperties + "\nquarkus.kubernetes-client.devservices.enabled=true"
+	assert.NoError(t, ensureProdWorkflowPropertiesConfigMapMutator(workflow, addonsProps, platformProps, nil)(cm)())
+
+	props := properties.MustLoadString(reflectCm.Data[applicationPropertiesFileName])
+	assert.Equal(t, "smallrye-kafka", props.GetString("mp.messaging.incoming.kogito_incoming_stream.connector", ""))
+	// the platform configuration wins over the add-ons one
+	assert.Equal(t, "platform", props.GetString("mp.messaging.outgoing.kogito_outgoing_stream.connector", ""))
+	// the user can override the add-ons configuration
+	assert.Equal(t, "true", props.GetString("quarkus.kubernetes-client.devservices.enabled", ""))
+
+	assert.Nil(t, getAddonsProperties([]string{"source-files", "unknown"}))
+}
+
+func Test_ensureWorkflowPropertiesConfigMapMutatorStrategies(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	cm, _ := workflowPropsConfigMapCreator(workflow)
+	cm.SetUID("1")
+	cm.SetResourceVersion("1")
+	reflectCm := cm.(*v1.ConfigMap)
+	visitor := ensureWorkflowDevPropertiesConfigMapMutator(workflow, nil)
+	assert.NoError(t, visitor(cm)())
+
+	managed := parseManagedProperties(reflectCm.Annotations[metadata.ManagedPropertiesAnnotation])
+	assert.Equal(t, propertyStrategyEnforced, managed["quarkus.http.port"].Strategy)
+	assert.Equal(t, propertyStrategyOverridable, managed["quarkus.devservices.enabled"].Strategy)
+
+	// the user overrides a default, declares a user only property and tries to override an enforced one in the dev profile
+	reflectCm.Annotations[metadata.UserPropertiesAnnotation] = "org.kie.kogito.addons.knative.eventing.health-enabled"
+	reflectCm.Data[applicationPropertiesFileName] = "quarkus.http.port=8080\nquarkus.http.host=0.0.0.0\n" +
+		"quarkus.devservices.enabled=true\nquarkus.kogito.devservices.enabled=false\n" +
+		"%dev.quarkus.http.port=9090\n%dev.my.prop=${my.other.prop}\n"
+	assert.NoError(t, visitor(cm)())
+
+	props, err := utils.LoadProperties(reflectCm.Data[applicationPropertiesFileName])
+	assert.NoError(t, err)
+	assert.Equal(t, "true", props.GetString("quarkus.devservices.enabled", ""))
+	assert.Equal(t, "false", props.GetString("quarkus.kogito.devservices.enabled", ""))
+	assert.Equal(t, "${my.other.prop}", props.GetString("%dev.my.prop", ""))
+	_, found := props.Get("org.kie.kogito.addons.knative.eventing.health-enabled")
+	assert.False(t, found)
+	_, found = props.Get("%dev.quarkus.http.port")
+	assert.False(t, found)
+	managed = parseManagedProperties(reflectCm.Annotations[metadata.ManagedPropertiesAnnotation])
+	assert.NotContains(t, managed, "quarkus.devservices.enabled")
+	assert.NotContains(t, managed, "org.kie.kogito.addons.knative.eventing.health-enabled")
+	assert.Contains(t, managed, "quarkus.kogito.devservices.enabled")
+}
+
+func Test_ensureWorkflowPropertiesConfigMapMutatorParseFailure(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	cm, _ := workflowPropsConfigMapCreator(workflow)
+	cm.SetUID("1")
+	cm.SetResourceVersion("1")
+	reflectCm := cm.(*v1.ConfigMap)
+	visitor := ensureWorkflowDevPropertiesConfigMapMutator(workflow, nil)
+
+	invalidProps := "my.prop=\\u12zz"
+	reflectCm.Data[applicationPropertiesFileName] = invalidProps
+	assert.NoError(t, visitor(cm)())
+	assert.Equal(t, invalidProps, reflectCm.Data[applicationPropertiesFileName])
+	assert.True(t, workflow.Status.GetCondition(api.PropertiesValidConditionType).IsFalse())
+	assert.Equal(t, api.PropertiesParseFailedReason, workflow.Status.GetCondition(api.PropertiesValidConditionType).Reason)
+
+	reflectCm.Data[applicationPropertiesFileName] = "my.prop=1"
+	assert.NoError(t, visitor(cm)())
+	assert.Nil(t, workflow.Status.GetCondition(api.PropertiesValidConditionType))
+	assert.Contains(t, reflectCm.Data[applicationPropertiesFileName], "quarkus.http.port")
+}
+
+func Test_platformConfigurationMutateVisitor(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	deployment, _ := defaultDeploymentCreator(workflow)
+	platformConfig := &platform.Configuration{
+		Properties: properties.MustLoadString("my.prop=1"),
+		EnvFrom:    []v1.EnvFromSource{{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "platform-secret"}}}},
+	}
+
+	assert.NoError(t, platformConfigurationMutateVisitor(platformConfig)(deployment)())
+	podTemplate := deployment.(*appsv1.Deployment).Spec.Template
+	assert.Equal(t, platformConfig.Checksum(), podTemplate.Annotations[metadata.PlatformConfigurationChecksumAnnotation])
+	assert.Equal(t, "platform-secret", podTemplate.Spec.Containers[0].EnvFrom[0].SecretRef.Name)
+}
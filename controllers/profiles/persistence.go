@@ -0,0 +1,117 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/magiconair/properties"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/utils"
+	kubeutil "github.com/kiegroup/kogito-serverless-operator/utils/kubernetes"
+)
+
+const (
+	persistenceMigrationContainerName = "persistence-migration"
+
+	quarkusDatasourceUsernameEnv = "QUARKUS_DATASOURCE_USERNAME"
+	quarkusDatasourcePasswordEnv = "QUARKUS_DATASOURCE_PASSWORD"
+	// quarkusInitAndExitEnv makes the workflow application exit once started, so the init container ends after the migration.
+	// See: https://quarkus.io/guides/flyway
+	quarkusInitAndExitEnv          = "QUARKUS_INIT_AND_EXIT"
+	quarkusFlywayMigrateAtStartEnv = "QUARKUS_FLYWAY_MIGRATE_AT_START"
+)
+
+// getPersistenceProperties gets the application properties to persist the workflow instances.
+// It returns nil if the workflow instances aren't persisted.
+func getPersistenceProperties(workflow *operatorapi.KogitoServerlessWorkflow, persistence *operatorapi.PersistenceSpec) (*properties.Properties, error) {
+	if persistence == nil {
+		return nil, nil
+	}
+	jdbcURL, err := workflowdef.GetPostgreSQLJdbcURL(persistence.PostgreSQL, workflow.Namespace, workflow.Name)
+	if err != nil {
+		return nil, fmt.Errorf("workflow %s: %w", workflow.Name, err)
+	}
+	props := utils.NewProperties()
+	_, _, _ = props.Set("kogito.persistence.type", "jdbc")
+	_, _, _ = props.Set("kogito.persistence.proto.marshaller", "false")
+	_, _, _ = props.Set("quarkus.datasource.db-kind", "postgresql")
+	_, _, _ = props.Set("quarkus.datasource.jdbc.url", jdbcURL)
+	if migration := workflowdef.GetPersistenceMigration(persistence); migration != operatorapi.PersistenceMigrationNone {
+		_, _, _ = props.Set("quarkus.flyway.migrate-at-start", fmt.Sprintf("%t", migration == operatorapi.PersistenceMigrationStartup))
+	}
+	return props, nil
+}
+
+// getPersistenceChecksum calculates the checksum of the persistence, so the workflow Deployment is rolled out when it changes.
+func getPersistenceChecksum(persistence *operatorapi.PersistenceSpec) string {
+	if persistence == nil {
+		return ""
+	}
+	// can't fail, it's a plain struct
+	spec, _ := json.Marshal(persistence)
+	return fmt.Sprintf("%x", sha256.Sum256(spec))
+}
+
+// persistenceMutateVisitor injects the database credentials in the workflow application and, if required, adds the init
+// container to migrate the database schema.
+// Must be called after every other visitor, since the init container is a copy of the workflow application container.
+func persistenceMutateVisitor(persistence *operatorapi.PersistenceSpec) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			deployment := object.(*appsv1.Deployment)
+			deployment.Spec.Template.Spec.InitContainers = nil
+			checksum := getPersistenceChecksum(persistence)
+			if persistence == nil {
+				delete(deployment.Spec.Template.Annotations, metadata.PersistenceChecksumAnnotation)
+				return nil
+			}
+
+			secretRef := persistence.PostgreSQL.SecretRef
+			userKey, passwordKey := workflowdef.GetPostgreSQLSecretKeys(secretRef)
+			container := &deployment.Spec.Template.Spec.Containers[0]
+			kubeutil.CreateOrReplaceEnvFromSecret(container, quarkusDatasourceUsernameEnv, secretRef.Name, userKey)
+			kubeutil.CreateOrReplaceEnvFromSecret(container, quarkusDatasourcePasswordEnv, secretRef.Name, passwordKey)
+
+			if workflowdef.GetPersistenceMigration(persistence) == operatorapi.PersistenceMigrationInitContainer {
+				migration := container.DeepCopy()
+				migration.Name = persistenceMigrationContainerName
+				migration.Ports = nil
+				migration.LivenessProbe = nil
+				migration.ReadinessProbe = nil
+				migration.StartupProbe = nil
+				kubeutil.CreateOrReplaceEnv(migration, quarkusInitAndExitEnv, "true")
+				kubeutil.CreateOrReplaceEnv(migration, quarkusFlywayMigrateAtStartEnv, "true")
+				deployment.Spec.Template.Spec.InitContainers = []corev1.Container{*migration}
+			}
+
+			if deployment.Spec.Template.Annotations == nil {
+				deployment.Spec.Template.Annotations = make(map[string]string, 1)
+			}
+			deployment.Spec.Template.Annotations[metadata.PersistenceChecksumAnnotation] = checksum
+			return nil
+		}
+	}
+}
@@ -17,6 +17,7 @@ package profiles
 import (
 	"github.com/go-logr/logr"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
@@ -31,7 +32,7 @@ const (
 	defaultProfile         = Production
 )
 
-type reconcilerBuilder func(client client.Client, config *rest.Config, logger *logr.Logger) ProfileReconciler
+type reconcilerBuilder func(client client.Client, config *rest.Config, recorder record.EventRecorder, logger *logr.Logger) ProfileReconciler
 
 var profileBuilders = map[Profile]reconcilerBuilder{
 	Production:  newProdProfileReconciler,
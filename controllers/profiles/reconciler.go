@@ -18,13 +18,17 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/tracing"
 )
 
 // ProfileReconciler is the public interface to have access to this package and perform the actual reconciliation flow.
@@ -62,8 +66,9 @@ type ProfileReconciler interface {
 
 // stateSupport is the shared structure with common accessors used throughout the whole reconciliation profiles
 type stateSupport struct {
-	logger *logr.Logger
-	client client.Client
+	logger   *logr.Logger
+	client   client.Client
+	recorder record.EventRecorder
 }
 
 // performStatusUpdate updates the KogitoServerlessWorkflow Status conditions
@@ -78,6 +83,17 @@ func (s stateSupport) performStatusUpdate(ctx context.Context, workflow *operato
 	return true, err
 }
 
+// performStatusUpdateIfChanged updates the KogitoServerlessWorkflow Status only when it differs from the given previous one,
+// sparing a call to the API server on every requeue
+func (s stateSupport) performStatusUpdateIfChanged(ctx context.Context, previous *operatorapi.KogitoServerlessWorkflowStatus, workflow *operatorapi.KogitoServerlessWorkflow) (bool, error) {
+	workflow.Status.Applied = workflow.Spec
+	workflow.Status.ObservedGeneration = workflow.Generation
+	if equality.Semantic.DeepEqual(previous, &workflow.Status) {
+		return false, nil
+	}
+	return s.performStatusUpdate(ctx, workflow)
+}
+
 // PostReconcile function to perform all the other operations required after the reconciliation - placeholder for null pattern usages
 func (s stateSupport) PostReconcile(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow) error {
 	//By default, we don't want to perform anything after the reconciliation, and so we will simply return no error
@@ -124,10 +140,11 @@ type ReconciliationState interface {
 }
 
 // newReconciliationStateMachine builder for the reconciliationStateMachine
-func newReconciliationStateMachine(logger *logr.Logger, states ...ReconciliationState) *reconciliationStateMachine {
+func newReconciliationStateMachine(logger *logr.Logger, recorder record.EventRecorder, states ...ReconciliationState) *reconciliationStateMachine {
 	return &reconciliationStateMachine{
-		states: states,
-		logger: logger,
+		states:   states,
+		logger:   logger,
+		recorder: recorder,
 	}
 }
 
@@ -136,15 +153,20 @@ func newReconciliationStateMachine(logger *logr.Logger, states ...Reconciliation
 //
 // TODO: implement state transition, so based on a given condition we do the status update which actively transition the object state
 type reconciliationStateMachine struct {
-	states []ReconciliationState
-	logger *logr.Logger
+	states   []ReconciliationState
+	logger   *logr.Logger
+	recorder record.EventRecorder
 }
 
-func (r *reconciliationStateMachine) do(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow) (ctrl.Result, []client.Object, error) {
+func (r *reconciliationStateMachine) do(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow) (result ctrl.Result, objs []client.Object, err error) {
+	ctx, span := tracing.StartSpan(ctx, "reconciliationStateMachine.do", workflow)
+	defer func() { tracing.EndSpan(span, err) }()
 	for _, h := range r.states {
 		if h.CanReconcile(workflow) {
 			r.logger.Info("Found a condition to reconcile.", "Conditions", workflow.Status.Conditions)
-			result, objs, err := h.Do(ctx, workflow)
+			previous := workflow.Status.DeepCopy()
+			result, objs, err = r.doState(ctx, h, workflow)
+			recordTransitionEvents(r.recorder, previous, workflow)
 			if err != nil {
 				return result, objs, err
 			}
@@ -157,7 +179,15 @@ func (r *reconciliationStateMachine) do(ctx context.Context, workflow *operatora
 	return ctrl.Result{}, nil, fmt.Errorf("the workflow %s in the namespace %s is in an unknown state condition. Can't reconcilie. Status is: %v", workflow.Name, workflow.Namespace, workflow.Status)
 }
 
+// doState calls ReconciliationState.Do within its own span, tagged with the state handling the workflow.
+func (r *reconciliationStateMachine) doState(ctx context.Context, state ReconciliationState, workflow *operatorapi.KogitoServerlessWorkflow) (result ctrl.Result, objs []client.Object, err error) {
+	ctx, span := tracing.StartSpan(ctx, "ReconciliationState.Do", workflow)
+	span.SetAttributes(attribute.String("state", fmt.Sprintf("%T", state)))
+	defer func() { tracing.EndSpan(span, err) }()
+	return state.Do(ctx, workflow)
+}
+
 // NewReconciler creates a new ProfileReconciler based on the given workflow and context.
-func NewReconciler(client client.Client, config *rest.Config, logger *logr.Logger, workflow *operatorapi.KogitoServerlessWorkflow) ProfileReconciler {
-	return profileBuilder(workflow)(client, config, logger)
+func NewReconciler(client client.Client, config *rest.Config, recorder record.EventRecorder, logger *logr.Logger, workflow *operatorapi.KogitoServerlessWorkflow) ProfileReconciler {
+	return profileBuilder(workflow)(client, config, recorder, logger)
 }
@@ -23,8 +23,10 @@ import (
 	"github.com/kiegroup/kogito-serverless-operator/utils"
 
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 
+	"github.com/kiegroup/kogito-serverless-operator/controllers/metrics"
 	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
 
 	"github.com/go-logr/logr"
@@ -73,24 +75,23 @@ func (d developmentProfile) GetProfile() Profile {
 	return Development
 }
 
-func newDevProfileReconciler(client client.Client, config *rest.Config, logger *logr.Logger) ProfileReconciler {
+func newDevProfileReconciler(client client.Client, config *rest.Config, recorder record.EventRecorder, logger *logr.Logger) ProfileReconciler {
 	support := &stateSupport{
-		logger: logger,
-		client: client,
+		logger:   logger,
+		client:   client,
+		recorder: recorder,
 	}
 
 	var ensurers *devProfileObjectEnsurers
-	var enrichers *devProfileObjectEnrichers
 	if utils.IsOpenShift() {
 		ensurers = newDevelopmentObjectEnsurersForOpenShift(support)
-		enrichers = newDevelopmentObjectEnrichersForOpenShift(support)
 	} else {
 		ensurers = newDevelopmentObjectEnsurers(support)
-		enrichers = newDevelopmentObjectEnrichers(support)
 	}
+	enrichers := newDevelopmentObjectEnrichers(support)
 
-	stateMachine := newReconciliationStateMachine(logger,
-		&ensureRunningDevWorkflowReconciliationState{stateSupport: support, ensurers: ensurers},
+	stateMachine := newReconciliationStateMachine(logger, recorder,
+		&ensureRunningDevWorkflowReconciliationState{stateSupport: support, ensurers: ensurers, enrichers: enrichers},
 		&followDeployDevWorkflowReconciliationState{stateSupport: support, enrichers: enrichers},
 		&recoverFromFailureDevReconciliationState{stateSupport: support})
 
@@ -106,9 +107,10 @@ func newDevelopmentObjectEnsurers(support *stateSupport) *devProfileObjectEnsure
 	return &devProfileObjectEnsurers{
 		deployment:          newDefaultObjectEnsurer(support.client, support.logger, defaultDeploymentCreator),
 		service:             newDefaultObjectEnsurer(support.client, support.logger, devServiceCreator),
-		network:             newDummyObjectEnsurer(),
+		network:             newNetworkObjectEnsurers(support),
 		definitionConfigMap: newDefaultObjectEnsurer(support.client, support.logger, workflowDefConfigMapCreator),
 		propertiesConfigMap: newDefaultObjectEnsurer(support.client, support.logger, workflowPropsConfigMapCreator),
+		secretProperties:    newDefaultObjectEnsurer(support.client, support.logger, workflowSecretPropsCreator),
 	}
 }
 
@@ -116,44 +118,45 @@ func newDevelopmentObjectEnsurersForOpenShift(support *stateSupport) *devProfile
 	return &devProfileObjectEnsurers{
 		deployment:          newDefaultObjectEnsurer(support.client, support.logger, defaultDeploymentCreator),
 		service:             newDefaultObjectEnsurer(support.client, support.logger, defaultServiceCreator),
-		network:             newDefaultObjectEnsurer(support.client, support.logger, defaultNetworkCreator),
+		network:             newNetworkObjectEnsurers(support),
 		definitionConfigMap: newDefaultObjectEnsurer(support.client, support.logger, workflowDefConfigMapCreator),
 		propertiesConfigMap: newDefaultObjectEnsurer(support.client, support.logger, workflowPropsConfigMapCreator),
+		secretProperties:    newDefaultObjectEnsurer(support.client, support.logger, workflowSecretPropsCreator),
 	}
 }
 
 func newDevelopmentObjectEnrichers(support *stateSupport) *devProfileObjectEnrichers {
 	return &devProfileObjectEnrichers{
-		networkInfo: newStatusEnricher(support.client, support.logger, defaultDevStatusEnricher),
-	}
-}
-
-func newDevelopmentObjectEnrichersForOpenShift(support *stateSupport) *devProfileObjectEnrichers {
-	return &devProfileObjectEnrichers{
-		networkInfo: newStatusEnricher(support.client, support.logger, devStatusEnricherForOpenShift),
+		networkInfo: newStatusEnricher(support.client, support.logger, networkStatusEnricher),
+		devModeInfo: newStatusEnricher(support.client, support.logger, devModeStatusEnricher),
 	}
 }
 
 type devProfileObjectEnsurers struct {
 	deployment          ObjectEnsurer
 	service             ObjectEnsurer
-	network             ObjectEnsurer
+	network             *networkObjectEnsurers
 	definitionConfigMap ObjectEnsurer
 	propertiesConfigMap ObjectEnsurer
+	secretProperties    ObjectEnsurer
 }
 
 type devProfileObjectEnrichers struct {
 	networkInfo *statusEnricher
+	devModeInfo *statusEnricher
 	//Here we can add more enrichers if we need in future to enrich objects with more info coming from reconciliation
 }
 
 type ensureRunningDevWorkflowReconciliationState struct {
 	*stateSupport
-	ensurers *devProfileObjectEnsurers
+	ensurers  *devProfileObjectEnsurers
+	enrichers *devProfileObjectEnrichers
 }
 
 func (e *ensureRunningDevWorkflowReconciliationState) CanReconcile(workflow *operatorapi.KogitoServerlessWorkflow) bool {
-	return workflow.Status.IsReady() || workflow.Status.GetTopLevelCondition().IsUnknown()
+	// a workflow that failed to build in dev mode or to be exposed must keep its objects ensured, so the user can fix the definition
+	return workflow.Status.IsReady() || workflow.Status.GetTopLevelCondition().IsUnknown() ||
+		workflow.Status.IsDevModeBuildFailed() || workflow.Status.IsNetworkConfigInvalid() || workflow.Status.IsWaitingForPlatform()
 }
 
 func (e *ensureRunningDevWorkflowReconciliationState) Do(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow) (ctrl.Result, []client.Object, error) {
@@ -165,41 +168,81 @@ func (e *ensureRunningDevWorkflowReconciliationState) Do(ctx context.Context, wo
 	}
 	objs = append(objs, flowDefCM)
 
-	propsCM, _, err := e.ensurers.propertiesConfigMap.ensure(ctx, workflow, ensureWorkflowDevPropertiesConfigMapMutator(workflow))
+	pl, err := platform.GetWorkflowPlatform(ctx, e.client, workflow)
+	if err != nil {
+		if !platform.IsPlatformUnavailable(err) {
+			e.logger.Error(err, "Failed to get the workflow platform")
+			return ctrl.Result{RequeueAfter: requeueAfterFailure}, objs, err
+		}
+		// the dev mode runs without any platform, unless the workflow selects one
+		if len(platform.SelectedPlatformName(workflow)) > 0 {
+			workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForPlatformReason,
+				"%s so the workflow cannot be deployed.", platformUnavailableMessage(workflow, err))
+			_, err = e.performStatusUpdate(ctx, workflow)
+			return ctrl.Result{RequeueAfter: requeueWhileWaitForPlatform}, objs, err
+		}
+		pl = nil
+	}
+	platformConfig, err := platform.GetConfiguration(ctx, e.client, pl)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: requeueAfterFailure}, objs, err
+	}
+
+	propsCM, _, err := e.ensurers.propertiesConfigMap.ensure(ctx, workflow, ensureWorkflowDevPropertiesConfigMapMutator(workflow, platformConfig.Properties))
 	if err != nil {
 		return ctrl.Result{Requeue: false}, objs, err
 	}
 	objs = append(objs, propsCM)
 
+	secretProps, _, err := e.ensurers.secretProperties.ensure(ctx, workflow, ensureWorkflowSecretPropsMutator(workflow))
+	if err != nil {
+		return ctrl.Result{Requeue: false}, objs, err
+	}
+	objs = append(objs, secretProps)
+	secretPropsChecksum, err := fetchSecretPropertiesChecksum(ctx, e.client, workflow)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: requeueAfterFailure}, objs, err
+	}
+
 	externalCM, err := workflowdef.FetchExternalResourcesConfigMapsRef(e.client, workflow)
 	if err != nil {
 		e.logger.Error(err, "External Resources ConfigMap not found")
 	}
 
 	devBaseContainerImage := workflowdef.GetDefaultWorkflowDevModeImageTag()
-	pl, errPl := platform.GetActivePlatform(ctx, e.client, workflow.Namespace)
 	// check if the Platform available
-	if errPl == nil && len(pl.Spec.DevBaseImage) > 0 {
+	if pl != nil && len(pl.Spec.DevBaseImage) > 0 {
 		devBaseContainerImage = pl.Spec.DevBaseImage
 	}
 
 	deployment, _, err := e.ensurers.deployment.ensure(ctx, workflow,
 		defaultDeploymentMutateVisitor(workflow),
 		naiveApplyImageDeploymentMutateVisitor(devBaseContainerImage),
-		mountDevConfigMapsMutateVisitor(flowDefCM.(*v1.ConfigMap), propsCM.(*v1.ConfigMap), externalCM))
+		mountDevConfigMapsMutateVisitor(flowDefCM.(*v1.ConfigMap), propsCM.(*v1.ConfigMap), externalCM),
+		platformConfigurationMutateVisitor(platformConfig),
+		secretPropertiesMutateVisitor(workflow, secretPropsChecksum))
 	if err != nil {
 		return ctrl.Result{RequeueAfter: requeueAfterFailure}, objs, err
 	}
 	objs = append(objs, deployment)
 
-	service, _, err := e.ensurers.service.ensure(ctx, workflow, defaultServiceMutateVisitor(workflow))
+	networkSpec := getWorkflowNetworkSpec(workflow, pl)
+
+	service, _, err := e.ensurers.service.ensure(ctx, workflow, defaultServiceMutateVisitor(workflow), networkServiceMutateVisitor(workflow, networkSpec))
 	if err != nil {
 		return ctrl.Result{RequeueAfter: requeueAfterFailure}, objs, err
 	}
 	objs = append(objs, service)
 
-	route, _, err := e.ensurers.network.ensure(ctx, workflow)
-	if err != nil {
+	route, err := e.ensurers.network.ensure(ctx, workflow, networkSpec)
+	if isNetworkConfigInvalid(err) {
+		e.logger.Info("Workflow can't be exposed with its network configuration", "error", err.Error())
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.NetworkConfigInvalidReason, err.Error())
+		if _, err = e.performStatusUpdate(ctx, workflow); err != nil {
+			return ctrl.Result{RequeueAfter: requeueAfterFailure}, objs, err
+		}
+		return ctrl.Result{}, objs, nil
+	} else if err != nil {
 		return ctrl.Result{RequeueAfter: requeueAfterFailure}, objs, err
 	}
 	objs = append(objs, route)
@@ -222,6 +265,27 @@ func (e *ensureRunningDevWorkflowReconciliationState) Do(ctx context.Context, wo
 		if _, err = e.performStatusUpdate(ctx, workflow); err != nil {
 			return ctrl.Result{RequeueAfter: requeueAfterFailure}, objs, err
 		}
+		return ctrl.Result{RequeueAfter: requeueAfterIsRunning}, objs, nil
+	}
+
+	// The deployment is available, but the workflow application might have failed to build the workflow
+	previousStatus := workflow.Status.DeepCopy()
+	if _, err = e.enrichers.devModeInfo.Enrich(ctx, workflow); err != nil {
+		e.logger.Info("Failed to probe the workflow application running in dev mode", "error", err.Error())
+		if _, err = e.performStatusUpdateIfChanged(ctx, previousStatus, workflow); err != nil {
+			return ctrl.Result{RequeueAfter: requeueAfterFailure}, objs, err
+		}
+		return ctrl.Result{RequeueAfter: requeueAfterIsRunning}, objs, nil
+	}
+	if workflow.Status.DevMode != nil && len(workflow.Status.DevMode.BuildError) > 0 {
+		e.logger.Info("Workflow application running in dev mode reported a build error")
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DevModeBuildErrorReason, workflow.Status.DevMode.BuildError)
+	} else if workflow.Status.IsDevModeBuildFailed() || workflow.Status.IsNetworkConfigInvalid() {
+		e.logger.Info("Workflow application running in dev mode recovered from the build or network error")
+		workflow.Status.Manager().MarkTrue(api.RunningConditionType)
+	}
+	if _, err = e.performStatusUpdateIfChanged(ctx, previousStatus, workflow); err != nil {
+		return ctrl.Result{RequeueAfter: requeueAfterFailure}, objs, err
 	}
 
 	return ctrl.Result{RequeueAfter: requeueAfterIsRunning}, objs, nil
@@ -279,11 +343,16 @@ func (f *followDeployDevWorkflowReconciliationState) PostReconcile(ctx context.C
 		return err
 	}
 	if deployment != nil && kubeutil.IsDeploymentAvailable(deployment) {
+		previousStatus := workflow.Status.DeepCopy()
 		// Enriching Workflow CR status with needed network info
 		if _, err := f.enrichers.networkInfo.Enrich(ctx, workflow); err != nil {
 			return err
 		}
-		if _, err := f.performStatusUpdate(ctx, workflow); err != nil {
+		// Enriching Workflow CR status with the info reported by the workflow application, it might not be reachable yet
+		if _, err := f.enrichers.devModeInfo.Enrich(ctx, workflow); err != nil {
+			f.logger.Info("Failed to probe the workflow application running in dev mode", "error", err.Error())
+		}
+		if _, err := f.performStatusUpdateIfChanged(ctx, previousStatus, workflow); err != nil {
 			return err
 		}
 	}
@@ -351,6 +420,8 @@ func (r *recoverFromFailureDevReconciliationState) Do(ctx context.Context, workf
 	}
 
 	workflow.Status.RecoverFailureAttempts += 1
+	metrics.IncRecoveryAttempts(workflow.Namespace)
+	r.recorder.Eventf(workflow, v1.EventTypeWarning, api.RecoveryAttemptEventReason, "Attempt %d to recover the workflow deployment", workflow.Status.RecoverFailureAttempts)
 	if _, err := r.performStatusUpdate(ctx, workflow); err != nil {
 		return ctrl.Result{Requeue: false}, nil, err
 	}
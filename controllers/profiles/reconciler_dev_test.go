@@ -21,6 +21,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
 
@@ -48,7 +49,7 @@ func Test_recoverFromFailureNoDeployment(t *testing.T) {
 	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow).Build()
 
 	config := &rest.Config{}
-	reconciler := newDevProfileReconciler(client, config, &logger)
+	reconciler := newDevProfileReconciler(client, config, &record.FakeRecorder{}, &logger)
 
 	// we are in failed state and have no objects
 	result, err := reconciler.Reconcile(context.TODO(), workflow)
@@ -91,7 +92,7 @@ func Test_newDevProfile(t *testing.T) {
 	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow).Build()
 
 	config := &rest.Config{}
-	devReconciler := newDevProfileReconciler(client, config, &logger)
+	devReconciler := newDevProfileReconciler(client, config, &record.FakeRecorder{}, &logger)
 
 	result, err := devReconciler.Reconcile(context.TODO(), workflow)
 	assert.NoError(t, err)
@@ -166,7 +167,7 @@ func Test_devProfileImageDefaultsNoPlatform(t *testing.T) {
 	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
 	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow).Build()
 	config := &rest.Config{}
-	devReconciler := newDevProfileReconciler(client, config, &logger)
+	devReconciler := newDevProfileReconciler(client, config, &record.FakeRecorder{}, &logger)
 
 	result, err := devReconciler.Reconcile(context.TODO(), workflow)
 	assert.NoError(t, err)
@@ -189,7 +190,7 @@ func Test_devProfileWithImageSnapshotOverrideWithPlatform(t *testing.T) {
 	errCreatePlatform := client.Create(context.Background(), platform)
 	assert.Nil(t, errCreatePlatform)
 	config := &rest.Config{}
-	devReconciler := newDevProfileReconciler(client, config, &logger)
+	devReconciler := newDevProfileReconciler(client, config, &record.FakeRecorder{}, &logger)
 
 	result, err := devReconciler.Reconcile(context.TODO(), workflow)
 	assert.NoError(t, err)
@@ -200,6 +201,37 @@ func Test_devProfileWithImageSnapshotOverrideWithPlatform(t *testing.T) {
 	assert.Equal(t, "quay.io/customgroup/custom-swf-builder-nightly:42.43.7", deployment.Spec.Template.Spec.Containers[0].Image)
 }
 
+func Test_devProfileWaitingForSelectedPlatform(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	workflow.Spec.PlatformRef = &operatorapi.PlatformReference{Name: "dev-platform"}
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow).Build()
+	devReconciler := newDevProfileReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger)
+
+	// the selected platform doesn't exist yet
+	result, err := devReconciler.Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, requeueWhileWaitForPlatform, result.RequeueAfter)
+	workflow = test.MustGetWorkflow(t, client, clientruntime.ObjectKeyFromObject(workflow))
+	assert.True(t, workflow.Status.IsWaitingForPlatform())
+	assert.Contains(t, workflow.Status.GetTopLevelCondition().Message, "dev-platform")
+	assert.Zero(t, workflow.Status.RecoverFailureAttempts)
+
+	// the workflow is deployed with the dev base image of the selected platform once it's ready
+	platform := test.GetKogitoServerlessPlatform("../../config/samples/" + test.KogitoServerlessPlatformWithDevBaseImageYamlCR)
+	platform.Name = "dev-platform"
+	platform.Namespace = workflow.Namespace
+	platform.Status.Manager().InitializeConditions()
+	platform.Status.Manager().MarkTrue(api.SucceedConditionType)
+	platform.Status.UpdatePhase()
+	assert.NoError(t, client.Create(context.TODO(), platform))
+	_, err = devReconciler.Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.False(t, workflow.Status.IsWaitingForPlatform())
+	deployment := test.MustGetDeployment(t, client, workflow)
+	assert.Equal(t, "quay.io/customgroup/custom-swf-builder-nightly:42.43.7", deployment.Spec.Template.Spec.Containers[0].Image)
+}
+
 func Test_devProfileWithWPlatformWithoutDevBaseImageAndWithBaseImage(t *testing.T) {
 	logger := ctrllog.FromContext(context.TODO())
 	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
@@ -212,7 +244,7 @@ func Test_devProfileWithWPlatformWithoutDevBaseImageAndWithBaseImage(t *testing.
 	errCreatePlatform := client.Create(context.Background(), platform)
 	assert.Nil(t, errCreatePlatform)
 	config := &rest.Config{}
-	devReconciler := newDevProfileReconciler(client, config, &logger)
+	devReconciler := newDevProfileReconciler(client, config, &record.FakeRecorder{}, &logger)
 
 	result, err := devReconciler.Reconcile(context.TODO(), workflow)
 	assert.NoError(t, err)
@@ -235,7 +267,7 @@ func Test_devProfileWithPlatformWithoutDevBaseImageAndWithoutBaseImage(t *testin
 	errCreatePlatform := client.Create(context.Background(), platform)
 	assert.Nil(t, errCreatePlatform)
 	config := &rest.Config{}
-	devReconciler := newDevProfileReconciler(client, config, &logger)
+	devReconciler := newDevProfileReconciler(client, config, &record.FakeRecorder{}, &logger)
 
 	result, err := devReconciler.Reconcile(context.TODO(), workflow)
 	assert.NoError(t, err)
@@ -251,7 +283,7 @@ func Test_newDevProfileWithExternalConfigMaps(t *testing.T) {
 	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeWithExternalResourceYamlCR, t.Name())
 	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow).Build()
 	config := &rest.Config{}
-	devReconciler := newDevProfileReconciler(client, config, &logger)
+	devReconciler := newDevProfileReconciler(client, config, &record.FakeRecorder{}, &logger)
 	configmapName := "mycamel-configmap"
 	camelXmlRouteFileName := "camelroute-xml"
 	xmlRoute := `<route routeConfigurationId="xmlError">
@@ -285,8 +317,8 @@ func Test_newDevProfileWithExternalConfigMaps(t *testing.T) {
 
 	// check if the objects have been created
 	deployment := test.MustGetDeployment(t, client, workflow)
-	assert.Equal(t, 3, len(deployment.Spec.Template.Spec.Containers[0].VolumeMounts))
-	assert.Equal(t, 3, len(deployment.Spec.Template.Spec.Volumes))
+	assert.Equal(t, 4, len(deployment.Spec.Template.Spec.Containers[0].VolumeMounts))
+	assert.Equal(t, 4, len(deployment.Spec.Template.Spec.Volumes))
 
 	wd := deployment.Spec.Template.Spec.Containers[0].VolumeMounts[0]
 	props := deployment.Spec.Template.Spec.Containers[0].VolumeMounts[1]
@@ -311,10 +343,10 @@ func Test_newDevProfileWithExternalConfigMaps(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 
-	//Now we expect 4 volumes mount wd, props  camelroute.xml and camelroute.yaml
+	//Now we expect 4 volumes mount wd, props, the camel routes and the secret props
 	deployment = test.MustGetDeployment(t, client, workflow)
-	assert.Equal(t, 3, len(deployment.Spec.Template.Spec.Containers[0].VolumeMounts))
-	assert.Equal(t, 3, len(deployment.Spec.Template.Spec.Volumes))
+	assert.Equal(t, 4, len(deployment.Spec.Template.Spec.Containers[0].VolumeMounts))
+	assert.Equal(t, 4, len(deployment.Spec.Template.Spec.Volumes))
 
 	extCamelRouteOne := deployment.Spec.Template.Spec.Containers[0].VolumeMounts[2]
 	assert.Equal(t, extCamelRouteOne.Name, configmapName)
@@ -329,8 +361,8 @@ func Test_newDevProfileWithExternalConfigMaps(t *testing.T) {
 	assert.NotNil(t, result)
 
 	deployment = test.MustGetDeployment(t, client, workflow)
-	assert.Equal(t, 3, len(deployment.Spec.Template.Spec.Containers[0].VolumeMounts))
-	assert.Equal(t, 3, len(deployment.Spec.Template.Spec.Volumes))
+	assert.Equal(t, 4, len(deployment.Spec.Template.Spec.Containers[0].VolumeMounts))
+	assert.Equal(t, 4, len(deployment.Spec.Template.Spec.Volumes))
 
 	// remove the external configmaps without removing the labels
 	errDel := client.Delete(context.Background(), cmUser)
@@ -345,13 +377,32 @@ func Test_newDevProfileWithExternalConfigMaps(t *testing.T) {
 	assert.NotNil(t, result)
 
 	deployment = test.MustGetDeployment(t, client, workflow)
-	assert.Equal(t, 2, len(deployment.Spec.Template.Spec.Volumes))
-	assert.Equal(t, 2, len(deployment.Spec.Template.Spec.Containers[0].VolumeMounts))
+	assert.Equal(t, 3, len(deployment.Spec.Template.Spec.Volumes))
+	assert.Equal(t, 3, len(deployment.Spec.Template.Spec.Containers[0].VolumeMounts))
 	wd = deployment.Spec.Template.Spec.Containers[0].VolumeMounts[0]
 	assert.Equal(t, wd.Name, configMapWorkflowDefVolumeName)
 	assert.Equal(t, wd.MountPath, configMapWorkflowDefMountPath)
 }
 
+func Test_devProfileWithRouteNetworkOutOfOpenShift(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	workflow.Spec.Network = &operatorapi.NetworkSpec{Mode: operatorapi.NetworkModeRoute}
+	client := test.NewKogitoClientBuilderWithoutOpenShift().WithRuntimeObjects(workflow).Build()
+	devReconciler := newDevProfileReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger)
+
+	_, err := devReconciler.Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.True(t, workflow.Status.IsNetworkConfigInvalid())
+	// the workflow application is still deployed, so the network configuration can be fixed
+	test.MustGetDeployment(t, client, workflow)
+
+	workflow.Spec.Network = nil
+	_, err = devReconciler.Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.False(t, workflow.Status.IsNetworkConfigInvalid())
+}
+
 func createConfigMapBase(namespace string, name string, cmData map[string]string) clientruntime.Object {
 	cm := &corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
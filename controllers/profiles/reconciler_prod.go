@@ -22,6 +22,7 @@ import (
 	kubeutil "github.com/kiegroup/kogito-serverless-operator/utils/kubernetes"
 
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
@@ -30,11 +31,13 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/kiegroup/kogito-serverless-operator/api"
+	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
 
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 	"github.com/kiegroup/kogito-serverless-operator/controllers/builder"
@@ -48,9 +51,10 @@ type prodProfile struct {
 }
 
 const (
-	requeueAfterStartingBuild   = 3 * time.Minute
-	requeueWhileWaitForBuild    = 1 * time.Minute
-	requeueWhileWaitForPlatform = 5 * time.Second
+	requeueAfterStartingBuild    = 3 * time.Minute
+	requeueWhileWaitForBuild     = 1 * time.Minute
+	requeueWhileWaitForPlatform  = 5 * time.Second
+	requeueWhileWaitForPromotion = 10 * time.Second
 
 	quarkusProdConfigMountPath = "/deployments/config"
 )
@@ -61,28 +65,49 @@ const (
 type prodObjectEnsurers struct {
 	deployment          ObjectEnsurer
 	service             ObjectEnsurer
+	network             *networkObjectEnsurers
+	monitoring          *monitoringObjectEnsurers
 	propertiesConfigMap ObjectEnsurer
+	secretProperties    ObjectEnsurer
 }
 
 func newProdObjectEnsurers(support *stateSupport) *prodObjectEnsurers {
 	return &prodObjectEnsurers{
 		deployment:          newDefaultObjectEnsurer(support.client, support.logger, defaultDeploymentCreator),
 		service:             newDefaultObjectEnsurer(support.client, support.logger, defaultServiceCreator),
+		network:             newNetworkObjectEnsurers(support),
+		monitoring:          newMonitoringObjectEnsurers(support),
 		propertiesConfigMap: newDefaultObjectEnsurer(support.client, support.logger, workflowPropsConfigMapCreator),
+		secretProperties:    newDefaultObjectEnsurer(support.client, support.logger, workflowSecretPropsCreator),
 	}
 }
 
-func newProdProfileReconciler(client client.Client, config *rest.Config, logger *logr.Logger) ProfileReconciler {
+// prodObjectEnrichers is a struct for the objects that ReconciliationState needs to enrich the workflow status for the Production profile.
+type prodObjectEnrichers struct {
+	networkInfo *statusEnricher
+}
+
+func newProdObjectEnrichers(support *stateSupport) *prodObjectEnrichers {
+	return &prodObjectEnrichers{
+		networkInfo: newStatusEnricher(support.client, support.logger, networkStatusEnricher),
+	}
+}
+
+func newProdProfileReconciler(client client.Client, config *rest.Config, recorder record.EventRecorder, logger *logr.Logger) ProfileReconciler {
 	support := &stateSupport{
-		logger: logger,
-		client: client,
+		logger:   logger,
+		client:   client,
+		recorder: recorder,
 	}
 	// the reconciliation state machine
 	stateMachine := newReconciliationStateMachine(
 		logger,
+		recorder,
+		&prebuiltImageReconciliationState{stateSupport: support},
+		&promoteImageReconciliationState{stateSupport: support},
 		&newBuilderReconciliationState{stateSupport: support},
 		&followBuildStatusReconciliationState{stateSupport: support},
-		&deployWorkflowReconciliationState{stateSupport: support, ensurers: newProdObjectEnsurers(support)},
+		&deployWorkflowReconciliationState{stateSupport: support, ensurers: newProdObjectEnsurers(support), enrichers: newProdObjectEnrichers(support)},
 	)
 	reconciler := &prodProfile{
 		baseReconciler: newBaseProfileReconciler(support, stateMachine),
@@ -100,23 +125,30 @@ type newBuilderReconciliationState struct {
 }
 
 func (h *newBuilderReconciliationState) CanReconcile(workflow *operatorapi.KogitoServerlessWorkflow) bool {
-	return workflow.Status.GetTopLevelCondition().IsUnknown() ||
-		workflow.Status.IsWaitingForPlatform() ||
-		workflow.Status.IsBuildFailed()
+	return isBuiltByOperator(workflow) &&
+		(workflow.Status.GetTopLevelCondition().IsUnknown() ||
+			workflow.Status.IsWaitingForPlatform() ||
+			workflow.Status.IsBuildFailed() ||
+			workflow.Status.IsBuildConfigInvalid())
 }
 
 func (h *newBuilderReconciliationState) Do(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow) (ctrl.Result, []client.Object, error) {
-	_, err := platform.GetActivePlatform(ctx, h.client, workflow.Namespace)
+	pl, err := platform.GetWorkflowPlatform(ctx, h.client, workflow)
 	if err != nil {
-		if errors.IsNotFound(err) {
+		if platform.IsPlatformUnavailable(err) {
 			workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForPlatformReason,
-				"No active Platform for namespace %s so the workflow cannot be built.", workflow.Namespace)
+				"%s so the workflow cannot be built.", platformUnavailableMessage(workflow, err))
 			_, err = h.performStatusUpdate(ctx, workflow)
 			return ctrl.Result{RequeueAfter: requeueWhileWaitForPlatform}, nil, err
 		}
-		h.logger.Error(err, "Failed to get active platform")
+		h.logger.Error(err, "Failed to get the workflow platform")
 		return ctrl.Result{RequeueAfter: requeueWhileWaitForPlatform}, nil, err
 	}
+	// the workflow or platform changes fixing the build configuration wake up the workflow
+	if markBuildConfigInvalid(workflow, pl) {
+		_, err = h.performStatusUpdate(ctx, workflow)
+		return ctrl.Result{}, nil, err
+	}
 	// If there is an active platform we have got all the information to build but...
 	// ...let's check before if we have got already a build!
 	buildManager := builder.NewKogitoServerlessBuildManager(ctx, h.client)
@@ -137,20 +169,37 @@ func (h *newBuilderReconciliationState) Do(ctx context.Context, workflow *operat
 	return ctrl.Result{RequeueAfter: requeueAfterStartingBuild}, nil, err
 }
 
+// markBuildConfigInvalid marks the workflow as not built when its build configuration, as merged with the platform one, is invalid
+func markBuildConfigInvalid(workflow *operatorapi.KogitoServerlessWorkflow, pl *operatorapi.KogitoServerlessPlatform) bool {
+	if err := workflowdef.ValidateBuildAttestation(workflow, pl); err != nil {
+		workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.BuildConfigInvalidReason, err.Error())
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForBuildReason, "")
+		return true
+	}
+	return false
+}
+
 type followBuildStatusReconciliationState struct {
 	*stateSupport
 }
 
 func (h *followBuildStatusReconciliationState) CanReconcile(workflow *operatorapi.KogitoServerlessWorkflow) bool {
-	return workflow.Status.IsBuildRunningOrUnknown()
+	return isBuiltByOperator(workflow) && workflow.Status.IsBuildRunningOrUnknown()
 }
 
 func (h *followBuildStatusReconciliationState) Do(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow) (ctrl.Result, []client.Object, error) {
 	// Let's retrieve the build to check the status
 	build, err := builder.NewKogitoServerlessBuildManager(ctx, h.client).GetOrCreateBuild(workflow)
 	if err != nil {
+		// the workflow may have selected another platform while building
+		if platform.IsPlatformUnavailable(err) {
+			workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForPlatformReason,
+				"%s so the workflow cannot be built.", platformUnavailableMessage(workflow, err))
+			_, err = h.performStatusUpdate(ctx, workflow)
+			return ctrl.Result{RequeueAfter: requeueWhileWaitForPlatform}, nil, err
+		}
 		h.logger.Error(err, "Failed to get or create the build for the workflow.")
-		workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.BuildFailedReason, build.Status.Error)
+		workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.BuildFailedReason, "Failed to get or create the build: %v", err)
 		if _, err = h.performStatusUpdate(ctx, workflow); err != nil {
 			return ctrl.Result{}, nil, err
 		}
@@ -176,9 +225,120 @@ func (h *followBuildStatusReconciliationState) Do(ctx context.Context, workflow
 	return ctrl.Result{RequeueAfter: requeueWhileWaitForBuild}, nil, nil
 }
 
+// promoteImageReconciliationState copies the image promoted by the workflow to the platform registry instead of building the workflow
+type promoteImageReconciliationState struct {
+	*stateSupport
+}
+
+func (h *promoteImageReconciliationState) CanReconcile(workflow *operatorapi.KogitoServerlessWorkflow) bool {
+	return workflow.Spec.Image == nil && workflow.Spec.Promotion != nil &&
+		(!workflow.Status.IsPromoted(workflow.Spec.Promotion) || !workflow.Status.GetCondition(api.BuiltConditionType).IsTrue())
+}
+
+func (h *promoteImageReconciliationState) Do(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow) (ctrl.Result, []client.Object, error) {
+	pl, err := platform.GetWorkflowPlatform(ctx, h.client, workflow)
+	if err != nil {
+		if platform.IsPlatformUnavailable(err) {
+			workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForPlatformReason,
+				"%s so the workflow image cannot be promoted.", platformUnavailableMessage(workflow, err))
+			_, err = h.performStatusUpdate(ctx, workflow)
+			return ctrl.Result{RequeueAfter: requeueWhileWaitForPlatform}, nil, err
+		}
+		h.logger.Error(err, "Failed to get the workflow platform")
+		return ctrl.Result{RequeueAfter: requeueWhileWaitForPlatform}, nil, err
+	}
+
+	image, err := builder.PromoteWorkflowImage(ctx, h.client, pl, workflow)
+	if err != nil {
+		h.logger.Error(err, "Failed to promote the workflow image", "Image", workflow.Spec.Promotion.Image)
+		workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.ImagePromotionFailedReason,
+			"Failed to promote the image %s: %v", workflow.Spec.Promotion.Image, err)
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForBuildReason, "")
+		_, err = h.performStatusUpdate(ctx, workflow)
+		return ctrl.Result{RequeueAfter: requeueAfterFailure}, nil, err
+	}
+	if len(image) == 0 {
+		// the image is still being copied
+		workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.ImagePromotingReason, "Promoting the image %s", workflow.Spec.Promotion.Image)
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForBuildReason, "")
+		_, err = h.performStatusUpdate(ctx, workflow)
+		return ctrl.Result{RequeueAfter: requeueWhileWaitForPromotion}, nil, err
+	}
+
+	h.logger.Info("Workflow image promoted", "Source", workflow.Spec.Promotion.Image, "Image", image)
+	now := metav1.Now()
+	workflow.Status.Promotion = &operatorapi.PromotionStatus{Source: workflow.Spec.Promotion.Image, Image: image, PromotionTime: &now}
+	workflow.Status.Manager().MarkTrueWithReason(api.BuiltConditionType, api.ImagePromotedReason, "Image promoted from %s", workflow.Spec.Promotion.Image)
+	workflow.Status.Manager().MarkUnknown(api.RunningConditionType, "", "")
+	_, err = h.performStatusUpdate(ctx, workflow)
+	return ctrl.Result{Requeue: true}, nil, err
+}
+
+// prebuiltImageReconciliationState checks the prebuilt image of the workflow in its registry instead of building the workflow
+type prebuiltImageReconciliationState struct {
+	*stateSupport
+}
+
+func (h *prebuiltImageReconciliationState) CanReconcile(workflow *operatorapi.KogitoServerlessWorkflow) bool {
+	return workflow.Spec.Image != nil &&
+		(workflow.Spec.Promotion != nil || !workflow.Status.IsPrebuiltImageValidated(workflow.Spec.Image) || !workflow.Status.GetCondition(api.BuiltConditionType).IsTrue())
+}
+
+func (h *prebuiltImageReconciliationState) Do(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow) (ctrl.Result, []client.Object, error) {
+	if workflow.Spec.Promotion != nil {
+		workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.ImageSourceConflictReason,
+			"The workflow references both a prebuilt image and a promoted image, only one of image and promotion can be set")
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForBuildReason, "")
+		_, err := h.performStatusUpdate(ctx, workflow)
+		return ctrl.Result{}, nil, err
+	}
+
+	image, err := builder.ResolvePrebuiltWorkflowImage(ctx, h.client, workflow)
+	if err != nil {
+		h.logger.Error(err, "Failed to validate the prebuilt workflow image", "Image", workflow.Spec.Image.Name)
+		workflow.Status.Manager().MarkFalse(api.BuiltConditionType, api.PrebuiltImageInvalidReason,
+			"Failed to validate the prebuilt image %s: %v", workflow.Spec.Image.Name, err)
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForBuildReason, "")
+		_, err = h.performStatusUpdate(ctx, workflow)
+		return ctrl.Result{RequeueAfter: requeueAfterFailure}, nil, err
+	}
+
+	h.logger.Info("Prebuilt workflow image validated", "Name", workflow.Spec.Image.Name, "Image", image)
+	now := metav1.Now()
+	workflow.Status.Prebuilt = &operatorapi.PrebuiltImageStatus{Name: workflow.Spec.Image.Name, Image: image, ValidationTime: &now}
+	workflow.Status.Manager().MarkTrueWithReason(api.BuiltConditionType, api.PrebuiltImageValidatedReason, "Prebuilt image %s found", workflow.Spec.Image.Name)
+	workflow.Status.Manager().MarkUnknown(api.RunningConditionType, "", "")
+	_, err = h.performStatusUpdate(ctx, workflow)
+	return ctrl.Result{Requeue: true}, nil, err
+}
+
+// isDeployedImageChanged checks if the Deployment runs another image than the prebuilt or promoted one, pinned by digest.
+// Images built by the operator keep their tag, OpenShift image triggers resolve it in the Deployment, so they aren't compared.
+func isDeployedImageChanged(workflow *operatorapi.KogitoServerlessWorkflow, deployment *appsv1.Deployment, image string) bool {
+	containers := deployment.Spec.Template.Spec.Containers
+	return !isBuiltByOperator(workflow) && (len(containers) == 0 || containers[0].Image != image)
+}
+
+// getImagePullSecrets gets the Secrets pulling the prebuilt image, or the image promoted to the platform registry
+func getImagePullSecrets(workflow *operatorapi.KogitoServerlessWorkflow, pl *operatorapi.KogitoServerlessPlatform) []v1.LocalObjectReference {
+	if workflow.Spec.Image != nil && workflow.Spec.Image.RegistrySecretRef != nil {
+		return []v1.LocalObjectReference{*workflow.Spec.Image.RegistrySecretRef}
+	}
+	if workflow.Spec.Promotion != nil && len(pl.Spec.BuildPlatform.Registry.Secret) > 0 {
+		return []v1.LocalObjectReference{{Name: pl.Spec.BuildPlatform.Registry.Secret}}
+	}
+	return nil
+}
+
+// isBuiltByOperator checks if the workflow image is built by the operator, rather than prebuilt or promoted
+func isBuiltByOperator(workflow *operatorapi.KogitoServerlessWorkflow) bool {
+	return workflow.Spec.Image == nil && workflow.Spec.Promotion == nil
+}
+
 type deployWorkflowReconciliationState struct {
 	*stateSupport
 	ensurers           *prodObjectEnsurers
+	enrichers          *prodObjectEnrichers
 	deploymentVisitors []mutateVisitor
 }
 
@@ -187,20 +347,42 @@ func (h *deployWorkflowReconciliationState) CanReconcile(workflow *operatorapi.K
 }
 
 func (h *deployWorkflowReconciliationState) Do(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow) (ctrl.Result, []client.Object, error) {
-	pl, err := platform.GetActivePlatform(ctx, h.client, workflow.Namespace)
+	pl, err := platform.GetWorkflowPlatform(ctx, h.client, workflow)
 	if err != nil {
-		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForPlatformReason,
-			"No active Platform for namespace %s so the resWorkflowDef cannot be deployed. Waiting for an active platform", workflow.Namespace)
+		if platform.IsPlatformUnavailable(err) {
+			workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.WaitingForPlatformReason,
+				"%s so the workflow cannot be deployed.", platformUnavailableMessage(workflow, err))
+			_, err = h.performStatusUpdate(ctx, workflow)
+			return ctrl.Result{RequeueAfter: requeueWhileWaitForPlatform}, nil, err
+		}
+		h.logger.Error(err, "Failed to get the workflow platform")
 		return ctrl.Result{RequeueAfter: requeueWhileWaitForPlatform}, nil, err
 	}
 
-	if h.isWorkflowChanged(workflow) { // Let's check that the 2 resWorkflowDef definition are different
-		workflow.Status.Manager().MarkUnknown(api.RunningConditionType, "", "")
-		buildManager := builder.NewKogitoServerlessBuildManager(ctx, h.client)
-		build, err := buildManager.GetOrCreateBuild(workflow)
-		if err != nil {
+	// prebuilt and promoted workflows aren't built, their states check or copy the image again when it changes
+	if workflow.Spec.Image != nil {
+		return h.handleObjects(ctx, workflow, workflow.Status.Prebuilt.Image, pl)
+	}
+	if workflow.Spec.Promotion != nil {
+		return h.handleObjects(ctx, workflow, workflow.Status.Promotion.Image, pl)
+	}
+
+	buildManager := builder.NewKogitoServerlessBuildManager(ctx, h.client)
+	build, err := buildManager.GetOrCreateBuild(workflow)
+	if err != nil {
+		return ctrl.Result{}, nil, err
+	}
+	// the platform may require other extensions since the build, e.g. when its persistence or services change
+	extensionsChanged, err := buildManager.RefreshExtensions(build, workflow, pl)
+	if err != nil {
+		return ctrl.Result{}, nil, err
+	}
+	if h.isWorkflowChanged(workflow) || extensionsChanged { // Let's check that the 2 resWorkflowDef definition are different
+		if markBuildConfigInvalid(workflow, pl) {
+			_, err = h.performStatusUpdate(ctx, workflow)
 			return ctrl.Result{}, nil, err
 		}
+		workflow.Status.Manager().MarkUnknown(api.RunningConditionType, "", "")
 		if err = buildManager.MarkToRestart(build); err != nil {
 			return ctrl.Result{}, nil, err
 		}
@@ -216,18 +398,40 @@ func (h *deployWorkflowReconciliationState) Do(ctx context.Context, workflow *op
 	if len(pl.Spec.BuildPlatform.Registry.Address) > 0 {
 		image = pl.Spec.BuildPlatform.Registry.Address + "/" + image
 	}
-	return h.handleObjects(ctx, workflow, image)
+	return h.handleObjects(ctx, workflow, image, pl)
 }
 
-func (h *deployWorkflowReconciliationState) handleObjects(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow, image string) (reconcile.Result, []client.Object, error) {
+func (h *deployWorkflowReconciliationState) handleObjects(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow, image string, pl *operatorapi.KogitoServerlessPlatform) (reconcile.Result, []client.Object, error) {
+	platformConfig, err := platform.GetConfiguration(ctx, h.client, pl)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: requeueAfterFailure}, nil, err
+	}
+
+	persistence := workflowdef.GetPersistence(workflow, pl)
+	persistenceProps, err := getPersistenceProperties(workflow, persistence)
+	if err != nil {
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.DeploymentFailureReason, err.Error())
+		_, err = h.performStatusUpdate(ctx, workflow)
+		return ctrl.Result{RequeueAfter: requeueAfterFailure}, nil, err
+	}
+
 	// the dev one is ok for now
-	propsCM, _, err := h.ensurers.propertiesConfigMap.ensure(ctx, workflow, ensureProdWorkflowPropertiesConfigMapMutator(workflow))
+	propsCM, _, err := h.ensurers.propertiesConfigMap.ensure(ctx, workflow, ensureProdWorkflowPropertiesConfigMapMutator(workflow, getAddonsProperties(workflowdef.GetAddons(workflow, pl)), platformConfig.Properties, persistenceProps))
 	if err != nil {
 		return ctrl.Result{}, nil, err
 	}
+	secretProps, _, err := h.ensurers.secretProperties.ensure(ctx, workflow, ensureWorkflowSecretPropsMutator(workflow))
+	if err != nil {
+		return ctrl.Result{}, nil, err
+	}
+	secretPropsChecksum, err := fetchSecretPropertiesChecksum(ctx, h.client, workflow)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: requeueAfterFailure}, nil, err
+	}
 
 	// Check if this Deployment already exists
 	// TODO: we should NOT do this. The ensurers are there to do exactly this fetch. Review once we refactor this reconciliation algorithm. See https://issues.redhat.com/browse/KOGITO-8524
+	pullSecrets := getImagePullSecrets(workflow, pl)
 	existingDeployment := &appsv1.Deployment{}
 	requeue := false
 	if err := h.client.Get(ctx, client.ObjectKeyFromObject(workflow), existingDeployment); err != nil {
@@ -238,31 +442,61 @@ func (h *deployWorkflowReconciliationState) handleObjects(ctx context.Context, w
 			h.ensurers.deployment.ensure(
 				ctx,
 				workflow,
-				h.getDeploymentMutateVisitors(workflow, image, propsCM.(*v1.ConfigMap))...,
+				h.getDeploymentMutateVisitors(workflow, image, pullSecrets, propsCM.(*v1.ConfigMap), platformConfig, secretPropsChecksum, persistence)...,
 			)
 		if err != nil {
 			return reconcile.Result{}, nil, err
 		}
 		existingDeployment, _ = deployment.(*appsv1.Deployment)
 		requeue = true
-	}
-	// TODO: verify if deployment is ready. See https://issues.redhat.com/browse/KOGITO-8524
-
-	existingService := &v1.Service{}
-	if err := h.client.Get(ctx, client.ObjectKeyFromObject(workflow), existingService); err != nil {
-		if !errors.IsNotFound(err) {
-			return reconcile.Result{Requeue: false}, nil, err
-		}
-		service, _, err := h.ensurers.service.ensure(ctx, workflow, defaultServiceMutateVisitor(workflow))
+	} else if existingDeployment.Spec.Template.Annotations[metadata.PlatformConfigurationChecksumAnnotation] != platformConfig.Checksum() ||
+		existingDeployment.Spec.Template.Annotations[metadata.SecretPropertiesChecksumAnnotation] != secretPropsChecksum ||
+		existingDeployment.Spec.Template.Annotations[metadata.PersistenceChecksumAnnotation] != getPersistenceChecksum(persistence) ||
+		isDeployedImageChanged(workflow, existingDeployment, image) ||
+		isImagePullSecretsChanged(existingDeployment, pullSecrets) {
+		h.logger.Info("Platform configuration, workflow Secrets, persistence or image changed, rolling out the Deployment", "Deployment.Name", existingDeployment.Name)
+		deployment, _, err := h.ensurers.deployment.ensure(ctx, workflow, h.getDeploymentMutateVisitors(workflow, image, pullSecrets, propsCM.(*v1.ConfigMap), platformConfig, secretPropsChecksum, persistence)...)
 		if err != nil {
 			return reconcile.Result{}, nil, err
 		}
-		existingService, _ = service.(*v1.Service)
+		existingDeployment, _ = deployment.(*appsv1.Deployment)
 		requeue = true
 	}
+	// TODO: verify if deployment is ready. See https://issues.redhat.com/browse/KOGITO-8524
+
+	networkSpec := fetchWorkflowNetworkSpec(ctx, h.client, workflow)
+	service, serviceOp, err := h.ensurers.service.ensure(ctx, workflow, defaultServiceMutateVisitor(workflow), networkServiceMutateVisitor(workflow, networkSpec))
+	if err != nil {
+		return reconcile.Result{}, nil, err
+	}
+	existingService, _ := service.(*v1.Service)
+	requeue = requeue || serviceOp == controllerutil.OperationResultCreated
 	// TODO: verify if service is ready. See https://issues.redhat.com/browse/KOGITO-8524
 
-	objs := []client.Object{existingDeployment, existingService, propsCM}
+	objs := []client.Object{existingDeployment, existingService, propsCM, secretProps}
+
+	network, err := h.ensurers.network.ensure(ctx, workflow, networkSpec)
+	if isNetworkConfigInvalid(err) {
+		h.logger.Info("Workflow can't be exposed with its network configuration", "error", err.Error())
+		workflow.Status.Manager().MarkFalse(api.RunningConditionType, api.NetworkConfigInvalidReason, err.Error())
+		if _, err = h.performStatusUpdate(ctx, workflow); err != nil {
+			return reconcile.Result{Requeue: false}, nil, err
+		}
+		return reconcile.Result{}, objs, nil
+	} else if err != nil {
+		return reconcile.Result{}, nil, err
+	}
+	if network != nil {
+		objs = append(objs, network)
+	}
+
+	monitoring, err := h.ensurers.monitoring.ensure(ctx, workflow, workflowdef.GetMonitoring(workflow, pl))
+	if err != nil {
+		return reconcile.Result{}, nil, err
+	}
+	if monitoring != nil {
+		objs = append(objs, monitoring)
+	}
 
 	if !requeue {
 		h.logger.Info("Skip reconcile: Deployment and service already exists",
@@ -282,17 +516,37 @@ func (h *deployWorkflowReconciliationState) handleObjects(ctx context.Context, w
 	return reconcile.Result{RequeueAfter: requeueAfterFollowDeployment}, objs, nil
 }
 
+func (h *deployWorkflowReconciliationState) PostReconcile(ctx context.Context, workflow *operatorapi.KogitoServerlessWorkflow) error {
+	if !workflow.Status.IsReady() {
+		return nil
+	}
+	// Enriching Workflow CR status with needed network info
+	if _, err := h.enrichers.networkInfo.Enrich(ctx, workflow); err != nil {
+		return err
+	}
+	_, err := h.performStatusUpdate(ctx, workflow)
+	return err
+}
+
 // getDeploymentMutateVisitors gets the deployment mutate visitors based on the current plat
-func (h *deployWorkflowReconciliationState) getDeploymentMutateVisitors(workflow *operatorapi.KogitoServerlessWorkflow, image string, configMap *v1.ConfigMap) []mutateVisitor {
+func (h *deployWorkflowReconciliationState) getDeploymentMutateVisitors(workflow *operatorapi.KogitoServerlessWorkflow, image string, pullSecrets []v1.LocalObjectReference, configMap *v1.ConfigMap, platformConfig *platform.Configuration, secretPropsChecksum string, persistence *operatorapi.PersistenceSpec) []mutateVisitor {
 	if utils.IsOpenShift() {
 		return []mutateVisitor{defaultDeploymentMutateVisitor(workflow),
 			mountProdConfigMapsMutateVisitor(configMap),
 			addOpenShiftImageTriggerDeploymentMutateVisitor(image),
-			naiveApplyImageDeploymentMutateVisitor(image)}
+			naiveApplyImageDeploymentMutateVisitor(image),
+			imagePullSecretsMutateVisitor(pullSecrets),
+			platformConfigurationMutateVisitor(platformConfig),
+			secretPropertiesMutateVisitor(workflow, secretPropsChecksum),
+			persistenceMutateVisitor(persistence)}
 	}
 	return []mutateVisitor{defaultDeploymentMutateVisitor(workflow),
 		naiveApplyImageDeploymentMutateVisitor(image),
-		mountProdConfigMapsMutateVisitor(configMap)}
+		imagePullSecretsMutateVisitor(pullSecrets),
+		mountProdConfigMapsMutateVisitor(configMap),
+		platformConfigurationMutateVisitor(platformConfig),
+		secretPropertiesMutateVisitor(workflow, secretPropsChecksum),
+		persistenceMutateVisitor(persistence)}
 }
 
 // mountDevConfigMapsMutateVisitor mounts the required configMaps in the Workflow Dev Deployment
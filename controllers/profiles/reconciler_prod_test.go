@@ -16,20 +16,36 @@ package profiles
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientruntime "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/kiegroup/kogito-serverless-operator/api"
+	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/registry"
+	cbtest "github.com/kiegroup/kogito-serverless-operator/container-builder/util/test"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/builder"
 
 	"github.com/kiegroup/kogito-serverless-operator/test"
+	"github.com/kiegroup/kogito-serverless-operator/utils"
 )
 
 func Test_reconcilerProdBuildConditions(t *testing.T) {
@@ -41,7 +57,7 @@ func Test_reconcilerProdBuildConditions(t *testing.T) {
 	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform).Build()
 
 	config := &rest.Config{}
-	result, err := NewReconciler(client, config, &logger, workflow).Reconcile(context.TODO(), workflow)
+	result, err := NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
 	assert.NoError(t, err)
 
 	assert.NotNil(t, result.RequeueAfter)
@@ -49,7 +65,7 @@ func Test_reconcilerProdBuildConditions(t *testing.T) {
 	assert.False(t, workflow.Status.IsReady())
 
 	// still building
-	result, err = NewReconciler(client, config, &logger, workflow).Reconcile(context.TODO(), workflow)
+	result, err = NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
 	assert.NoError(t, err)
 	assert.Equal(t, requeueWhileWaitForBuild, result.RequeueAfter)
 	assert.True(t, workflow.Status.IsBuildRunningOrUnknown())
@@ -62,7 +78,7 @@ func Test_reconcilerProdBuildConditions(t *testing.T) {
 	assert.NoError(t, client.Status().Update(context.TODO(), build))
 
 	// last reconciliation cycle waiting for build
-	result, err = NewReconciler(client, config, &logger, workflow).Reconcile(context.TODO(), workflow)
+	result, err = NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
 	assert.NoError(t, err)
 	assert.Equal(t, requeueWhileWaitForBuild, result.RequeueAfter)
 	assert.False(t, workflow.Status.IsBuildRunningOrUnknown())
@@ -70,19 +86,169 @@ func Test_reconcilerProdBuildConditions(t *testing.T) {
 	assert.Equal(t, api.WaitingForBuildReason, workflow.Status.GetTopLevelCondition().Reason)
 
 	// now we create the objects
-	result, err = NewReconciler(client, config, &logger, workflow).Reconcile(context.TODO(), workflow)
+	result, err = NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
 	assert.NoError(t, err)
 	assert.False(t, workflow.Status.IsBuildRunningOrUnknown())
 	assert.False(t, workflow.Status.IsReady())
 	assert.Equal(t, api.WaitingForDeploymentReason, workflow.Status.GetTopLevelCondition().Reason)
 
 	// now with the objects created, it should be running
-	result, err = NewReconciler(client, config, &logger, workflow).Reconcile(context.TODO(), workflow)
+	result, err = NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
 	assert.NoError(t, err)
 	assert.False(t, workflow.Status.IsBuildRunningOrUnknown())
 	assert.True(t, workflow.Status.IsReady())
 }
 
+func Test_reconcilerProdTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Status.Applied = workflow.Spec
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform).Build()
+
+	_, err := NewReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "ReconciliationState.Do", spans[0].Name())
+	assert.Equal(t, "reconciliationStateMachine.do", spans[1].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+
+	// the build created by the workflow reconciliation carries its trace context
+	build := &operatorapi.KogitoServerlessBuild{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), build))
+	assert.Contains(t, build.Annotations["sw.kogito.kie.org/trace.traceparent"], spans[1].SpanContext().TraceID().String())
+}
+
+func Test_reconcilerProdSelectedPlatform(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.PlatformRef = &operatorapi.PlatformReference{Name: "release-registry"}
+	workflow.Status.Applied = workflow.Spec
+	activePlatform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, activePlatform).Build()
+	config := &rest.Config{}
+
+	// the selected platform doesn't exist, the active one must not be used
+	result, err := NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, requeueWhileWaitForPlatform, result.RequeueAfter)
+	assert.True(t, workflow.Status.IsWaitingForPlatform())
+	assert.Contains(t, workflow.Status.GetTopLevelCondition().Message, "release-registry")
+
+	// the selected platform exists, but it's not ready yet
+	selected := test.GetKogitoServerlessPlatform("../../config/samples/" + test.KogitoServerlessPlatformWithCacheYamlCR)
+	selected.Name = "release-registry"
+	selected.Namespace = t.Name()
+	selected.Spec.BuildTemplate.Timeout = metav1.Duration{Duration: 42 * time.Minute}
+	assert.NoError(t, client.Create(context.TODO(), selected))
+	result, err = NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, requeueWhileWaitForPlatform, result.RequeueAfter)
+	assert.True(t, workflow.Status.IsWaitingForPlatform())
+
+	// once ready, the build comes from the selected platform
+	selected.Status.Manager().InitializeConditions()
+	selected.Status.Manager().MarkTrue(api.SucceedConditionType)
+	selected.Status.UpdatePhase()
+	assert.NoError(t, client.Status().Update(context.TODO(), selected))
+	_, err = NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.False(t, workflow.Status.IsWaitingForPlatform())
+
+	build := &operatorapi.KogitoServerlessBuild{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), build))
+	assert.Equal(t, "release-registry", build.Annotations[metadata.PlatformAnnotation])
+	assert.Equal(t, 42*time.Minute, build.Spec.BuildTemplate.Timeout.Duration)
+
+	// switching back to the active platform, the build follows its template
+	workflow.Spec.PlatformRef = nil
+	build, err = builder.NewKogitoServerlessBuildManager(context.TODO(), client).GetOrCreateBuild(workflow)
+	assert.NoError(t, err)
+	assert.NotContains(t, build.Annotations, metadata.PlatformAnnotation)
+	assert.Equal(t, activePlatform.Spec.BuildTemplate.Timeout, build.Spec.BuildTemplate.Timeout)
+}
+
+func Test_reconcilerProdSwitchPlatformWhileBuilding(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Status.Applied = workflow.Spec
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform).Build()
+	config := &rest.Config{}
+
+	_, err := NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.True(t, workflow.Status.IsBuildRunningOrUnknown())
+
+	// the running build was created for the active platform, the newly selected one doesn't exist
+	workflow.Spec.PlatformRef = &operatorapi.PlatformReference{Name: "release-registry"}
+	workflow.Status.Applied = workflow.Spec
+	result, err := NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, requeueWhileWaitForPlatform, result.RequeueAfter)
+	assert.True(t, workflow.Status.IsWaitingForPlatform())
+	assert.Contains(t, workflow.Status.GetTopLevelCondition().Message, "release-registry")
+
+	persisted := &operatorapi.KogitoServerlessWorkflow{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), persisted))
+	assert.True(t, persisted.Status.IsWaitingForPlatform())
+}
+
+func Test_deployWorkflowReconciliationStateWaitingForPlatform(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.PlatformRef = &operatorapi.PlatformReference{Name: "release-registry"}
+	workflow.Status.Applied = workflow.Spec
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow).Build()
+	handler := &deployWorkflowReconciliationState{
+		stateSupport: fakeReconcilerSupport(client),
+		ensurers:     newProdObjectEnsurers(&stateSupport{logger: &logger, client: client}),
+	}
+
+	result, objects, err := handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Nil(t, objects)
+	assert.Equal(t, requeueWhileWaitForPlatform, result.RequeueAfter)
+
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), workflow))
+	assert.True(t, workflow.Status.IsWaitingForPlatform())
+	assert.Contains(t, workflow.Status.GetTopLevelCondition().Message, "cannot be deployed")
+}
+
+func Test_reconcilerProdBuildConfigInvalid(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.Build = &operatorapi.WorkflowBuildSpec{Platforms: []string{"linux/amd64", "linux/arm64"}}
+	workflow.Status.Applied = workflow.Spec
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, t.Name())
+	platform.Spec.BuildTemplate.Attestation = &operatorapi.AttestationSpec{SBOM: operatorapi.SBOMFormatSPDX}
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform).Build()
+	config := &rest.Config{}
+
+	// the platform attests the images, which can't be combined with the multi-platform build of the workflow
+	result, err := NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	assert.True(t, workflow.Status.IsBuildConfigInvalid())
+	assert.Contains(t, workflow.Status.GetCondition(api.BuiltConditionType).Message, "multi-platform")
+	assert.True(t, errors.IsNotFound(client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), &operatorapi.KogitoServerlessBuild{})))
+
+	// the workflow is built once it targets a single platform
+	workflow.Spec.Build.Platforms = []string{"linux/arm64"}
+	workflow.Status.Applied = workflow.Spec
+	_, err = NewReconciler(client, config, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.False(t, workflow.Status.IsBuildConfigInvalid())
+	assert.True(t, workflow.Status.IsBuildRunningOrUnknown())
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), &operatorapi.KogitoServerlessBuild{}))
+}
+
 func Test_deployWorkflowReconciliationHandler_handleObjects(t *testing.T) {
 	logger := ctrllog.FromContext(context.TODO())
 	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
@@ -98,7 +264,7 @@ func Test_deployWorkflowReconciliationHandler_handleObjects(t *testing.T) {
 	assert.Greater(t, result.RequeueAfter, int64(0))
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Len(t, objects, 3)
+	assert.Len(t, objects, 4)
 
 	deployment := &v1.Deployment{}
 	err = client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), deployment)
@@ -129,6 +295,142 @@ func Test_deployWorkflowReconciliationHandler_handleObjects(t *testing.T) {
 
 }
 
+func Test_deployWorkflowReconciliationHandler_secretProperties(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.Secrets = []operatorapi.SecretPropertiesSpec{
+		{Name: "db-credentials", Key: "db.properties"},
+		{Name: "kafka-credentials", Mode: operatorapi.SecretPropertiesModeEnv, Optional: true},
+	}
+	// make sure that the workflow won't trigger a change
+	workflow.Status.Applied = workflow.Spec
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, t.Name())
+	dbSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: workflow.Namespace},
+		Data:       map[string][]byte{"db.properties": []byte("quarkus.datasource.password=secret")},
+	}
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform, dbSecret).Build()
+	handler := &deployWorkflowReconciliationState{
+		stateSupport: fakeReconcilerSupport(client),
+		ensurers:     newProdObjectEnsurers(&stateSupport{logger: &logger, client: client}),
+	}
+	_, _, err := handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+
+	secretProps := &corev1.Secret{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKey{Namespace: workflow.Namespace, Name: GetWorkflowSecretPropertiesName(workflow)}, secretProps))
+	assert.Contains(t, secretProps.Data, applicationPropertiesFileName)
+
+	deployment := &v1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), deployment))
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 3)
+	assert.Equal(t, "db-credentials", deployment.Spec.Template.Spec.Volumes[2].Secret.SecretName)
+	assert.Equal(t, "db.properties", deployment.Spec.Template.Spec.Volumes[2].Secret.Items[0].Key)
+	assert.Len(t, container.VolumeMounts, 3)
+	assert.Len(t, container.EnvFrom, 1)
+	assert.Equal(t, "kafka-credentials", container.EnvFrom[0].SecretRef.Name)
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name:  quarkusConfigLocationsEnv,
+		Value: secretPropsMountPath + "/" + secretProps.Name + "/application.properties," + secretPropsMountPath + "/db-credentials/application.properties",
+	})
+	checksum := deployment.Spec.Template.Annotations[metadata.SecretPropertiesChecksumAnnotation]
+	assert.NotEmpty(t, checksum)
+
+	// nothing changed, the deployment is kept
+	_, _, err = handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), deployment))
+	assert.Equal(t, checksum, deployment.Spec.Template.Annotations[metadata.SecretPropertiesChecksumAnnotation])
+
+	// rotating the credentials rolls out the deployment
+	dbSecret.Data["db.properties"] = []byte("quarkus.datasource.password=rotated")
+	assert.NoError(t, client.Update(context.TODO(), dbSecret))
+	_, _, err = handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), deployment))
+	assert.NotEqual(t, checksum, deployment.Spec.Template.Annotations[metadata.SecretPropertiesChecksumAnnotation])
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 3)
+	assert.Len(t, deployment.Spec.Template.Spec.Containers[0].EnvFrom, 1)
+}
+
+func Test_deployWorkflowReconciliationHandler_persistence(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.Persistence = &operatorapi.PersistenceSpec{
+		PostgreSQL: &operatorapi.PostgreSQLPersistenceSpec{
+			SecretRef:  operatorapi.PostgreSQLSecretReference{Name: "postgresql", UserKey: "user"},
+			ServiceRef: &operatorapi.PostgreSQLServiceReference{Name: "postgresql"},
+		},
+		Migration: operatorapi.PersistenceMigrationInitContainer,
+	}
+	// make sure that the workflow won't trigger a change
+	workflow.Status.Applied = workflow.Spec
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform).Build()
+	handler := &deployWorkflowReconciliationState{
+		stateSupport: fakeReconcilerSupport(client),
+		ensurers:     newProdObjectEnsurers(&stateSupport{logger: &logger, client: client}),
+	}
+	_, _, err := handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+
+	propsCM := &corev1.ConfigMap{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKey{Namespace: workflow.Namespace, Name: getWorkflowPropertiesConfigMapName(workflow)}, propsCM))
+	props, err := utils.LoadProperties(propsCM.Data[applicationPropertiesFileName])
+	assert.NoError(t, err)
+	assert.Equal(t, "jdbc", props.GetString("kogito.persistence.type", ""))
+	assert.Equal(t, "jdbc:postgresql://postgresql."+workflow.Namespace+":5432/kogito?currentSchema="+workflow.Name, props.GetString("quarkus.datasource.jdbc.url", ""))
+	assert.Equal(t, "false", props.GetString("quarkus.flyway.migrate-at-start", ""))
+
+	deployment := &v1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), deployment))
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+		Name: quarkusDatasourceUsernameEnv,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "postgresql"}, Key: "user"},
+		},
+	})
+	assert.Len(t, deployment.Spec.Template.Spec.InitContainers, 1)
+	migration := deployment.Spec.Template.Spec.InitContainers[0]
+	assert.Equal(t, persistenceMigrationContainerName, migration.Name)
+	assert.Equal(t, deployment.Spec.Template.Spec.Containers[0].Image, migration.Image)
+	assert.Nil(t, migration.ReadinessProbe)
+	assert.Contains(t, migration.Env, corev1.EnvVar{Name: quarkusInitAndExitEnv, Value: "true"})
+	assert.Contains(t, migration.Env, corev1.EnvVar{Name: quarkusFlywayMigrateAtStartEnv, Value: "true"})
+	assert.NotEmpty(t, deployment.Spec.Template.Annotations[metadata.PersistenceChecksumAnnotation])
+}
+
+func Test_deployWorkflowReconciliationHandler_platformServices(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	// make sure that the workflow won't trigger a change
+	workflow.Status.Applied = workflow.Spec
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, t.Name())
+	platform.Spec.Services = &operatorapi.PlatformServicesSpec{
+		DataIndex:  &operatorapi.PlatformServiceSpec{},
+		JobService: &operatorapi.PlatformServiceSpec{},
+	}
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform).Build()
+	handler := &deployWorkflowReconciliationState{
+		stateSupport: fakeReconcilerSupport(client),
+		ensurers:     newProdObjectEnsurers(&stateSupport{logger: &logger, client: client}),
+	}
+	_, _, err := handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+
+	propsCM := &corev1.ConfigMap{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKey{Namespace: workflow.Namespace, Name: getWorkflowPropertiesConfigMapName(workflow)}, propsCM))
+	props, err := utils.LoadProperties(propsCM.Data[applicationPropertiesFileName])
+	assert.NoError(t, err)
+	dataIndexURL := "http://" + platform.Name + "-data-index-service." + platform.Namespace
+	jobServiceURL := "http://" + platform.Name + "-jobs-service." + platform.Namespace
+	assert.Equal(t, dataIndexURL, props.GetString("kogito.data-index.url", ""))
+	assert.Equal(t, dataIndexURL+"/processes", props.GetString("mp.messaging.outgoing.kogito-processinstances-events.url", ""))
+	assert.Equal(t, jobServiceURL, props.GetString("kogito.jobs-service.url", ""))
+	assert.Equal(t, jobServiceURL+"/v2/jobs/events", props.GetString("mp.messaging.outgoing.kogito-job-service-job-request-events.url", ""))
+}
+
 func Test_GenerationAnnotationCheck(t *testing.T) {
 	logger := ctrllog.FromContext(context.TODO())
 	// we load a workflow with metadata.generation to 0
@@ -145,7 +447,7 @@ func Test_GenerationAnnotationCheck(t *testing.T) {
 	assert.Greater(t, result.RequeueAfter, int64(0))
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Len(t, objects, 3)
+	assert.Len(t, objects, 4)
 	// then we load a workflow with metadata.generation set to 1
 	workflowChanged := test.GetKogitoServerlessWorkflow("../../test/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
 	client = test.NewKogitoClientBuilder().WithRuntimeObjects(workflowChanged, platform).Build()
@@ -159,3 +461,197 @@ func Test_GenerationAnnotationCheck(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Len(t, objects, 0)
 }
+
+func Test_deployWorkflowReconciliationHandler_extensionsChanged(t *testing.T) {
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	// make sure that the workflow won't trigger a change
+	workflow.Status.Applied = workflow.Spec
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformWithCacheYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform).Build()
+	handler := &deployWorkflowReconciliationState{
+		stateSupport: fakeReconcilerSupport(client),
+		ensurers:     newProdObjectEnsurers(&stateSupport{logger: &logger, client: client}),
+	}
+	_, objects, err := handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 4)
+
+	// the platform persistence requires the JDBC extensions, so the workflow is built again
+	platform.Spec.Persistence = &operatorapi.PersistenceSpec{
+		PostgreSQL: &operatorapi.PostgreSQLPersistenceSpec{
+			SecretRef:  operatorapi.PostgreSQLSecretReference{Name: "postgresql"},
+			ServiceRef: &operatorapi.PostgreSQLServiceReference{Name: "postgresql"},
+		},
+	}
+	assert.NoError(t, client.Update(context.TODO(), platform))
+	_, objects, err = handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 0)
+	assert.Equal(t, api.BuildIsRunningReason, workflow.Status.GetCondition(api.BuiltConditionType).Reason)
+	build := &operatorapi.KogitoServerlessBuild{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), build))
+	assert.Contains(t, build.Annotations[metadata.QuarkusExtensionsAnnotation], "io.quarkus:quarkus-jdbc-postgresql")
+	assert.Equal(t, operatorapi.BuildPhaseNone, build.Status.BuildPhase)
+
+	// so do the platform services, enabled once the workflow is deployed
+	build.Status.BuildPhase = operatorapi.BuildPhaseSucceeded
+	assert.NoError(t, client.Status().Update(context.TODO(), build))
+	platform.Spec.Services = &operatorapi.PlatformServicesSpec{DataIndex: &operatorapi.PlatformServiceSpec{}}
+	assert.NoError(t, client.Update(context.TODO(), platform))
+	_, objects, err = handler.Do(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 0)
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), build))
+	assert.Contains(t, build.Annotations[metadata.QuarkusExtensionsAnnotation], "org.kie.kogito:kogito-addons-quarkus-events-process")
+	assert.Equal(t, operatorapi.BuildPhaseNone, build.Status.BuildPhase)
+}
+
+func Test_reconcilerProdPromotion(t *testing.T) {
+	dev := &cbtest.RegistryStandIn{}
+	devServer := dev.Start(true)
+	defer devServer.Close()
+	prod := &cbtest.RegistryStandIn{Username: "prod", Password: "secret"}
+	prodServer := prod.Start(true)
+	defer prodServer.Close()
+	manifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"config":{"digest":%q},"layers":[]}`, dev.PutBlob("dev/greeting", []byte("{}"))))
+	digest := dev.PutManifest("dev/greeting", "", registry.MediaTypeDockerManifest, manifest)
+
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	source := strings.TrimPrefix(devServer.URL, "http://") + "/dev/greeting@" + digest
+	workflow.Spec.Promotion = &operatorapi.PromotionSpec{Image: source, Insecure: true}
+	workflow.Status.Applied = workflow.Spec
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, t.Name())
+	platform.Spec.BuildPlatform.Registry = operatorapi.RegistrySpec{Address: strings.TrimPrefix(prodServer.URL, "http://") + "/prod", Secret: "prod-registry", Insecure: true}
+	secret := newDockerConfigSecret("prod-registry", t.Name(), strings.TrimPrefix(prodServer.URL, "http://"), "prod", "secret")
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform, secret).Build()
+
+	// the image is copied in the background instead of being built
+	result, err := NewReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, requeueWhileWaitForPromotion, result.RequeueAfter)
+	assert.Equal(t, api.ImagePromotingReason, workflow.Status.GetCondition(api.BuiltConditionType).Reason)
+	reconcileWhilePromoting(t, client, workflow)
+	image := platform.Spec.BuildPlatform.Registry.Address + "/" + workflow.Name + "@" + digest
+	assert.Equal(t, source, workflow.Status.Promotion.Source)
+	assert.Equal(t, image, workflow.Status.Promotion.Image)
+	assert.True(t, workflow.Status.GetCondition(api.BuiltConditionType).IsTrue())
+	assert.Equal(t, api.ImagePromotedReason, workflow.Status.GetCondition(api.BuiltConditionType).Reason)
+	_, content, found := prod.Manifest("prod/"+workflow.Name, workflow.Annotations[metadata.Version])
+	assert.True(t, found)
+	assert.Equal(t, manifest, content)
+	assert.True(t, errors.IsNotFound(client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), &operatorapi.KogitoServerlessBuild{})))
+
+	// the promoted image is deployed
+	_, err = NewReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, api.WaitingForDeploymentReason, workflow.Status.GetTopLevelCondition().Reason)
+	deployment := &v1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), deployment))
+	assert.Equal(t, image, deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "prod-registry"}}, deployment.Spec.Template.Spec.ImagePullSecrets)
+
+	// promoting another image rolls out the Deployment
+	manifest = []byte(fmt.Sprintf(`{"schemaVersion":2,"config":{"digest":%q},"layers":[]}`, dev.PutBlob("dev/greeting", []byte(`{"os":"linux"}`))))
+	digest = dev.PutManifest("dev/greeting", "", registry.MediaTypeDockerManifest, manifest)
+	source = strings.TrimPrefix(devServer.URL, "http://") + "/dev/greeting@" + digest
+	workflow.Spec.Promotion.Image = source
+	reconcileWhilePromoting(t, client, workflow)
+	_, err = NewReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	image = platform.Spec.BuildPlatform.Registry.Address + "/" + workflow.Name + "@" + digest
+	assert.Equal(t, image, workflow.Status.Promotion.Image)
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), deployment))
+	assert.Equal(t, image, deployment.Spec.Template.Spec.Containers[0].Image)
+
+	// the promotion fails when the image can't be copied
+	workflow.Spec.Promotion.Image = strings.TrimPrefix(devServer.URL, "http://") + "/dev/greeting@sha256:" + strings.Repeat("0", 64)
+	reconcileWhilePromoting(t, client, workflow)
+	assert.Equal(t, api.ImagePromotionFailedReason, workflow.Status.GetCondition(api.BuiltConditionType).Reason)
+	assert.Equal(t, source, workflow.Status.Promotion.Source)
+}
+
+// newDockerConfigSecret creates a docker config Secret with the credentials of the given registry
+func newDockerConfigSecret(name, namespace, host, username, password string) *corev1.Secret {
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"` + host + `":{"auth":"` + auth + `"}}}`)},
+	}
+}
+
+// reconcileWhilePromoting reconciles the workflow until the copy of its promoted image is done
+func reconcileWhilePromoting(t *testing.T, client clientruntime.Client, workflow *operatorapi.KogitoServerlessWorkflow) {
+	logger := ctrllog.FromContext(context.TODO())
+	assert.Eventually(t, func() bool {
+		_, err := NewReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+		assert.NoError(t, err)
+		return workflow.Status.GetCondition(api.BuiltConditionType).Reason != api.ImagePromotingReason
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func Test_reconcilerProdPrebuiltImage(t *testing.T) {
+	ci := &cbtest.RegistryStandIn{Username: "ci", Password: "secret"}
+	server := ci.Start(true)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	digest := ci.PutManifest("ci/greeting", "1.0", registry.MediaTypeImageManifest, []byte(`{"schemaVersion":2}`))
+
+	logger := ctrllog.FromContext(context.TODO())
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	workflow.Spec.Image = &operatorapi.PrebuiltImageSpec{Name: host + "/ci/greeting:1.0", RegistrySecretRef: &corev1.LocalObjectReference{Name: "ci-registry"}, Insecure: true}
+	workflow.Status.Applied = workflow.Spec
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, t.Name())
+	secret := newDockerConfigSecret("ci-registry", t.Name(), host, "ci", "secret")
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, platform, secret).Build()
+
+	// the image is checked instead of being built
+	_, err := NewReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	image := host + "/ci/greeting@" + digest
+	assert.Equal(t, host+"/ci/greeting:1.0", workflow.Status.Prebuilt.Name)
+	assert.Equal(t, image, workflow.Status.Prebuilt.Image)
+	assert.True(t, workflow.Status.GetCondition(api.BuiltConditionType).IsTrue())
+	assert.Equal(t, api.PrebuiltImageValidatedReason, workflow.Status.GetCondition(api.BuiltConditionType).Reason)
+	assert.True(t, errors.IsNotFound(client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), &operatorapi.KogitoServerlessBuild{})))
+
+	// the image is deployed pinned by digest, with the usual objects
+	_, err = NewReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.Equal(t, api.WaitingForDeploymentReason, workflow.Status.GetTopLevelCondition().Reason)
+	deployment := &v1.Deployment{}
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), deployment))
+	assert.Equal(t, image, deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "ci-registry"}}, deployment.Spec.Template.Spec.ImagePullSecrets)
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), &corev1.Service{}))
+
+	// pointing the workflow to another tag rolls out the Deployment
+	digest = ci.PutManifest("ci/greeting", "1.1", registry.MediaTypeImageManifest, []byte(`{"schemaVersion":2,"layers":[]}`))
+	workflow.Spec.Image.Name = host + "/ci/greeting:1.1"
+	for i := 0; i < 2; i++ {
+		_, err = NewReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+		assert.NoError(t, err)
+	}
+	image = host + "/ci/greeting@" + digest
+	assert.Equal(t, image, workflow.Status.Prebuilt.Image)
+	assert.NoError(t, client.Get(context.TODO(), clientruntime.ObjectKeyFromObject(workflow), deployment))
+	assert.Equal(t, image, deployment.Spec.Template.Spec.Containers[0].Image)
+
+	// the image must exist in the registry
+	workflow.Spec.Image.Name = host + "/ci/greeting:2.0"
+	_, err = NewReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.False(t, workflow.Status.GetCondition(api.BuiltConditionType).IsTrue())
+	assert.Equal(t, api.PrebuiltImageInvalidReason, workflow.Status.GetCondition(api.BuiltConditionType).Reason)
+	assert.Equal(t, image, workflow.Status.Prebuilt.Image)
+
+	// the image can't be both prebuilt and promoted
+	workflow.Spec.Image.Name = host + "/ci/greeting:1.1"
+	workflow.Spec.Promotion = &operatorapi.PromotionSpec{Image: host + "/ci/greeting@" + digest}
+	_, err = NewReconciler(client, &rest.Config{}, &record.FakeRecorder{}, &logger, workflow).Reconcile(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.False(t, workflow.Status.GetCondition(api.BuiltConditionType).IsTrue())
+	assert.Equal(t, api.ImageSourceConflictReason, workflow.Status.GetCondition(api.BuiltConditionType).Reason)
+}
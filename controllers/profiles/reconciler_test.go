@@ -16,9 +16,15 @@ package profiles
 
 import (
 	"context"
+	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kiegroup/kogito-serverless-operator/test"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 )
 
 func fakeReconcilerSupport(client client.Client) *stateSupport {
@@ -28,3 +34,25 @@ func fakeReconcilerSupport(client client.Client) *stateSupport {
 		client: client,
 	}
 }
+
+func Test_performStatusUpdateIfChanged(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	cli := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow).Build()
+	support := fakeReconcilerSupport(cli)
+	workflow.Status.DevMode = &operatorapi.DevModeStatus{Health: "UP"}
+	updated, err := support.performStatusUpdate(context.TODO(), workflow)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+
+	previousStatus := workflow.Status.DeepCopy()
+	workflow.Status.DevMode = &operatorapi.DevModeStatus{Health: "UP"}
+	updated, err = support.performStatusUpdateIfChanged(context.TODO(), previousStatus, workflow)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+
+	workflow.Status.DevMode = nil
+	updated, err = support.performStatusUpdateIfChanged(context.TODO(), previousStatus, workflow)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.Nil(t, test.MustGetWorkflow(t, cli, client.ObjectKeyFromObject(workflow)).Status.DevMode)
+}
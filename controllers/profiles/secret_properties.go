@@ -0,0 +1,181 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	kubeutil "github.com/kiegroup/kogito-serverless-operator/utils/kubernetes"
+)
+
+const (
+	workflowSecretNameSuffix      = "-secret-props"
+	secretWorkflowPropsVolumeName = "workflow-secret-properties"
+	secretPropsVolumeNamePrefix   = "secret-props-"
+	// secretPropsMountPath is where the Secrets in the file mode are mounted, one directory per Secret
+	secretPropsMountPath = "/etc/workflow/secrets"
+	// quarkusConfigLocationsEnv holds the additional properties files read by the workflow application.
+	// See: https://quarkus.io/guides/config-reference#quarkus-config-locations
+	quarkusConfigLocationsEnv = "QUARKUS_CONFIG_LOCATIONS"
+)
+
+// workflowSecretPropsCreator creates the companion Secret to hold the sensitive application properties of the workflow
+func workflowSecretPropsCreator(workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetWorkflowSecretPropertiesName(workflow),
+			Namespace: workflow.Namespace,
+			Labels:    workflowdef.GetDefaultLabels(workflow),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{applicationPropertiesFileName: {}},
+	}, nil
+}
+
+// ensureWorkflowSecretPropsMutator guarantees the companion Secret labels. The data belongs to the user.
+func ensureWorkflowSecretPropsMutator(workflow *operatorapi.KogitoServerlessWorkflow) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			if kubeutil.IsObjectNew(object) {
+				return nil
+			}
+			original, err := workflowSecretPropsCreator(workflow)
+			if err != nil {
+				return err
+			}
+			object.SetLabels(original.GetLabels())
+			return nil
+		}
+	}
+}
+
+// GetWorkflowSecretPropertiesName gets the name of the companion Secret holding the sensitive application properties of the workflow
+func GetWorkflowSecretPropertiesName(workflow *operatorapi.KogitoServerlessWorkflow) string {
+	return workflow.Name + workflowSecretNameSuffix
+}
+
+// getWorkflowSecrets gets every Secret made available to the workflow application, the companion Secret first
+func getWorkflowSecrets(workflow *operatorapi.KogitoServerlessWorkflow) []operatorapi.SecretPropertiesSpec {
+	secrets := []operatorapi.SecretPropertiesSpec{{Name: GetWorkflowSecretPropertiesName(workflow), Mode: operatorapi.SecretPropertiesModeFile, Optional: true}}
+	return append(secrets, workflow.Spec.Secrets...)
+}
+
+// IsSecretReferencedByWorkflow verifies whether the given Secret name is made available to the workflow application
+func IsSecretReferencedByWorkflow(workflow *operatorapi.KogitoServerlessWorkflow, secretName string) bool {
+	for _, secret := range getWorkflowSecrets(workflow) {
+		if secret.Name == secretName {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchSecretPropertiesChecksum calculates the checksum of the Secrets made available to the workflow application.
+// Missing Secrets are part of the checksum too, so the workflow application is rolled out once they're created.
+func fetchSecretPropertiesChecksum(ctx context.Context, c client.Client, workflow *operatorapi.KogitoServerlessWorkflow) (string, error) {
+	hash := sha256.New()
+	for _, ref := range getWorkflowSecrets(workflow) {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: workflow.Namespace, Name: ref.Name}, secret); err != nil {
+			if !errors.IsNotFound(err) {
+				return "", err
+			}
+			fmt.Fprintf(hash, "%s:missing;", ref.Name)
+			continue
+		}
+		fmt.Fprintf(hash, "%s;", ref.Name)
+		keys := make([]string, 0, len(secret.Data))
+		for k := range secret.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(hash, "%s=%x;", k, secret.Data[k])
+		}
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// secretPropertiesMutateVisitor makes the workflow Secrets available to the workflow application.
+// Secrets in the file mode are mounted and added to `quarkus.config.locations`, Secrets in the env mode are injected as environment variables.
+// Must be called after the visitors mounting the workflow ConfigMaps and injecting the platform configuration.
+func secretPropertiesMutateVisitor(workflow *operatorapi.KogitoServerlessWorkflow, checksum string) mutateVisitor {
+	return func(object client.Object) controllerutil.MutateFn {
+		return func() error {
+			deployment := object.(*appsv1.Deployment)
+			container := &deployment.Spec.Template.Spec.Containers[0]
+			envFrom := make([]corev1.EnvFromSource, 0, len(container.EnvFrom))
+			envFrom = append(envFrom, container.EnvFrom...)
+			var locations []string
+
+			for i, ref := range getWorkflowSecrets(workflow) {
+				optional := ref.Optional
+				if ref.Mode == operatorapi.SecretPropertiesModeEnv {
+					envFrom = append(envFrom, corev1.EnvFromSource{
+						SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name}, Optional: &optional},
+					})
+					continue
+				}
+				key := ref.Key
+				if len(key) == 0 {
+					key = applicationPropertiesFileName
+				}
+				volumeName := secretWorkflowPropsVolumeName
+				if i > 0 {
+					volumeName = fmt.Sprintf("%s%d", secretPropsVolumeNamePrefix, i)
+				}
+				mountPath := path.Join(secretPropsMountPath, ref.Name)
+				deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+					Name: volumeName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: ref.Name,
+							Items:      []corev1.KeyToPath{{Key: key, Path: applicationPropertiesFileName}},
+							Optional:   &optional,
+						},
+					},
+				})
+				container.VolumeMounts = append(container.VolumeMounts, kubeutil.VolumeMount(volumeName, true, mountPath))
+				locations = append(locations, path.Join(mountPath, applicationPropertiesFileName))
+			}
+
+			container.EnvFrom = envFrom
+			// Quarkus ignores the missing files in the config locations, hence optional Secrets are fine
+			kubeutil.CreateOrReplaceEnv(container, quarkusConfigLocationsEnv, strings.Join(locations, ","))
+
+			if deployment.Spec.Template.Annotations == nil {
+				deployment.Spec.Template.Annotations = make(map[string]string, 1)
+			}
+			deployment.Spec.Template.Annotations[metadata.SecretPropertiesChecksumAnnotation] = checksum
+			return nil
+		}
+	}
+}
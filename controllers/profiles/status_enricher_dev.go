@@ -71,7 +71,7 @@ func defaultDevStatusEnricher(ctx context.Context, c client.Client, workflow *op
 	return workflow, nil
 }
 
-func devStatusEnricherForOpenShift(ctx context.Context, client client.Client, workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
+func routeStatusEnricher(ctx context.Context, client client.Client, workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
 	// On OpenShift we need to retrieve the Route to have the URL the service is available to
 	route := &openshiftv1.Route{}
 	err := client.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, route)
@@ -84,6 +84,7 @@ func devStatusEnricherForOpenShift(ctx context.Context, client client.Client, wo
 	} else {
 		url = apis.HTTP(route.Spec.Host)
 	}
+	url.Path = route.Spec.Path
 
 	workflow.Status.Endpoint = url
 
@@ -0,0 +1,221 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+const (
+	// quarkusHealthPath see: https://quarkus.io/guides/smallrye-health
+	quarkusHealthPath = "/q/health"
+	// kogitoProcessesManagementPath lists the process definitions deployed in a Kogito application
+	kogitoProcessesManagementPath = "/management/processes"
+	// devModeProbeTimeout is the deadline shared by every request probing the workflow application, so a slow pod
+	// doesn't hold the reconciliation
+	devModeProbeTimeout = 2 * time.Second
+	// devModeBuildErrorMaxLength caps the build error copied to the status to avoid huge stack traces in the CR
+	devModeBuildErrorMaxLength = 512
+	// devModeProbeMaxBodySize caps the response body read from the workflow application, an error page is way smaller
+	devModeProbeMaxBodySize = 1 << 20
+)
+
+var (
+	devModeProbeClient = &http.Client{}
+	// devModeProbeBaseURL resolves the base URL to reach the given workflow dev pod.
+	devModeProbeBaseURL = func(pod *v1.Pod) string {
+		return fmt.Sprintf("http://%s:%d", pod.Status.PodIP, defaultHTTPWorkflowPortInt)
+	}
+	devModeErrorHeaderRegex  = regexp.MustCompile(`(?s)<h1[^>]*>(.*?)</h1>`)
+	devModeErrorMessageRegex = regexp.MustCompile(`(?s)<h2[^>]*>(.*?)</h2>`)
+)
+
+// quarkusHealthResponse is the subset of the SmallRye Health response we are interested in
+type quarkusHealthResponse struct {
+	Status string `json:"status"`
+}
+
+// kogitoProcessDefinition is the subset of the Kogito process management response we are interested in
+type kogitoProcessDefinition struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// devModeStatusEnricher probes the workflow application running in dev mode to fill the operatorapi.DevModeStatus.
+// Quarkus replies to every request with an error page when the application fails to restart, for example, when the workflow
+// code generation fails. In that case, the error is reported in the status as the build error.
+// The operatorapi.DevModeStatus is cleared when there's no running pod or the application can't be probed, so stale
+// information is never reported.
+func devModeStatusEnricher(ctx context.Context, c client.Client, workflow *operatorapi.KogitoServerlessWorkflow) (client.Object, error) {
+	pod, err := getRunningWorkflowPod(ctx, c, workflow)
+	if err != nil {
+		return nil, err
+	}
+	if pod == nil {
+		workflow.Status.DevMode = nil
+		return workflow, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, devModeProbeTimeout)
+	defer cancel()
+	baseURL := devModeProbeBaseURL(pod)
+	devMode := &operatorapi.DevModeStatus{}
+
+	health, buildErr, err := probeDevModeHealth(ctx, baseURL)
+	if err != nil {
+		workflow.Status.DevMode = nil
+		return nil, err
+	}
+	devMode.Health = health
+	devMode.BuildError = buildErr
+
+	if len(buildErr) == 0 {
+		if devMode.ProcessIDs, devMode.WorkflowVersion, err = probeDevModeProcesses(ctx, baseURL, workflow); err != nil {
+			workflow.Status.DevMode = nil
+			return nil, err
+		}
+	}
+	workflow.Status.DevMode = devMode
+
+	return workflow, nil
+}
+
+// getRunningWorkflowPod gets the first running pod with an IP address for the given workflow or nil if there's none.
+func getRunningWorkflowPod(ctx context.Context, c client.Client, workflow *operatorapi.KogitoServerlessWorkflow) (*v1.Pod, error) {
+	labels := workflowdef.GetDefaultLabels(workflow)
+	podList := &v1.PodList{}
+	opts := []client.ListOption{
+		client.InNamespace(workflow.Namespace),
+		client.MatchingLabels{workflowdef.LabelApp: labels[workflowdef.LabelApp]},
+	}
+	if err := c.List(ctx, podList, opts...); err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		if podList.Items[i].Status.Phase == v1.PodRunning && len(podList.Items[i].Status.PodIP) > 0 {
+			return &podList.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// probeDevModeHealth returns the health status and the build error, if any, reported by the Quarkus application.
+func probeDevModeHealth(ctx context.Context, baseURL string) (string, string, error) {
+	status, body, err := devModeGet(ctx, baseURL+quarkusHealthPath)
+	if err != nil {
+		return "", "", err
+	}
+	health := &quarkusHealthResponse{}
+	if err = json.Unmarshal(body, health); err == nil && len(health.Status) > 0 {
+		// SmallRye Health replies 503 when any check is DOWN, it's still a valid health response
+		return health.Status, "", nil
+	}
+	if status >= http.StatusBadRequest {
+		return "", extractDevModeBuildError(status, body), nil
+	}
+	return "", "", fmt.Errorf("unexpected response from %s: %s", baseURL+quarkusHealthPath, http.StatusText(status))
+}
+
+// probeDevModeProcesses returns the deployed process IDs and the version of the process matching the given workflow.
+func probeDevModeProcesses(ctx context.Context, baseURL string, workflow *operatorapi.KogitoServerlessWorkflow) ([]string, string, error) {
+	status, body, err := devModeGet(ctx, baseURL+kogitoProcessesManagementPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if status != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected response from %s: %s", baseURL+kogitoProcessesManagementPath, http.StatusText(status))
+	}
+	var processIDs []string
+	if err = json.Unmarshal(body, &processIDs); err != nil {
+		return nil, "", err
+	}
+
+	version := ""
+	for _, id := range processIDs {
+		if id != workflow.Spec.Flow.ID {
+			continue
+		}
+		status, body, err = devModeGet(ctx, baseURL+kogitoProcessesManagementPath+"/"+id)
+		if err != nil {
+			return nil, "", err
+		}
+		if status == http.StatusOK {
+			definition := &kogitoProcessDefinition{}
+			if err = json.Unmarshal(body, definition); err != nil {
+				return nil, "", err
+			}
+			version = definition.Version
+		}
+		break
+	}
+	return processIDs, version, nil
+}
+
+func devModeGet(ctx context.Context, url string) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := devModeProbeClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, devModeProbeMaxBodySize))
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// extractDevModeBuildError reads the error out of the Quarkus dev mode error page.
+func extractDevModeBuildError(status int, body []byte) string {
+	var parts []string
+	if match := devModeErrorHeaderRegex.FindSubmatch(body); match != nil {
+		parts = append(parts, strings.TrimSpace(html.UnescapeString(string(match[1]))))
+	}
+	if match := devModeErrorMessageRegex.FindSubmatch(body); match != nil {
+		parts = append(parts, strings.TrimSpace(html.UnescapeString(string(match[1]))))
+	}
+	buildErr := strings.Join(parts, ": ")
+	if len(buildErr) == 0 {
+		buildErr = fmt.Sprintf("workflow application replied with status %d: %s", status, http.StatusText(status))
+	}
+	if len(buildErr) > devModeBuildErrorMaxLength {
+		// cut on a rune boundary, the status must be valid UTF-8
+		end := devModeBuildErrorMaxLength
+		for end > 0 && !utf8.RuneStart(buildErr[end]) {
+			end--
+		}
+		buildErr = buildErr[:end]
+	}
+	return buildErr
+}
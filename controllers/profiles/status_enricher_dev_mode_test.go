@@ -0,0 +1,167 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiegroup/kogito-serverless-operator/controllers/workflowdef"
+	"github.com/kiegroup/kogito-serverless-operator/test"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+func newRunningWorkflowPod(workflow *operatorapi.KogitoServerlessWorkflow) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflow.Name + "-pod",
+			Namespace: workflow.Namespace,
+			Labels:    workflowdef.GetDefaultLabels(workflow),
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning, PodIP: "10.0.0.1"},
+	}
+}
+
+func withDevModeServer(t *testing.T, handler http.HandlerFunc) {
+	server := httptest.NewServer(handler)
+	original := devModeProbeBaseURL
+	devModeProbeBaseURL = func(pod *v1.Pod) string {
+		return server.URL
+	}
+	t.Cleanup(func() {
+		devModeProbeBaseURL = original
+		server.Close()
+	})
+}
+
+func Test_devModeStatusEnricher(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, newRunningWorkflowPod(workflow)).Build()
+
+	withDevModeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case quarkusHealthPath:
+			_, _ = w.Write([]byte(`{"status":"UP","checks":[]}`))
+		case kogitoProcessesManagementPath:
+			_, _ = w.Write([]byte(`["` + workflow.Spec.Flow.ID + `","subflow"]`))
+		case kogitoProcessesManagementPath + "/" + workflow.Spec.Flow.ID:
+			_, _ = w.Write([]byte(`{"id":"` + workflow.Spec.Flow.ID + `","version":"1.0"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	_, err := devModeStatusEnricher(context.TODO(), client, workflow)
+	assert.NoError(t, err)
+	assert.NotNil(t, workflow.Status.DevMode)
+	assert.Equal(t, "UP", workflow.Status.DevMode.Health)
+	assert.Equal(t, []string{workflow.Spec.Flow.ID, "subflow"}, workflow.Status.DevMode.ProcessIDs)
+	assert.Equal(t, "1.0", workflow.Status.DevMode.WorkflowVersion)
+	assert.Empty(t, workflow.Status.DevMode.BuildError)
+}
+
+func Test_devModeStatusEnricherWithBuildError(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, newRunningWorkflowPod(workflow)).Build()
+
+	withDevModeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`<html><body><header><h1 class="container">Error restarting Quarkus</h1>` +
+			`<div class="exception-message"><h2 class="container">Build failure: invalid state &quot;start&quot;</h2></div></header></body></html>`))
+	})
+
+	_, err := devModeStatusEnricher(context.TODO(), client, workflow)
+	assert.NoError(t, err)
+	assert.NotNil(t, workflow.Status.DevMode)
+	assert.Empty(t, workflow.Status.DevMode.ProcessIDs)
+	assert.Equal(t, `Error restarting Quarkus: Build failure: invalid state "start"`, workflow.Status.DevMode.BuildError)
+}
+
+func Test_devModeStatusEnricherNoRunningPod(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow).Build()
+	workflow.Status.DevMode = &operatorapi.DevModeStatus{Health: "UP"}
+
+	_, err := devModeStatusEnricher(context.TODO(), client, workflow)
+	assert.NoError(t, err)
+	assert.Nil(t, workflow.Status.DevMode)
+}
+
+func Test_devModeStatusEnricherClearsStatusOnProbeFailure(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, newRunningWorkflowPod(workflow)).Build()
+	workflow.Status.DevMode = &operatorapi.DevModeStatus{Health: "UP", ProcessIDs: []string{workflow.Spec.Flow.ID}}
+
+	withDevModeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`not a health response`))
+	})
+
+	_, err := devModeStatusEnricher(context.TODO(), client, workflow)
+	assert.Error(t, err)
+	assert.Nil(t, workflow.Status.DevMode)
+}
+
+func Test_devModeStatusEnricherBoundsResponseBody(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, newRunningWorkflowPod(workflow)).Build()
+
+	// the error header lies beyond the read limit, so it must never be parsed
+	withDevModeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(strings.Repeat(" ", devModeProbeMaxBodySize) + "<h1>Error restarting Quarkus</h1>"))
+	})
+
+	_, err := devModeStatusEnricher(context.TODO(), client, workflow)
+	assert.NoError(t, err)
+	assert.NotNil(t, workflow.Status.DevMode)
+	assert.Equal(t, "workflow application replied with status 500: Internal Server Error", workflow.Status.DevMode.BuildError)
+}
+
+func Test_devModeStatusEnricherHonorsDeadline(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleDevModeYamlCR, t.Name())
+	client := test.NewKogitoClientBuilder().WithRuntimeObjects(workflow, newRunningWorkflowPod(workflow)).Build()
+
+	// a stuck workflow application must not hold the reconciliation
+	withDevModeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := devModeStatusEnricher(ctx, client, workflow)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), devModeProbeTimeout)
+}
+
+func Test_extractDevModeBuildErrorTruncatesOnRuneBoundary(t *testing.T) {
+	// the two-byte "é" runes are shifted by one, so the cap falls in the middle of a rune
+	body := "<h1>a" + strings.Repeat("é", devModeBuildErrorMaxLength) + "</h1>"
+
+	buildErr := extractDevModeBuildError(http.StatusInternalServerError, []byte(body))
+	assert.True(t, utf8.ValidString(buildErr))
+	assert.LessOrEqual(t, len(buildErr), devModeBuildErrorMaxLength)
+	assert.Equal(t, "a"+strings.Repeat("é", devModeBuildErrorMaxLength/2-1), buildErr)
+}
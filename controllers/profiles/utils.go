@@ -15,6 +15,8 @@
 package profiles
 
 import (
+	"fmt"
+
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,6 +24,7 @@ import (
 
 	"github.com/kiegroup/kogito-serverless-operator/api/metadata"
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/platform"
 	kubeutil "github.com/kiegroup/kogito-serverless-operator/utils/kubernetes"
 )
 
@@ -63,3 +66,11 @@ func IsDevProfile(workflow *operatorapi.KogitoServerlessWorkflow) bool {
 	}
 	return Profile(profile) == Development
 }
+
+// platformUnavailableMessage explains why the platform of the workflow can't be used, either the selected one or the active one of its namespace
+func platformUnavailableMessage(workflow *operatorapi.KogitoServerlessWorkflow, err error) string {
+	if name := platform.SelectedPlatformName(workflow); len(name) > 0 {
+		return fmt.Sprintf("Platform %s selected by the workflow isn't available (%v)", name, err)
+	}
+	return fmt.Sprintf("No active Platform for namespace %s", workflow.Namespace)
+}
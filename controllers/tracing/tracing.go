@@ -0,0 +1,148 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing configures the OpenTelemetry tracing of the operator and provides helpers to trace the reconciliation cycles.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildertracing "github.com/kiegroup/kogito-serverless-operator/container-builder/util/tracing"
+)
+
+const (
+	// ExporterNone disables the tracing, the default
+	ExporterNone = "none"
+	// ExporterOTLP exports the spans to an OpenTelemetry collector using OTLP over gRPC
+	ExporterOTLP = "otlp"
+	// ExporterFile writes the spans as JSON to a file, mainly for testing and troubleshooting
+	ExporterFile = "file"
+
+	serviceName = "kogito-serverless-operator"
+	tracerName  = "github.com/kiegroup/kogito-serverless-operator"
+
+	objectNameKey = attribute.Key("k8s.object.name")
+)
+
+// Config the tracing configuration given to the operator.
+type Config struct {
+	// Exporter one of ExporterNone, ExporterOTLP or ExporterFile
+	Exporter string
+	// Endpoint the OTLP collector address, for example "otel-collector:4317". Defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable.
+	Endpoint string
+	// Insecure disables the TLS connection to the OTLP collector
+	Insecure bool
+	// File path of the file the spans are written to when using ExporterFile
+	File string
+}
+
+// Shutdown flushes the pending spans and releases the exporter resources.
+type Shutdown func(ctx context.Context) error
+
+// Setup configures the global TracerProvider and the W3C Trace Context propagator according to the given Config.
+func Setup(ctx context.Context, config Config) (Shutdown, error) {
+	exporter, closer, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return func(ctx context.Context) error {
+		if err := provider.Shutdown(ctx); err != nil {
+			return err
+		}
+		if closer != nil {
+			return closer.Close()
+		}
+		return nil
+	}, nil
+}
+
+func newExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, *os.File, error) {
+	switch config.Exporter {
+	case "", ExporterNone:
+		return nil, nil, nil
+	case ExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if len(config.Endpoint) > 0 {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+		return exporter, nil, err
+	case ExporterFile:
+		if len(config.File) == 0 {
+			return nil, nil, fmt.Errorf("the tracing file is required for the %s exporter", ExporterFile)
+		}
+		f, err := os.Create(config.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, nil, err
+		}
+		return exporter, f, nil
+	}
+	return nil, nil, fmt.Errorf("tracing exporter %q not supported, use one of %s, %s or %s", config.Exporter, ExporterNone, ExporterOTLP, ExporterFile)
+}
+
+// StartSpan starts a new span named after the operation, tagged with the given object reference.
+func StartSpan(ctx context.Context, name string, object client.Object) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(
+		semconv.K8SNamespaceName(object.GetNamespace()),
+		objectNameKey.String(object.GetName())))
+}
+
+// EndSpan records the given error, if any, in the span and ends it.
+func EndSpan(span trace.Span, err error) {
+	buildertracing.EndSpan(span, err)
+}
+
+// Inject writes the trace context carried by ctx into the object's annotations, so the objects created from it can join the same trace.
+func Inject(ctx context.Context, object client.Object) {
+	buildertracing.Inject(ctx, object)
+}
+
+// Extract returns a copy of ctx carrying the span context stored in the object's annotations, unless ctx already carries a span.
+func Extract(ctx context.Context, object client.Object) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	return buildertracing.Extract(ctx, object)
+}
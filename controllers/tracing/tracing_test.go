@@ -0,0 +1,80 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kiegroup/kogito-serverless-operator/test"
+)
+
+func TestSetup_FileExporter(t *testing.T) {
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	file := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Setup(context.TODO(), Config{Exporter: ExporterFile, File: file})
+	assert.NoError(t, err)
+
+	build := test.GetNewEmptyKogitoServerlessBuild("greeting", t.Name())
+	_, span := StartSpan(context.TODO(), "BuildManager.Schedule", build)
+	EndSpan(span, errors.New("no platform"))
+	assert.NoError(t, shutdown(context.TODO()))
+
+	traces, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Contains(t, string(traces), `"Name":"BuildManager.Schedule"`)
+	assert.Contains(t, string(traces), `"Value":"greeting"`)
+	assert.Contains(t, string(traces), `"Description":"no platform"`)
+}
+
+func TestSetup_Exporters(t *testing.T) {
+	shutdown, err := Setup(context.TODO(), Config{Exporter: ExporterNone})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.TODO()))
+
+	_, err = Setup(context.TODO(), Config{Exporter: ExporterFile})
+	assert.Error(t, err)
+	_, err = Setup(context.TODO(), Config{Exporter: "zipkin"})
+	assert.Error(t, err)
+}
+
+func TestInjectExtract(t *testing.T) {
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	build := test.GetNewEmptyKogitoServerlessBuild("greeting", t.Name())
+
+	// nothing to propagate
+	Inject(context.TODO(), build)
+	assert.Empty(t, build.Annotations)
+	assert.False(t, trace.SpanContextFromContext(Extract(context.TODO(), build)).IsValid())
+
+	Inject(trace.ContextWithSpanContext(context.TODO(), spanCtx), build)
+	assert.Equal(t, "00-01000000000000000000000000000000-0200000000000000-01", build.Annotations["sw.kogito.kie.org/trace.traceparent"])
+
+	extracted := trace.SpanContextFromContext(Extract(context.TODO(), build))
+	assert.Equal(t, spanCtx.TraceID(), extracted.TraceID())
+	assert.Equal(t, spanCtx.SpanID(), extracted.SpanID())
+	assert.True(t, extracted.IsRemote())
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflowdef
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+// Addon is a Kogito add-on known by the operator: the Quarkus extensions added to the build and the application
+// properties configuring them in the prod profile.
+type Addon struct {
+	// Extensions the Quarkus extensions of the add-on
+	Extensions []string
+	// Properties the application properties of the workflow deployment using the add-on
+	Properties map[string]string
+}
+
+// addonsCatalog the add-ons that can be added to the workflows, by name.
+// See: https://kiegroup.github.io/kogito-docs/serverlessworkflow/latest/index.html
+var addonsCatalog = map[string]Addon{
+	"kafka": {
+		Extensions: []string{
+			"org.kie.kogito:kogito-addons-quarkus-messaging",
+			"io.quarkus:quarkus-smallrye-reactive-messaging-kafka",
+		},
+		Properties: map[string]string{
+			"mp.messaging.incoming.kogito_incoming_stream.connector": "smallrye-kafka",
+			"mp.messaging.outgoing.kogito_outgoing_stream.connector": "smallrye-kafka",
+		},
+	},
+	"knative-eventing": {
+		Extensions: []string{
+			"org.kie.kogito:kogito-addons-quarkus-knative-eventing",
+		},
+		Properties: map[string]string{
+			"mp.messaging.outgoing.kogito_outgoing_stream.url": "${K_SINK:http://localhost:8080}",
+		},
+	},
+	"kubernetes": {
+		Extensions: []string{
+			"org.kie.kogito:kogito-addons-quarkus-kubernetes",
+			"org.kie.kogito:kogito-addons-quarkus-microprofile-config-service-catalog",
+		},
+		Properties: map[string]string{
+			"quarkus.kubernetes-client.devservices.enabled": "false",
+		},
+	},
+	"monitoring": {
+		Extensions: []string{
+			"org.kie.kogito:kogito-addons-quarkus-monitoring-prometheus",
+		},
+		Properties: map[string]string{
+			"quarkus.micrometer.export.prometheus.enabled": "true",
+		},
+	},
+	"process-management": {
+		Extensions: []string{
+			"org.kie.kogito:kogito-addons-quarkus-process-management",
+		},
+	},
+	"source-files": {
+		Extensions: []string{
+			"org.kie.kogito:kogito-addons-quarkus-source-files",
+		},
+	},
+}
+
+// extensionCoordinatesRegexp matches the `groupId:artifactId[:version]` coordinates of a Quarkus extension
+var extensionCoordinatesRegexp = regexp.MustCompile(`^[\w.\-]+:[\w.\-]+(:[\w.\-]+)?$`)
+
+// GetAddons gets the names of the add-ons of the workflow, the Platform ones first, without duplicates
+func GetAddons(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) []string {
+	var addons []string
+	if platform != nil {
+		addons = appendMissing(addons, platform.Spec.BuildTemplate.Addons...)
+	}
+	if workflow.Spec.Build != nil {
+		addons = appendMissing(addons, workflow.Spec.Build.Addons...)
+	}
+	return addons
+}
+
+// GetAddon gets the add-on from the catalog, false if unknown
+func GetAddon(name string) (Addon, bool) {
+	addon, ok := addonsCatalog[name]
+	return addon, ok
+}
+
+// ValidateBuildExtensions verifies that the Quarkus extensions of the workflow are valid coordinates and that its add-ons are known
+func ValidateBuildExtensions(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) error {
+	for _, extension := range getBuildExtensions(workflow, platform) {
+		if !extensionCoordinatesRegexp.MatchString(extension) {
+			return fmt.Errorf("invalid Quarkus extension %q, expected groupId:artifactId[:version]", extension)
+		}
+	}
+	for _, name := range GetAddons(workflow, platform) {
+		if _, ok := addonsCatalog[name]; !ok {
+			return fmt.Errorf("unknown add-on %q, supported add-ons are: %s", name, strings.Join(getAddonsCatalogNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// getBuildExtensions gets the Quarkus extensions set in the workflow and Platform build specs
+func getBuildExtensions(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) []string {
+	var extensions []string
+	if platform != nil {
+		extensions = appendMissing(extensions, platform.Spec.BuildTemplate.Extensions...)
+	}
+	if workflow.Spec.Build != nil {
+		extensions = appendMissing(extensions, workflow.Spec.Build.Extensions...)
+	}
+	return extensions
+}
+
+func getAddonsCatalogNames() []string {
+	names := make([]string, 0, len(addonsCatalog))
+	for name := range addonsCatalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// appendMissing appends the values not in the slice yet, keeping their order
+func appendMissing(slice []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range slice {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			slice = append(slice, value)
+		}
+	}
+	return slice
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflowdef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+	"github.com/kiegroup/kogito-serverless-operator/test"
+)
+
+func TestGetQuarkusExtensionsWithAddons(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	platform := test.GetKogitoServerlessPlatformInReadyPhase("../../config/samples/"+test.KogitoServerlessPlatformYamlCR, t.Name())
+	platform.Spec.BuildTemplate.Addons = []string{"kafka"}
+	platform.Spec.BuildTemplate.Extensions = []string{"io.quarkus:quarkus-smallrye-health"}
+	workflow.Spec.Build = &operatorapi.WorkflowBuildSpec{
+		Addons:     []string{"source-files", "kafka"},
+		Extensions: []string{"io.quarkus:quarkus-smallrye-health", "org.acme:my-extension:1.0.0"},
+	}
+
+	assert.NoError(t, ValidateBuildExtensions(workflow, platform))
+	assert.Equal(t, []string{"kafka", "source-files"}, GetAddons(workflow, platform))
+	assert.Equal(t, []string{
+		"org.kie.kogito:kogito-addons-quarkus-messaging",
+		"io.quarkus:quarkus-smallrye-reactive-messaging-kafka",
+		"org.kie.kogito:kogito-addons-quarkus-source-files",
+		"io.quarkus:quarkus-smallrye-health",
+		"org.acme:my-extension:1.0.0",
+	}, GetQuarkusExtensions(workflow, platform))
+}
+
+func TestValidateBuildExtensions(t *testing.T) {
+	workflow := test.GetKogitoServerlessWorkflow("../../config/samples/"+test.KogitoServerlessWorkflowSampleYamlCR, t.Name())
+	assert.NoError(t, ValidateBuildExtensions(workflow, nil))
+
+	workflow.Spec.Build = &operatorapi.WorkflowBuildSpec{Addons: []string{"unknown"}}
+	err := ValidateBuildExtensions(workflow, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "kafka, knative-eventing, kubernetes")
+
+	for _, extension := range []string{"quarkus-smallrye-health", "io.quarkus:a,io.quarkus:b", "io.quarkus:quarkus-smallrye-health:1.0:extra"} {
+		workflow.Spec.Build = &operatorapi.WorkflowBuildSpec{Extensions: []string{extension}}
+		assert.Error(t, ValidateBuildExtensions(workflow, nil), extension)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflowdef
+
+import (
+	"errors"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+// GetBuildMode gets how the workflow is compiled, either from the workflow or from the platform, which can be nil.
+// It defaults to operatorapi.BuildModeJVM.
+func GetBuildMode(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) operatorapi.BuildMode {
+	if workflow.Spec.Build != nil && len(workflow.Spec.Build.Mode) > 0 {
+		return workflow.Spec.Build.Mode
+	}
+	if platform != nil && len(platform.Spec.BuildTemplate.Mode) > 0 {
+		return platform.Spec.BuildTemplate.Mode
+	}
+	return operatorapi.BuildModeJVM
+}
+
+// GetBuildPlatforms gets the platforms the workflow image is built for, either from the workflow or from the platform, which can be nil.
+// It returns nil when the image is built for the platform of the builder.
+func GetBuildPlatforms(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) []string {
+	if workflow.Spec.Build != nil && len(workflow.Spec.Build.Platforms) > 0 {
+		return workflow.Spec.Build.Platforms
+	}
+	if platform != nil {
+		return platform.Spec.BuildTemplate.Platforms
+	}
+	return nil
+}
+
+// GetBuildAttestation gets how the workflow image is attested, either from the workflow or from the platform, which can be nil.
+// It returns nil when the image isn't attested.
+func GetBuildAttestation(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) *operatorapi.AttestationSpec {
+	if workflow.Spec.Build != nil && workflow.Spec.Build.Attestation != nil {
+		return workflow.Spec.Build.Attestation
+	}
+	if platform != nil {
+		return platform.Spec.BuildTemplate.Attestation
+	}
+	return nil
+}
+
+// ValidateBuildAttestation verifies that the workflow image can be attested: the attestation, even when inherited from the platform,
+// applies to a single image, so it can't be combined with a multi-platform build.
+func ValidateBuildAttestation(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) error {
+	if GetBuildAttestation(workflow, platform) != nil && len(GetBuildPlatforms(workflow, platform)) > 1 {
+		return errors.New("image attestation isn't supported by multi-platform builds, the workflow must be built for a single platform or without attestation")
+	}
+	return nil
+}
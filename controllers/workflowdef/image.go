@@ -25,6 +25,10 @@ const (
 	nightlySuffix               = "nightly"
 	defaultWorkflowDevModeImage = "quay.io/kiegroup/kogito-swf-devmode"
 	defaultWorkflowBuilderImage = "quay.io/kiegroup/kogito-swf-builder"
+	defaultDataIndexImage       = "quay.io/kiegroup/kogito-data-index"
+	defaultJobServiceImage      = "quay.io/kiegroup/kogito-jobs-service"
+	ephemeralImageSuffix        = "-ephemeral"
+	postgreSQLImageSuffix       = "-postgresql"
 )
 
 // GetWorkflowAppImageNameTag retrieve the tag for the image based on the Workflow based annotation, <workflowid>:latest otherwise
@@ -44,6 +48,23 @@ func GetDefaultWorkflowBuilderImageTag() string {
 	return getDefaultImageTag(defaultWorkflowBuilderImage)
 }
 
+// GetDefaultDataIndexImageTag gets the Data Index image, either persisting its data in PostgreSQL or in memory
+func GetDefaultDataIndexImageTag(persistent bool) string {
+	return getDefaultImageTag(defaultDataIndexImage + getPersistenceImageSuffix(persistent))
+}
+
+// GetDefaultJobServiceImageTag gets the Jobs Service image, either persisting its data in PostgreSQL or in memory
+func GetDefaultJobServiceImageTag(persistent bool) string {
+	return getDefaultImageTag(defaultJobServiceImage + getPersistenceImageSuffix(persistent))
+}
+
+func getPersistenceImageSuffix(persistent bool) string {
+	if persistent {
+		return postgreSQLImageSuffix
+	}
+	return ephemeralImageSuffix
+}
+
 func getDefaultImageTag(imgTag string) string {
 	if version.IsSnapshot() {
 		imgTag += "-" + nightlySuffix
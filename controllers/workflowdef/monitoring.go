@@ -0,0 +1,44 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflowdef
+
+import (
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+// micrometerPrometheusExtension exposes the workflow application metrics in the Prometheus format in `/q/metrics`.
+// See: https://quarkus.io/guides/micrometer
+const micrometerPrometheusExtension = "io.quarkus:quarkus-micrometer-registry-prometheus"
+
+// GetMonitoring gets the monitoring of the workflow application, either from the workflow or from the platform, which can be nil.
+// It returns nil if the workflow application metrics aren't scraped.
+func GetMonitoring(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) *operatorapi.MonitoringSpec {
+	monitoring := workflow.Spec.Monitoring
+	if monitoring == nil && platform != nil {
+		monitoring = platform.Spec.Monitoring
+	}
+	if monitoring == nil || !monitoring.Enabled {
+		return nil
+	}
+	return monitoring
+}
+
+// GetMonitoringType gets the kind of Prometheus Operator object scraping the workflow application metrics
+func GetMonitoringType(monitoring *operatorapi.MonitoringSpec) operatorapi.MonitoringType {
+	if len(monitoring.Type) == 0 {
+		return operatorapi.MonitoringTypeServiceMonitor
+	}
+	return monitoring.Type
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflowdef
+
+import (
+	"errors"
+	"fmt"
+
+	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
+)
+
+const (
+	defaultPostgreSQLPort         = 5432
+	defaultPostgreSQLDatabaseName = "kogito"
+	defaultPostgreSQLUserKey      = "username"
+	defaultPostgreSQLPasswordKey  = "password"
+)
+
+var (
+	// postgreSQLPersistenceExtensions are the Quarkus extensions required to persist the workflow instances in PostgreSQL
+	// See: https://kiegroup.github.io/kogito-docs/serverlessworkflow/latest/persistence/persistence-with-postgresql.html
+	postgreSQLPersistenceExtensions = []string{
+		"org.kie.kogito:kogito-addons-quarkus-persistence-jdbc",
+		"io.quarkus:quarkus-jdbc-postgresql",
+		"io.quarkus:quarkus-agroal",
+	}
+	flywayExtension = "io.quarkus:quarkus-flyway"
+	// httpMessagingExtension sends the workflow events to the platform services over HTTP
+	httpMessagingExtension = "io.quarkiverse.reactivemessaging.http:quarkus-reactive-messaging-http"
+	// dataIndexExtension publishes the workflow instances events consumed by the Data Index
+	dataIndexExtension = "org.kie.kogito:kogito-addons-quarkus-events-process"
+	// jobServiceExtension delegates the workflow timers to the Jobs Service
+	jobServiceExtension = "org.kie.kogito:kogito-addons-quarkus-jobs-management"
+)
+
+// GetPersistence gets the persistence of the workflow instances, either from the workflow or from the platform, which can be nil.
+// It returns nil if the workflow instances aren't persisted.
+func GetPersistence(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) *operatorapi.PersistenceSpec {
+	persistence := workflow.Spec.Persistence
+	if persistence == nil && platform != nil {
+		persistence = platform.Spec.Persistence
+	}
+	if persistence == nil || persistence.PostgreSQL == nil {
+		return nil
+	}
+	return persistence
+}
+
+// GetPersistenceMigration gets the migration of the database schema of the given persistence
+func GetPersistenceMigration(persistence *operatorapi.PersistenceSpec) operatorapi.PersistenceMigration {
+	if len(persistence.Migration) == 0 {
+		return operatorapi.PersistenceMigrationStartup
+	}
+	return persistence.Migration
+}
+
+// GetPostgreSQLJdbcURL gets the JDBC URL of the given PostgreSQL persistence.
+// The namespace and schema are the defaults for the service reference, if not set.
+func GetPostgreSQLJdbcURL(postgreSQL *operatorapi.PostgreSQLPersistenceSpec, namespace, schema string) (string, error) {
+	if len(postgreSQL.JdbcURL) > 0 {
+		return postgreSQL.JdbcURL, nil
+	}
+	service := postgreSQL.ServiceRef
+	if service == nil {
+		return "", errors.New("the PostgreSQL persistence requires either a jdbcUrl or a serviceRef")
+	}
+	if len(service.Namespace) > 0 {
+		namespace = service.Namespace
+	}
+	port := int32(defaultPostgreSQLPort)
+	if service.Port != nil {
+		port = *service.Port
+	}
+	database := service.DatabaseName
+	if len(database) == 0 {
+		database = defaultPostgreSQLDatabaseName
+	}
+	if len(service.DatabaseSchema) > 0 {
+		schema = service.DatabaseSchema
+	}
+	return fmt.Sprintf("jdbc:postgresql://%s.%s:%d/%s?currentSchema=%s", service.Name, namespace, port, database, schema), nil
+}
+
+// GetPostgreSQLSecretKeys gets the keys holding the user and the password in the PostgreSQL credentials Secret
+func GetPostgreSQLSecretKeys(secretRef operatorapi.PostgreSQLSecretReference) (userKey, passwordKey string) {
+	userKey = secretRef.UserKey
+	if len(userKey) == 0 {
+		userKey = defaultPostgreSQLUserKey
+	}
+	passwordKey = secretRef.PasswordKey
+	if len(passwordKey) == 0 {
+		passwordKey = defaultPostgreSQLPasswordKey
+	}
+	return userKey, passwordKey
+}
+
+// GetQuarkusExtensions gets the Quarkus extensions to add to the workflow application when building it.
+// Besides the ones required by the operator features, it includes the add-ons and extensions of the workflow and Platform build specs.
+func GetQuarkusExtensions(workflow *operatorapi.KogitoServerlessWorkflow, platform *operatorapi.KogitoServerlessPlatform) []string {
+	var extensions []string
+	if persistence := GetPersistence(workflow, platform); persistence != nil {
+		extensions = append(extensions, postgreSQLPersistenceExtensions...)
+		if GetPersistenceMigration(persistence) != operatorapi.PersistenceMigrationNone {
+			extensions = append(extensions, flywayExtension)
+		}
+	}
+	if GetMonitoring(workflow, platform) != nil {
+		extensions = append(extensions, micrometerPrometheusExtension)
+	}
+	if platform != nil && platform.Spec.Services != nil {
+		dataIndex := platform.Spec.Services.DataIndex.IsEnabled()
+		jobService := platform.Spec.Services.JobService.IsEnabled()
+		if dataIndex || jobService {
+			extensions = append(extensions, httpMessagingExtension)
+		}
+		if dataIndex {
+			extensions = append(extensions, dataIndexExtension)
+		}
+		if jobService {
+			extensions = append(extensions, jobServiceExtension)
+		}
+	}
+	for _, name := range GetAddons(workflow, platform) {
+		if addon, ok := GetAddon(name); ok {
+			extensions = appendMissing(extensions, addon.Extensions...)
+		}
+	}
+	return appendMissing(extensions, getBuildExtensions(workflow, platform)...)
+}
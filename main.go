@@ -15,24 +15,30 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
+	"time"
 
 	"github.com/kiegroup/kogito-serverless-operator/utils"
 
 	"github.com/kiegroup/kogito-serverless-operator/controllers"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/metrics"
+	"github.com/kiegroup/kogito-serverless-operator/controllers/tracing"
 	ocputil "github.com/kiegroup/kogito-serverless-operator/utils/openshift"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 	//+kubebuilder:scaffold:imports
@@ -46,6 +52,8 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(operatorapi.AddToScheme(scheme))
+	utilruntime.Must(gwapi.AddToScheme(scheme))
+	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -53,11 +61,18 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var tracingConfig tracing.Config
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&tracingConfig.Exporter, "tracing-exporter", tracing.ExporterNone,
+		"The OpenTelemetry traces exporter, one of \"none\", \"otlp\" or \"file\".")
+	flag.StringVar(&tracingConfig.Endpoint, "tracing-endpoint", "",
+		"The OpenTelemetry collector address for the otlp exporter. Defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable.")
+	flag.BoolVar(&tracingConfig.Insecure, "tracing-insecure", false, "Disable TLS when connecting to the OpenTelemetry collector.")
+	flag.StringVar(&tracingConfig.File, "tracing-file", "", "The file the traces are written to by the file exporter.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -66,6 +81,19 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			setupLog.Error(err, "unable to flush the traces")
+		}
+	}()
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
@@ -112,6 +140,11 @@ func main() {
 	}
 	//+kubebuilder:scaffold:builder
 
+	if err = metrics.RegisterCollector(mgr.GetClient()); err != nil {
+		setupLog.Error(err, "unable to register the operator metrics")
+		os.Exit(1)
+	}
+
 	if utils.IsOpenShift() {
 		ocputil.MustAddToScheme(mgr.GetScheme())
 	}
@@ -21,14 +21,18 @@ import (
 	buildv1 "github.com/openshift/api/build/v1"
 	imgv1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 )
@@ -37,6 +41,19 @@ import (
 func NewKogitoClientBuilder() *fake.ClientBuilder {
 	s := scheme.Scheme
 	utilruntime.Must(operatorapi.AddToScheme(s))
+	utilruntime.Must(gwapi.AddToScheme(s))
+	utilruntime.Must(monitoringv1.AddToScheme(s))
+	return fake.NewClientBuilder().WithScheme(s)
+}
+
+// NewKogitoClientBuilderWithoutOpenShift creates a new fake.ClientBuilder with a dedicated scheme free of the OpenShift
+// types registered by other tests, like the operator scheme on Kubernetes clusters.
+func NewKogitoClientBuilderWithoutOpenShift() *fake.ClientBuilder {
+	s := runtime.NewScheme()
+	utilruntime.Must(scheme.AddToScheme(s))
+	utilruntime.Must(operatorapi.AddToScheme(s))
+	utilruntime.Must(gwapi.AddToScheme(s))
+	utilruntime.Must(monitoringv1.AddToScheme(s))
 	return fake.NewClientBuilder().WithScheme(s)
 }
 
@@ -48,6 +65,8 @@ func NewKogitoClientBuilderWithOpenShift() *fake.ClientBuilder {
 	utilruntime.Must(buildv1.Install(s))
 	utilruntime.Must(imgv1.Install(s))
 	utilruntime.Must(operatorapi.AddToScheme(s))
+	utilruntime.Must(gwapi.AddToScheme(s))
+	utilruntime.Must(monitoringv1.AddToScheme(s))
 	return fake.NewClientBuilder().WithScheme(s)
 }
 
@@ -66,6 +85,16 @@ func MustGetConfigMap(t *testing.T, client ctrl.WithWatch, workflow *operatorapi
 	return mustGet(t, client, workflow, cm).(*v1.ConfigMap)
 }
 
+func MustGetIngress(t *testing.T, client ctrl.WithWatch, workflow *operatorapi.KogitoServerlessWorkflow) *networkingv1.Ingress {
+	ingress := &networkingv1.Ingress{}
+	return mustGet(t, client, workflow, ingress).(*networkingv1.Ingress)
+}
+
+func MustGetHTTPRoute(t *testing.T, client ctrl.WithWatch, workflow *operatorapi.KogitoServerlessWorkflow) *gwapi.HTTPRoute {
+	route := &gwapi.HTTPRoute{}
+	return mustGet(t, client, workflow, route).(*gwapi.HTTPRoute)
+}
+
 func MustGetWorkflow(t *testing.T, client ctrl.WithWatch, name types.NamespacedName) *operatorapi.KogitoServerlessWorkflow {
 	workflow := &operatorapi.KogitoServerlessWorkflow{}
 	workflow.Name = name.Name
@@ -24,6 +24,7 @@ import (
 
 	"github.com/kiegroup/kogito-serverless-operator/container-builder/util/log"
 
+	"github.com/kiegroup/kogito-serverless-operator/api"
 	operatorapi "github.com/kiegroup/kogito-serverless-operator/api/v1alpha08"
 )
 
@@ -80,9 +81,27 @@ func GetKogitoServerlessPlatform(path string) *operatorapi.KogitoServerlessPlatf
 	return ksp
 }
 
+func GetKogitoServerlessClusterPlatform(path string) *operatorapi.KogitoServerlessClusterPlatform {
+	kscp := &operatorapi.KogitoServerlessClusterPlatform{}
+	yamlFile, err := os.ReadFile(path)
+	if err != nil {
+		log.Errorf(err, "yamlFile.Get err #%v ", err)
+		panic(err)
+	}
+	err = yaml.NewYAMLOrJSONDecoder(bytes.NewReader(yamlFile), 100).Decode(kscp)
+	if err != nil {
+		log.Errorf(err, "Unmarshal: %v", err)
+		panic(err)
+	}
+	log.Debugf("Successfully read KSCP  #%v ", kscp)
+	return kscp
+}
+
 func GetKogitoServerlessPlatformInReadyPhase(path string, namespace string) *operatorapi.KogitoServerlessPlatform {
 	ksp := GetKogitoServerlessPlatform(path)
-	ksp.Status.Phase = operatorapi.PlatformPhaseReady
+	ksp.Status.Manager().MarkTrue(api.SucceedConditionType)
+	ksp.Status.Manager().InitializeConditions()
+	ksp.Status.UpdatePhase()
 	ksp.Namespace = namespace
 	return ksp
 }
@@ -31,3 +31,20 @@ func CreateOrReplaceEnv(container *v1.Container, name, value string) {
 		})
 	}
 }
+
+// CreateOrReplaceEnvFromSecret sets the given env var to the value of the Secret's key
+func CreateOrReplaceEnvFromSecret(container *v1.Container, name, secretName, key string) {
+	env := v1.EnvVar{
+		Name: name,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: secretName}, Key: key},
+		},
+	}
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			container.Env[i] = env
+			return
+		}
+	}
+	container.Env = append(container.Env, env)
+}
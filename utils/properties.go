@@ -0,0 +1,30 @@
+// Copyright 2023 Red Hat, Inc. and/or its affiliates
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import "github.com/magiconair/properties"
+
+// NewProperties creates the properties without expanding the `${...}` expressions, which are resolved by Quarkus.
+func NewProperties() *properties.Properties {
+	props := properties.NewProperties()
+	props.DisableExpansion = true
+	return props
+}
+
+// LoadProperties parses the properties without expanding the `${...}` expressions, which are resolved by Quarkus.
+func LoadProperties(props string) (*properties.Properties, error) {
+	loader := &properties.Loader{Encoding: properties.UTF8, DisableExpansion: true}
+	return loader.LoadBytes([]byte(props))
+}